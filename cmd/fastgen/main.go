@@ -0,0 +1,73 @@
+// Command fastgen generates Go structs, and decode functions specialised to each template, from a FAST templates XML file. It is intended to be run
+// with go generate, i.e.
+//
+//	//go:generate go run github.com/Guardian-Development/fastengine/cmd/fastgen -template templates.xml -output templates_fast.go -templates 141,153,122
+//
+// Only one generated file should be placed in each package, as each declares the Decoder of its templates.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/template/codegen"
+)
+
+func main() {
+	templateFile := flag.String("template", "", "FAST templates XML file to generate code from")
+	output := flag.String("output", "", "file to write the generated code to, by default this is stdout")
+	packageName := flag.String("package", os.Getenv("GOPACKAGE"), "package of the generated code, by default this is the package go generate is run in")
+	templates := flag.String("templates", "", "comma separated template IDs to generate code for, by default every template in the file")
+	flag.Parse()
+
+	logger := log.New(os.Stderr, "fastgen: ", 0)
+	if *templateFile == "" || *packageName == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	templateIDs, err := parseTemplateIDs(*templates)
+	if err != nil {
+		logger.Fatalf("unable to parse template IDs, reason: %v", err)
+	}
+
+	file, err := os.Open(*templateFile)
+	if err != nil {
+		logger.Fatalf("unable to open template file, reason: %v", err)
+	}
+	defer file.Close()
+
+	source, err := codegen.Generate(file, codegen.Options{Package: *packageName, Source: filepath.Base(*templateFile), TemplateIDs: templateIDs}, logger)
+	if err != nil {
+		logger.Fatalf("unable to generate code, reason: %v", err)
+	}
+
+	if *output == "" {
+		os.Stdout.Write(source)
+		return
+	}
+	if err := ioutil.WriteFile(*output, source, 0644); err != nil {
+		logger.Fatalf("unable to write generated code, reason: %v", err)
+	}
+}
+
+func parseTemplateIDs(templates string) ([]uint32, error) {
+	var templateIDs []uint32
+	for _, templateID := range strings.Split(templates, ",") {
+		if strings.TrimSpace(templateID) == "" {
+			continue
+		}
+		parsed, err := strconv.ParseUint(strings.TrimSpace(templateID), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a template ID", templateID)
+		}
+		templateIDs = append(templateIDs, uint32(parsed))
+	}
+	return templateIDs, nil
+}
@@ -0,0 +1,65 @@
+package engine
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+// BatchResult of decoding a single message within a batch
+type BatchResult struct {
+	Message *fix.Message
+	Err     error
+}
+
+// DecodeBatch decodes every framed message in messages, spreading the messages across the given number of workers (each using its own engine from the pool).
+// The dictionary of previous values is reset before every message, as it is for every message a pool decodes, so messages can be decoded in any
+// order and decode the same on any worker. Results are returned in the same order as messages, a message that fails to decode does not stop the rest of
+// the batch being decoded. If workers is less than 1, a worker is used per CPU. With WithZeroCopy the messages returned hold views into messages, which
+// are valid until messages are reused.
+func (pool *Pool) DecodeBatch(messages [][]byte, workers int) []BatchResult {
+	results := make([]BatchResult, len(messages))
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(messages) {
+		workers = len(messages)
+	}
+
+	var next int64 = -1
+	wait := sync.WaitGroup{}
+	for worker := 0; worker < workers; worker++ {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+
+			engine := pool.get()
+			defer pool.put(engine)
+
+			cursor := decoder.NewCursor(nil)
+			for {
+				index := int(atomic.AddInt64(&next, 1))
+				if index >= len(messages) {
+					return
+				}
+
+				cursor.Reset(messages[index])
+				engine.globalDictionary.Reset()
+				message, err := engine.Deserialise(cursor)
+				if err != nil {
+					pool.logger.Printf("unable to decode message %d in batch: %v", index, err)
+					results[index] = BatchResult{Err: fmt.Errorf("unable to decode message %d in batch, reason: %v", index, err)}
+					continue
+				}
+				results[index] = BatchResult{Message: message}
+			}
+		}()
+	}
+	wait.Wait()
+
+	return results
+}
@@ -0,0 +1,82 @@
+package engine
+
+import (
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCanDecodeBatchInInputOrder(t *testing.T) {
+	// Arrange
+	logger := log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)
+	messages := readHexMessages(t, "../../test/example-decoding-tests/snapshot-messages-hex.txt")
+	messages = append(messages, readHexMessages(t, "../../test/example-decoding-tests/instrument-messages-hex.txt")...)
+	expected := decodeSequentially(t, messages, logger)
+	pool, err := NewPoolFromTemplateFile("../../test/example-decoding-tests/templates.xml", WithLogger(logger))
+	if err != nil {
+		t.Fatalf("unable to load pool: %v", err)
+	}
+
+	for _, workers := range []int{0, 1, 4, 16} {
+		// Act
+		results := pool.DecodeBatch(messages, workers)
+
+		// Assert
+		if len(results) != len(messages) {
+			t.Fatalf("Expected a result for every message, workers: %d, expected: %d, actual: %d", workers, len(messages), len(results))
+		}
+		for index, result := range results {
+			if result.Err != nil {
+				t.Fatalf("Got an error when none was expected, workers: %d, message: %d, error: %s", workers, index, result.Err)
+			}
+			if result.Message.String() != expected[index] {
+				t.Fatalf("Expected message and batch decoded message were not equal, workers: %d, message: %d, expected: %s, actual: %s", workers, index, expected[index], result.Message.String())
+			}
+		}
+	}
+}
+
+func TestDecodeBatchReportsFailedMessageWithoutStoppingBatch(t *testing.T) {
+	// Arrange
+	/*
+		Message format:
+		11000000           pmap
+		00000001 10010000  template 144 (150 is not in store)
+		10001010           34 = 10
+		10001011           52 = 11
+	*/
+	messages := [][]byte{
+		{192, 1, 144, 138, 139},
+		{192, 1, 150, 138, 139},
+		{192, 1, 144, 140, 141},
+	}
+	pool, _ := NewPoolFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+
+	// Act
+	results := pool.DecodeBatch(messages, 2)
+
+	// Assert
+	if results[0].Err != nil || results[0].Message.String() != "1128=9|35=0|34=10|52=11|" {
+		t.Errorf("Expected first message to be decoded, but got: %#v", results[0])
+	}
+	if results[1].Err == nil || !strings.Contains(results[1].Err.Error(), "message 1 ") {
+		t.Errorf("Expected error naming message 1 as the message that failed to decode, but got: %v", results[1].Err)
+	}
+	if results[2].Err != nil || results[2].Message.String() != "1128=9|35=0|34=12|52=13|" {
+		t.Errorf("Expected third message to be decoded, but got: %#v", results[2])
+	}
+}
+
+func TestDecodeBatchOfNoMessagesReturnsNoResults(t *testing.T) {
+	// Arrange
+	pool, _ := NewPoolFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+
+	// Act
+	results := pool.DecodeBatch([][]byte{}, 4)
+
+	// Assert
+	if len(results) != 0 {
+		t.Errorf("Expected no results for an empty batch, but got: %d", len(results))
+	}
+}
@@ -8,71 +8,414 @@ import (
 
 	"github.com/Guardian-Development/fastengine/pkg/fast/errors"
 
+	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
 	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
 	"github.com/Guardian-Development/fastengine/pkg/fast/header"
+	"github.com/Guardian-Development/fastengine/pkg/fast/presencemap"
 	"github.com/Guardian-Development/fastengine/pkg/fast/template/loader"
+	"github.com/Guardian-Development/fastengine/pkg/fast/template/program"
 	"github.com/Guardian-Development/fastengine/pkg/fast/template/store"
 	"github.com/Guardian-Development/fastengine/pkg/fix"
 )
 
-// FastEngine capable of deserialising a fast encoded message from the given byte buffer.
+// FastEngine capable of deserialising a fast encoded message from the given reader, and serialising a fix message into fast encoded bytes.
 // This is not thread safe, and should only be called from a single threaded context, due to the fast engine making
 // use of a dictionary of previous values
 type FastEngine interface {
-	Deserialise(message *bytes.Buffer) (*fix.Message, error)
+	Deserialise(message decoder.Reader) (*fix.Message, error)
+	DeserialiseWithTemplateID(message decoder.Reader) (uint32, *fix.Message, error)
+	DeserialiseInto(message decoder.Reader, v interface{}) (uint32, error)
+	DeserialiseReusing(message decoder.Reader, fixMessage *fix.Message) (uint32, error)
+	Visit(message decoder.Reader, visitor store.Visitor) (uint32, error)
+	Serialise(message *fix.Message, templateID uint32) ([]byte, error)
+	DecodeAll(datagram []byte) *MessageIterator
+	Counters() decoder.Counters
+	Reset()
+	Snapshot() ([]byte, error)
+	Restore(snapshot []byte) error
+	Inspect() []dictionary.Inspection
+	DeserialiseRecording(message decoder.Reader) (*fix.Message, []dictionary.Write, error)
+}
+
+// UnknownTemplateError is returned when a message is encoded with a template ID that does not exist within the template store (D9)
+type UnknownTemplateError struct {
+	TemplateID uint32
+}
+
+func (err UnknownTemplateError) Error() string {
+	return fmt.Sprintf("%s: id %d", errors.D9, err.TemplateID)
+}
+
+// HeaderError is returned when the header of a message can not be read, so the message has no template ID. The template ID returned with a HeaderError
+// is always 0, which is not the template ID of the message.
+type HeaderError struct {
+	Reason error
+}
+
+func (err HeaderError) Error() string {
+	return fmt.Sprintf("unable to parse message, reason: %v", err.Reason)
+}
+
+// Unwrap the reason the header could not be read
+func (err HeaderError) Unwrap() error {
+	return err.Reason
 }
 
 type fastEngine struct {
-	templateStore    store.Store
-	globalDictionary dictionary.Dictionary
+	templateStore     store.Store
+	programs          map[uint32]program.Program
+	globalDictionary  dictionary.Dictionary
+	encoderDictionary dictionary.Dictionary
 
-	logger *log.Logger
+	options     options
+	settings    *decoder.SettingsReader
+	projections map[uint32]store.Projection
+	names       map[uint32]map[string]uint64
+	logger      *log.Logger
+
+	// pMap, machine and projector are reused by every message decoded by DeserialiseReusing or Visit, or decoded with a projection
+	pMap      presencemap.PresenceMap
+	machine   program.Machine
+	projector store.ProjectingVisitor
 }
 
-// Deserialise takes a FAST encoded FIX message in bytes, decodes and turns it into a FIX message
+// Deserialise takes a FAST encoded FIX message in bytes, decodes and turns it into a FIX message. Only the bytes of this message are read, leaving the reader
+// positioned at the start of the next message. A *bytes.Buffer, *bufio.Reader, decoder.Cursor or decoder.CountingReader can be read from.
 // Expected message format: (PMap (1+ bytes), templateId (1 + bytes), Message encoded from template with templateId)
-func (engine fastEngine) Deserialise(message *bytes.Buffer) (*fix.Message, error) {
-	engine.globalDictionary.Reset()
+func (engine *fastEngine) Deserialise(message decoder.Reader) (*fix.Message, error) {
+	_, fixMessage, err := engine.DeserialiseWithTemplateID(message)
+	return fixMessage, err
+}
+
+// DeserialiseWithTemplateID decodes a FAST encoded FIX message in the same way as Deserialise, also returning the template ID the message was encoded with.
+// If the template ID is not within the template store, the template ID is returned along with an UnknownTemplateError.
+func (engine *fastEngine) DeserialiseWithTemplateID(message decoder.Reader) (uint32, *fix.Message, error) {
+	message = engine.reader(message)
+	defer engine.release()
+
+	messageHeader, _, err := engine.readHeader(message)
+	if err != nil {
+		return messageHeader.TemplateID, nil, err
+	}
+
+	_, templateProgram, _ := engine.template(messageHeader.TemplateID)
+	if projection, exists := engine.projections[messageHeader.TemplateID]; exists {
+		fixMessage := fix.New()
+		err := engine.project(message, messageHeader.PMap, templateProgram, projection, &fixMessage, false)
+		return messageHeader.TemplateID, &fixMessage, err
+	}
+
+	fixMessage, err := templateProgram.Deserialise(message, messageHeader.PMap, &engine.globalDictionary)
+	return messageHeader.TemplateID, fixMessage, err
+}
+
+// DeserialiseInto decodes a FAST encoded FIX message, and unmarshals it into v using fix.UnmarshalWithNames. Struct fields can be tagged with either the tag
+// or name of a field within the template the message was encoded with, i.e. `fast:"270"` or `fast:"MDEntryPx"`. The template ID of the message is returned.
+func (engine *fastEngine) DeserialiseInto(message decoder.Reader, v interface{}) (uint32, error) {
+	templateID, fixMessage, err := engine.DeserialiseWithTemplateID(message)
+	if err != nil {
+		return templateID, err
+	}
+
+	names, exists := engine.names[templateID]
+	if !exists {
+		names = engine.templateStore.Templates[templateID].TagsByName()
+		engine.names[templateID] = names
+	}
+
+	if err := fix.UnmarshalWithNames(fixMessage, v, names); err != nil {
+		engine.logger.Printf("unable to unmarshal message with template %d: %v", templateID, err)
+		return templateID, fmt.Errorf("unable to unmarshal message, reason: %v", err)
+	}
+	return templateID, nil
+}
+
+// DeserialiseReusing decodes a FAST encoded FIX message in the same way as DeserialiseWithTemplateID, into the message given rather than a new message. The
+// message is Reset and its tags, including the repeating groups of sequences, are set to typed values reused from the message before, so decoding a stream
+// of messages into the same message stops allocating once the message has held each template. Any value read from the message, including []byte values,
+// is only valid until the message is next decoded into. Templates with a projection store only the projected tags, set to typed values in the same way.
+func (engine *fastEngine) DeserialiseReusing(message decoder.Reader, fixMessage *fix.Message) (uint32, error) {
+	message = engine.reader(message)
+	defer engine.release()
+
+	templateID, templateProgram, err := engine.readProgramHeader(message)
+	if err != nil {
+		return templateID, err
+	}
+
+	if projection, exists := engine.projections[templateID]; exists {
+		fixMessage.Reset()
+		return templateID, engine.project(message, &engine.pMap, templateProgram, projection, fixMessage, true)
+	}
+
+	return templateID, templateProgram.DeserialiseReusing(message, &engine.pMap, &engine.globalDictionary, &engine.machine, fixMessage)
+}
+
+// Visit decodes a FAST encoded FIX message in the same way as Deserialise, but passes each value to the visitor as it is decoded rather than building a FIX message.
+// The template ID of the message is returned, so the visitor can be told which template the values it has been passed belong to. Each value is passed as the
+// *fix.TypedValue the program decoded it into, which is reused for the next value, so a value is only valid until OnField returns and visiting a stream of
+// messages stops allocating once the engine has visited each template. Templates with a projection are visited in full. If the header of the message can
+// not be read a HeaderError is returned.
+func (engine *fastEngine) Visit(message decoder.Reader, visitor store.Visitor) (uint32, error) {
+	message = engine.reader(message)
+	defer engine.release()
+
+	templateID, templateProgram, err := engine.readProgramHeader(message)
+	if err != nil {
+		return templateID, err
+	}
+
+	return templateID, templateProgram.Visit(message, &engine.pMap, &engine.globalDictionary, &engine.machine, visitor)
+}
+
+// project the message onto the projection of its template, by visiting the message with the program of the template. Only the values of projected tags
+// are stored in the fix message, so no other value is boxed.
+func (engine *fastEngine) project(message decoder.Reader, pMap *presencemap.PresenceMap, templateProgram program.Program, projection store.Projection,
+	fixMessage *fix.Message, typed bool) error {
+	engine.projector.Reset(projection, fixMessage, typed)
+	err := templateProgram.Visit(message, pMap, &engine.globalDictionary, &engine.machine, &engine.projector)
+	engine.projector.Reset(store.Projection{}, nil, false)
+	return err
+}
+
+// reader wraps the message in the settings the engine is configured with, the settings reader is reused between messages and only used when the engine
+// is not configured with the strict defaults. release must be called once the message has been read.
+func (engine *fastEngine) reader(message decoder.Reader) decoder.Reader {
+	if engine.settings == nil {
+		return message
+	}
+
+	engine.settings.Reader = message
+	return engine.settings
+}
+
+// release the message from the settings reader, so it is not held onto between messages
+func (engine *fastEngine) release() {
+	if engine.settings != nil {
+		engine.settings.Reader = nil
+	}
+}
+
+// template with the template id, along with the program compiled from it. The reset template is an empty template when it is not within the template store,
+// so reset messages are decoded and encoded without any fields rather than as an unknown template (D9).
+func (engine *fastEngine) template(templateID uint32) (store.Template, program.Program, bool) {
+	template, exists := engine.templateStore.Templates[templateID]
+	if !exists && templateID == engine.options.resetTemplateID {
+		return store.Template{Logger: engine.logger}, program.Program{}, true
+	}
+
+	return template, engine.programs[templateID], exists
+}
+
+// readHeader of the message, resetting the dictionary first if required, and returning the template the rest of the message is encoded with
+func (engine *fastEngine) readHeader(message decoder.Reader) (header.MessageHeader, store.Template, error) {
+	if engine.options.dictionaryReset == ResetPerMessage {
+		engine.globalDictionary.Reset()
+	}
 
 	messageHeader, err := header.New(message, &engine.globalDictionary, engine.logger)
 	if err != nil {
 		engine.logger.Printf("unable to deserialise header of message: %v", err)
-		return nil, fmt.Errorf("unable to parse message, reason: %v", err)
+		return header.MessageHeader{}, store.Template{}, HeaderError{Reason: err}
 	}
+	engine.resetOnDecode(messageHeader.TemplateID)
 
-	if template, exists := engine.templateStore.Templates[messageHeader.TemplateID]; exists {
-		return template.Deserialise(message, messageHeader.PMap, &engine.globalDictionary)
+	template, templateProgram, exists := engine.template(messageHeader.TemplateID)
+	if !exists {
+		engine.logger.Println("no template exists for id", messageHeader.TemplateID)
+		return messageHeader, store.Template{}, UnknownTemplateError{TemplateID: messageHeader.TemplateID}
 	}
 
-	engine.logger.Println("no template exists for id", messageHeader.TemplateID)
-	return nil, fmt.Errorf("%s: id %d", errors.D9, messageHeader.TemplateID)
+	if err := engine.checkPresenceMap(message, messageHeader.PMap, templateProgram); err != nil {
+		return messageHeader, store.Template{}, err
+	}
+	return messageHeader, template, nil
+}
+
+// readProgramHeader of the message in the same way as readHeader, into the presence map of the engine so the header is read without allocating, returning
+// the program the rest of the message is encoded with
+func (engine *fastEngine) readProgramHeader(message decoder.Reader) (uint32, program.Program, error) {
+	if engine.options.dictionaryReset == ResetPerMessage {
+		engine.globalDictionary.Reset()
+	}
+
+	templateID, err := header.Read(message, &engine.pMap, &engine.globalDictionary, engine.logger)
+	if err != nil {
+		engine.logger.Printf("unable to deserialise header of message: %v", err)
+		return 0, program.Program{}, HeaderError{Reason: err}
+	}
+	engine.resetOnDecode(templateID)
+
+	_, templateProgram, exists := engine.template(templateID)
+	if !exists {
+		engine.logger.Println("no template exists for id", templateID)
+		return templateID, program.Program{}, UnknownTemplateError{TemplateID: templateID}
+	}
+
+	if err := engine.checkPresenceMap(message, &engine.pMap, templateProgram); err != nil {
+		return templateID, program.Program{}, err
+	}
+	return templateID, templateProgram, nil
+}
+
+// checkPresenceMap of the message is neither overlong (R7) or too long (R8) for the template it is encoded with, the presence map of a message holds the
+// bit of the template id read by the header before the bits of the template
+func (engine *fastEngine) checkPresenceMap(message decoder.Reader, pMap *presencemap.PresenceMap, templateProgram program.Program) error {
+	if err := pMap.Check(message, 1+templateProgram.Layout().Bits()); err != nil {
+		engine.logger.Printf("presence map of message is invalid: %v", err)
+		return fmt.Errorf("unable to parse message, reason: %v", err)
+	}
+
+	return nil
+}
+
+// Serialise takes a FIX message, and encodes it into FAST encoded bytes using the template with the given templateID
+// Message format produced: (PMap (1+ bytes), templateId (1 + bytes), Message encoded from template with templateId)
+func (engine *fastEngine) Serialise(message *fix.Message, templateID uint32) ([]byte, error) {
+	template, _, exists := engine.template(templateID)
+	if !exists {
+		engine.logger.Println("no template exists for id", templateID)
+		return nil, UnknownTemplateError{TemplateID: templateID}
+	}
+
+	if engine.options.dictionaryReset == ResetPerMessage {
+		engine.encoderDictionary.Reset()
+	}
+
+	pMap := presencemap.PresenceMap{}
+	messageBody := bytes.Buffer{}
+	messageHeader := header.MessageHeader{PMap: &pMap, TemplateID: templateID}
+	if err := messageHeader.Serialise(&messageBody, &engine.encoderDictionary, engine.logger); err != nil {
+		engine.logger.Printf("unable to serialise header of message: %v", err)
+		return nil, fmt.Errorf("unable to serialise message, reason: %v", err)
+	}
+	engine.resetOnTemplate(templateID, &engine.encoderDictionary)
+
+	if err := template.Serialise(&messageBody, &pMap, &engine.encoderDictionary, message); err != nil {
+		engine.logger.Printf("unable to serialise message with template %d: %v", templateID, err)
+		return nil, fmt.Errorf("unable to serialise message, reason: %v", err)
+	}
+
+	encodedMessage := bytes.NewBuffer(pMap.Bytes())
+	encodedMessage.Write(messageBody.Bytes())
+	return encodedMessage.Bytes(), nil
+}
+
+// DecodeAll returns an iterator over every FAST encoded message within the datagram, where messages are sent back to back. With ResetPerPacket the
+// dictionary of previous values is reset before the first message of the datagram.
+func (engine *fastEngine) DecodeAll(datagram []byte) *MessageIterator {
+	if engine.options.dictionaryReset == ResetPerPacket {
+		engine.globalDictionary.Reset()
+	}
+
+	return &MessageIterator{
+		engine:   engine,
+		datagram: decoder.NewCursor(datagram),
+	}
+}
+
+// Reset the dictionaries of previous values used to deserialise and serialise messages, so every previous value is undefined. This is the trigger for
+// resetting the dictionaries at the start of each packet or block with ResetPerPacket, and can be called with any reset policy.
+func (engine *fastEngine) Reset() {
+	engine.globalDictionary.Reset()
+	engine.encoderDictionary.Reset()
 }
 
-// New instance of a FAST engine, that can serialise/deserialise FAST messages using the template store provided
-func New(templateStore store.Store, logger *log.Logger) FastEngine {
-	return fastEngine{
-		templateStore:    templateStore,
-		globalDictionary: dictionary.New(),
-		logger:           logger,
+// resetOnTemplate resets the dictionary once the header of a message has been read or written, if the message is encoded with the reset template
+// or a template marked reset="Y", whatever the reset policy of the engine. Returns whether the dictionary was reset.
+func (engine *fastEngine) resetOnTemplate(templateID uint32, dict *dictionary.Dictionary) bool {
+	if templateID != engine.options.resetTemplateID && !engine.templateStore.Templates[templateID].Reset {
+		return false
 	}
+
+	dict.Reset()
+	return true
+}
+
+// resetOnDecode resets the dictionary used to deserialise messages once the header of a message has been read, as resetOnTemplate does, passing the
+// template id of the message to the reset handler of the engine if the dictionary was reset
+func (engine *fastEngine) resetOnDecode(templateID uint32) {
+	if engine.resetOnTemplate(templateID, &engine.globalDictionary) && engine.options.onReset != nil {
+		engine.options.onReset(templateID)
+	}
+}
+
+// Counters of the recoverable spec violations counted while decoding messages, these are only counted when the engine is not strict
+func (engine *fastEngine) Counters() decoder.Counters {
+	return engine.options.counters.Load()
+}
+
+// New instance of a FAST engine, that can serialise/deserialise FAST messages using the template store provided, configured by the options given
+func New(templateStore store.Store, engineOptions ...Option) FastEngine {
+	templateStore = withSlots(templateStore)
+	return newEngine(templateStore, program.Compile(templateStore), newOptions(engineOptions))
+}
+
+// withSlots of the dictionary keys of the template store, which are resolved for a store that was built rather than loaded from a template file
+func withSlots(templateStore store.Store) store.Store {
+	if templateStore.Slots == nil {
+		templateStore.Slots = store.SlotsOf(templateStore.Templates)
+	}
+
+	return templateStore
+}
+
+// newEngine decoding messages by running the programs compiled from the template store, which are only read from so can be shared between engines
+func newEngine(templateStore store.Store, programs map[uint32]program.Program, options options) *fastEngine {
+	engine := &fastEngine{
+		templateStore:     templateStore,
+		programs:          programs,
+		globalDictionary:  dictionary.NewOf(templateStore.Slots),
+		encoderDictionary: dictionary.NewOf(templateStore.Slots),
+		options:           options,
+		names:             make(map[uint32]map[string]uint64),
+		logger:            options.logger,
+	}
+	if settings, required := options.settings(); required {
+		engine.settings = &decoder.SettingsReader{Settings: settings}
+	}
+	if len(options.projections) > 0 {
+		engine.projections = make(map[uint32]store.Projection, len(options.projections))
+		for templateID, tags := range options.projections {
+			template, exists := templateStore.Templates[templateID]
+			if !exists {
+				options.logger.Printf("no template exists for id %d, unable to project it", templateID)
+				continue
+			}
+			engine.projections[templateID] = template.Project(tags...)
+		}
+	}
+
+	return engine
 }
 
 // NewFromTemplateFile of a FAST engine, that can serialise/deserialise FAST messages using the template file provided.
 // This file should be xml, if we are unable to find the file or parse it, an error is returned
-func NewFromTemplateFile(templateFile string, logger *log.Logger) (FastEngine, error) {
+func NewFromTemplateFile(templateFile string, engineOptions ...Option) (FastEngine, error) {
+	resolvedOptions := newOptions(engineOptions)
+	templateStore, err := loadTemplateFile(templateFile, resolvedOptions.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return newEngine(templateStore, program.Compile(templateStore), resolvedOptions), nil
+}
+
+// loadTemplateFile into a template store, every field within the store logs to the logger given
+func loadTemplateFile(templateFile string, logger *log.Logger) (store.Store, error) {
 	file, err := os.Open(templateFile)
 
 	if err != nil {
 		logger.Println("unable to open template file")
-		return nil, fmt.Errorf("unable to open template file: %s", err)
+		return store.Store{}, fmt.Errorf("unable to open template file: %s", err)
 	}
 	defer file.Close()
 
 	templateStore, err := loader.Load(file, logger)
 	if err != nil {
 		logger.Println("unable to load template store")
-		return nil, fmt.Errorf("unable to load template file: %s", err)
+		return store.Store{}, fmt.Errorf("unable to load template file: %s", err)
 	}
-	fastEngine := New(templateStore, logger)
-	return fastEngine, nil
+
+	return templateStore, nil
 }
@@ -1,19 +1,21 @@
 package engine
 
 import (
+	"bufio"
 	"bytes"
 	"log"
 	"os"
 	"strings"
 	"testing"
 
+	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
 	"github.com/Guardian-Development/fastengine/pkg/fast/errors"
 )
 
 func TestTemplateIdNotFoundInTemplateStoreErrorReturned(t *testing.T) {
 	// Arrange
 	message := bytes.NewBuffer([]byte{192, 1, 150, 130, 210, 129, 210, 130, 131})
-	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile))
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
 
 	// Act
 	_, err := fastEngine.Deserialise(message)
@@ -34,7 +36,7 @@ func TestCanDeserialiseHeartbeatMessageBasedOnTemplateInTemplateStore(t *testing
 		10001011           52 = 11
 	*/
 	message := bytes.NewBuffer([]byte{192, 1, 144, 138, 139})
-	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile))
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
 
 	// Act
 	fixMessage, _ := fastEngine.Deserialise(message)
@@ -56,7 +58,7 @@ func TestCanDeserialiseMessageWithOptionalValueNotPresent(t *testing.T) {
 		10001010           52 = 10
 	*/
 	message := bytes.NewBuffer([]byte{192, 1, 144, 128, 138})
-	fastEngine, _ := NewFromTemplateFile("../../test/test_optional_value_template.xml", log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile))
+	fastEngine, _ := NewFromTemplateFile("../../test/test_optional_value_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
 
 	// Act
 	fixMessage, _ := fastEngine.Deserialise(message)
@@ -78,7 +80,7 @@ func TestCanDeserialiseMessageWithOptionalValuePresent(t *testing.T) {
 		10001010           52 = 10
 	*/
 	message := bytes.NewBuffer([]byte{192, 1, 144, 129, 138})
-	fastEngine, _ := NewFromTemplateFile("../../test/test_optional_value_template.xml", log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile))
+	fastEngine, _ := NewFromTemplateFile("../../test/test_optional_value_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
 
 	// Act
 	fixMessage, _ := fastEngine.Deserialise(message)
@@ -90,8 +92,137 @@ func TestCanDeserialiseMessageWithOptionalValuePresent(t *testing.T) {
 	}
 }
 
+func TestCanDeserialiseMessageFromByteSliceReportingOffset(t *testing.T) {
+	// Arrange
+	/*
+		Message format:
+		11000000           pmap
+		00000001 10010000  template 144
+		10001010           34 = 10
+		10001011           52 = 11
+		11000000           pmap (next message)
+	*/
+	message := decoder.NewCursor([]byte{192, 1, 144, 138, 139, 192})
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+
+	// Act
+	fixMessage, _ := fastEngine.Deserialise(message)
+
+	// Assert
+	fixMessageAsString := fixMessage.String()
+	if fixMessageAsString != "1128=9|35=0|34=10|52=11|" {
+		t.Errorf("Expected message and actual message were not equal, actual: %s", fixMessageAsString)
+	}
+	if message.Offset() != 5 {
+		t.Errorf("Expected offset to be at the end of the message, actual: %d", message.Offset())
+	}
+}
+
+func TestCanDeserialiseMessagesFromBufferedReader(t *testing.T) {
+	// Arrange
+	/*
+		Message format:
+		11000000           pmap
+		00000001 10010000  template 144
+		10001010           34 = 10
+		10001011           52 = 11
+		11000000           pmap
+		00000001 10010000  template 144
+		10001100           34 = 12
+		10001101           52 = 13
+	*/
+	message := decoder.NewCountingReader(bufio.NewReader(bytes.NewReader([]byte{192, 1, 144, 138, 139, 192, 1, 144, 140, 141})))
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+
+	// Act
+	firstMessage, _ := fastEngine.Deserialise(message)
+	secondMessage, _ := fastEngine.Deserialise(message)
+
+	// Assert
+	if firstMessage.String() != "1128=9|35=0|34=10|52=11|" || secondMessage.String() != "1128=9|35=0|34=12|52=13|" {
+		t.Errorf("Expected messages and actual messages were not equal, actual: %s, %s", firstMessage.String(), secondMessage.String())
+	}
+	if message.Offset() != 10 {
+		t.Errorf("Expected offset to be at the end of both messages, actual: %d", message.Offset())
+	}
+}
+
 // func printByteArrayAsBits(array *[]byte) {
 // 	for _, n := range *array {
 // 		fmt.Printf("% 08b", n)
 // 	}
 // }
+
+func TestFieldsWithTheSameNameInTemplateDictionariesKeepTheirOwnPreviousValues(t *testing.T) {
+	// Arrange
+	/*
+		Message format:
+		11100000  pmap
+		10000001  template 1, then 10000010 template 2
+		10000101  44 = 5, then 10000111 44 = 7
+		third message has no price: 11000000 pmap, 10000001 template 1
+	*/
+	fastEngine, _ := NewFromTemplateFile("../../test/test_template_dictionary_template.xml",
+		WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)),
+		WithDictionaryReset(ResetNever))
+	fastEngine.Deserialise(bytes.NewBuffer([]byte{224, 129, 133}))
+	fastEngine.Deserialise(bytes.NewBuffer([]byte{224, 130, 135}))
+
+	// Act
+	fixMessage, err := fastEngine.Deserialise(bytes.NewBuffer([]byte{192, 129}))
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+	if err == nil && fixMessage.String() != "44=5|" {
+		t.Errorf("Expected the price to be copied from the previous message of the same template, actual: %s", fixMessage.String())
+	}
+}
+
+func TestFieldsWithTheSameKeyShareTheirPreviousValue(t *testing.T) {
+	// Arrange
+	/*
+		Message format:
+		11100000  pmap, ask size not present
+		10000001  template 1
+		10000101  134 = 5
+	*/
+	message := bytes.NewBuffer([]byte{224, 129, 133})
+	fastEngine, _ := NewFromTemplateFile("../../test/test_shared_key_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+
+	// Act
+	fixMessage, err := fastEngine.Deserialise(message)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+	if err == nil && fixMessage.String() != "134=5|135=5|" {
+		t.Errorf("Expected the ask size to be copied from the bid size sharing its key, actual: %s", fixMessage.String())
+	}
+}
+
+func TestCanDeserialiseMessageWithTemplateRef(t *testing.T) {
+	// Arrange
+	/*
+		Message format:
+		11110000           pmap, 34 and 83 present
+		00000001 10010001  template 145
+		10000101           34 = 5
+		10000111           83 = 7
+	*/
+	message := bytes.NewBuffer([]byte{240, 1, 145, 133, 135})
+	fastEngine, _ := NewFromTemplateFile("../../test/test_template_ref_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+
+	// Act
+	fixMessage, err := fastEngine.Deserialise(message)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+	if err == nil && fixMessage.String() != "35=X|34=5|83=7|" {
+		t.Errorf("Expected the fields of the referenced header to be decoded before the fields of the template, actual: %s", fixMessage.String())
+	}
+}
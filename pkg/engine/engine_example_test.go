@@ -15,7 +15,7 @@ func TestCanReadInstrumentMessages(t *testing.T) {
 	defer file.Close()
 
 	logger := log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)
-	engine, err := NewFromTemplateFile("../../test/example-decoding-tests/templates.xml", logger)
+	engine, err := NewFromTemplateFile("../../test/example-decoding-tests/templates.xml", WithLogger(logger))
 	if err != nil {
 		t.Fatalf("unable to load engine: %v", err)
 	}
@@ -42,7 +42,7 @@ func TestCanReadSnapshotMessagesTest(t *testing.T) {
 	defer file.Close()
 
 	logger := log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)
-	engine, err := NewFromTemplateFile("../../test/example-decoding-tests/templates.xml", logger)
+	engine, err := NewFromTemplateFile("../../test/example-decoding-tests/templates.xml", WithLogger(logger))
 	if err != nil {
 		t.Fatalf("unable to load engine: %v", err)
 	}
@@ -0,0 +1,164 @@
+package engine
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/errors"
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+func TestSerialiseTemplateIdNotFoundInTemplateStoreErrorReturned(t *testing.T) {
+	// Arrange
+	message := fix.New()
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+
+	// Act
+	_, err := fastEngine.Serialise(&message, 150)
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), errors.D9) {
+		t.Errorf("Expected error message informing user template ID is not found in store for message, but got: %v", err)
+	}
+}
+
+func TestCanSerialiseHeartbeatMessageBasedOnTemplateInTemplateStore(t *testing.T) {
+	// Arrange
+	/*
+		Message format:
+		11000000           pmap
+		00000001 10010000  template 144
+		10001010           34 = 10
+		10001011           52 = 11
+	*/
+	expectedMessage := []byte{192, 1, 144, 138, 139}
+	message := fix.New()
+	message.SetTag(1128, fix.NewRawValue("9"))
+	message.SetTag(35, fix.NewRawValue("0"))
+	message.SetTag(34, fix.NewRawValue(uint32(10)))
+	message.SetTag(52, fix.NewRawValue(uint64(11)))
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+
+	// Act
+	result, err := fastEngine.Serialise(&message, 144)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+	if !reflect.DeepEqual(expectedMessage, result) {
+		t.Errorf("Expected message and actual message were not equal, expected: %v, actual: %v", expectedMessage, result)
+	}
+}
+
+func TestCanSerialiseMessageWithOptionalValueNotPresent(t *testing.T) {
+	// Arrange
+	/*
+		Message format:
+		11000000           pmap
+		00000001 10010000  template 144
+		10000000           34 = Nil
+		10001010           52 = 10
+	*/
+	expectedMessage := []byte{192, 1, 144, 128, 138}
+	message := fix.New()
+	message.SetTag(1128, fix.NewRawValue("9"))
+	message.SetTag(35, fix.NewRawValue("0"))
+	message.SetTag(52, fix.NewRawValue(uint64(10)))
+	fastEngine, _ := NewFromTemplateFile("../../test/test_optional_value_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+
+	// Act
+	result, err := fastEngine.Serialise(&message, 144)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+	if !reflect.DeepEqual(expectedMessage, result) {
+		t.Errorf("Expected message and actual message were not equal, expected: %v, actual: %v", expectedMessage, result)
+	}
+}
+
+func TestSerialiseMissingRequiredValueErrorReturned(t *testing.T) {
+	// Arrange
+	message := fix.New()
+	message.SetTag(1128, fix.NewRawValue("9"))
+	message.SetTag(35, fix.NewRawValue("0"))
+	message.SetTag(52, fix.NewRawValue(uint64(10)))
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+
+	// Act
+	_, err := fastEngine.Serialise(&message, 144)
+
+	// Assert
+	if err == nil {
+		t.Errorf("Expected error as required tag 34 is not present in the message")
+	}
+}
+
+func TestCanRoundTripSnapshotMessages(t *testing.T) {
+	assertCanRoundTripMessages(t, "../../test/example-decoding-tests/snapshot-messages-hex.txt")
+}
+
+func TestCanRoundTripInstrumentMessages(t *testing.T) {
+	assertCanRoundTripMessages(t, "../../test/example-decoding-tests/instrument-messages-hex.txt")
+}
+
+func assertCanRoundTripMessages(t *testing.T, messagesFile string) {
+	// Arrange
+	file, _ := os.Open(messagesFile)
+	defer file.Close()
+
+	logger := log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)
+	engine, err := NewFromTemplateFile("../../test/example-decoding-tests/templates.xml", WithLogger(logger))
+	if err != nil {
+		t.Fatalf("unable to load engine: %v", err)
+	}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		message, _ := hex.DecodeString(scanner.Text())
+		buffer := bytes.NewBuffer(message)
+		decoded, err := engine.Deserialise(buffer)
+		if err != nil {
+			t.Fatalf("unable to decode message: %s", scanner.Text())
+		}
+		templateID := templateIDOf(message)
+
+		// Act
+		encoded, err := engine.Serialise(decoded, templateID)
+		if err != nil {
+			t.Fatalf("unable to encode message %s, reason: %s", decoded, err)
+		}
+		reDecoded, err := engine.Deserialise(bytes.NewBuffer(encoded))
+
+		// Assert
+		if err != nil {
+			t.Fatalf("unable to decode encoded message %s, reason: %s", decoded, err)
+		}
+		if reDecoded.String() != decoded.String() {
+			t.Fatalf("Expected message and round tripped message were not equal, expected: %s, actual: %s", decoded, reDecoded)
+		}
+	}
+}
+
+func templateIDOf(message []byte) uint32 {
+	// skip the pmap, then read the stop bit encoded template id
+	index := 0
+	for message[index]&128 == 0 {
+		index++
+	}
+	var templateID uint32
+	for index = index + 1; ; index++ {
+		templateID = templateID<<7 | uint32(message[index]&127)
+		if message[index]&128 == 128 {
+			return templateID
+		}
+	}
+}
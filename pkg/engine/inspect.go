@@ -0,0 +1,26 @@
+package engine
+
+import (
+	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
+	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+// Inspect the dictionary of previous values used to deserialise messages, listing the entry of every dictionary key of the templates of the engine sorted
+// by dictionary, scope and key, with its state and value. This is intended for debugging a copy or delta field that decodes to a surprising value.
+func (engine *fastEngine) Inspect() []dictionary.Inspection {
+	return engine.globalDictionary.Inspect(engine.keys())
+}
+
+// DeserialiseRecording decodes a FAST encoded FIX message in the same way as Deserialise, also returning every write to the dictionary of previous values
+// made while decoding the message, in the order they were made, with the entry before and after each write.
+func (engine *fastEngine) DeserialiseRecording(message decoder.Reader) (*fix.Message, []dictionary.Write, error) {
+	var writes []dictionary.Write
+	engine.globalDictionary.OnWrite(func(write dictionary.Write) {
+		writes = append(writes, write)
+	})
+	defer engine.globalDictionary.OnWrite(nil)
+
+	fixMessage, err := engine.Deserialise(message)
+	return fixMessage, writes, err
+}
@@ -0,0 +1,70 @@
+package engine
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
+)
+
+func TestDeserialiseRecordingReturnsEveryWriteToTheDictionary(t *testing.T) {
+	// Arrange 11000000 pmap, 00000001 10010000 template id 144, 10001010 34 = 10, 10001011 52 = 11
+	logger := log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(logger), WithDictionaryReset(ResetNever))
+
+	// Act
+	fixMessage, writes, err := fastEngine.DeserialiseRecording(bytes.NewBuffer([]byte{192, 1, 144, 138, 139}))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Got an error decoding the message when none was expected: %s", err)
+	}
+	if fixMessage.String() != "1128=9|35=0|34=10|52=11|" {
+		t.Errorf("Expected the message to be decoded as it is by Deserialise, result: %s", fixMessage)
+	}
+	expected := []string{
+		"TemplateId=undefined -> TemplateId=144",
+		"144.ApplVerID=undefined -> 144.ApplVerID=9",
+		"144.MsgType=undefined -> 144.MsgType=0",
+		"144.MsgSeqNum=undefined -> 144.MsgSeqNum=10",
+		"144.SendingTime=undefined -> 144.SendingTime=11",
+	}
+	if len(writes) != len(expected) {
+		t.Fatalf("Expected %d writes to be recorded, result: %v", len(expected), writes)
+	}
+	for index, write := range writes {
+		if write.String() != expected[index] {
+			t.Errorf("Expected write %d to be %s, result: %s", index, expected[index], write)
+		}
+	}
+	_, writes, _ = fastEngine.DeserialiseRecording(bytes.NewBuffer([]byte{128, 140, 141}))
+	if len(writes) != len(expected) || writes[0].String() != "TemplateId=144 -> TemplateId=144" {
+		t.Errorf("Expected the copied template id to be recorded with the value it was copied from, result: %v", writes)
+	}
+}
+
+func TestInspectListsPreviousValuesOfEngine(t *testing.T) {
+	// Arrange
+	logger := log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(logger), WithDictionaryReset(ResetNever))
+	fastEngine.Deserialise(bytes.NewBuffer([]byte{192, 1, 144, 138, 139}))
+
+	// Act
+	inspections := fastEngine.Inspect()
+
+	// Assert
+	expected := []string{"144.ApplVerID=9", "144.MsgSeqNum=10", "144.MsgType=0", "144.SendingTime=11", "TemplateId=144"}
+	if len(inspections) != len(expected) {
+		t.Fatalf("Expected %d inspections, result: %v", len(expected), inspections)
+	}
+	for index, inspection := range inspections {
+		if inspection.String() != expected[index] {
+			t.Errorf("Expected inspection %d to be %s, result: %s", index, expected[index], inspection)
+		}
+	}
+	if inspections[4].Key != dictionary.GlobalKey("TemplateId") {
+		t.Errorf("Expected the template id to be listed by its key in the global dictionary, result: %#v", inspections[4].Key)
+	}
+}
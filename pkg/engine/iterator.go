@@ -0,0 +1,83 @@
+package engine
+
+import (
+	"fmt"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+// MessageRange is the range of bytes [Start, End) within a datagram that a single FAST message was decoded from
+type MessageRange struct {
+	Start int
+	End   int
+}
+
+// MessageIterator decodes each FAST message within a datagram in turn, until the datagram is empty or a message fails to decode.
+// A datagram is commonly a single UDP packet, containing several FAST messages back to back.
+type MessageIterator struct {
+	engine   *fastEngine
+	datagram *decoder.Cursor
+	release  func()
+
+	index        int
+	message      *fix.Message
+	messageRange MessageRange
+	err          error
+}
+
+// Next decodes the next message in the datagram, returning true if a message was decoded. Once false is returned, Err should be checked
+// to see whether the datagram was fully decoded, or decoding stopped due to an error
+func (iterator *MessageIterator) Next() bool {
+	if iterator.err != nil || iterator.datagram.Len() == 0 {
+		iterator.message = nil
+		iterator.done()
+		return false
+	}
+
+	if iterator.message != nil {
+		iterator.index++
+	}
+
+	start := iterator.datagram.Offset()
+	message, err := iterator.engine.Deserialise(iterator.datagram)
+	if err != nil {
+		iterator.engine.logger.Printf("unable to decode message %d in datagram starting at byte %d: %v", iterator.index, start, err)
+		iterator.err = fmt.Errorf("unable to decode message %d in datagram starting at byte %d, reason: %v", iterator.index, start, err)
+		iterator.message = nil
+		iterator.done()
+		return false
+	}
+
+	iterator.message = message
+	iterator.messageRange = MessageRange{Start: start, End: iterator.datagram.Offset()}
+	return true
+}
+
+// done releases the engine used by the iterator, if it was borrowed from a Pool
+func (iterator *MessageIterator) done() {
+	if iterator.release != nil {
+		iterator.release()
+		iterator.release = nil
+	}
+}
+
+// Message returns the message decoded by the last call to Next
+func (iterator *MessageIterator) Message() *fix.Message {
+	return iterator.message
+}
+
+// Range returns the bytes of the datagram consumed decoding the message returned by Message
+func (iterator *MessageIterator) Range() MessageRange {
+	return iterator.messageRange
+}
+
+// Index returns the position of the message returned by Message within the datagram, starting at 0
+func (iterator *MessageIterator) Index() int {
+	return iterator.index
+}
+
+// Err returns the error that stopped decoding of the datagram, or nil if every message in the datagram was decoded
+func (iterator *MessageIterator) Err() error {
+	return iterator.err
+}
@@ -0,0 +1,175 @@
+package engine
+
+import (
+	"bufio"
+	"encoding/hex"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCanDecodeAllMessagesInDatagram(t *testing.T) {
+	// Arrange
+	/*
+		Message format:
+		11000000           pmap
+		00000001 10010000  template 144
+		10001010           34 = 10
+		10001011           52 = 11
+		11000000           pmap
+		00000001 10010000  template 144
+		10001100           34 = 12
+		10001101           52 = 13
+	*/
+	datagram := []byte{192, 1, 144, 138, 139, 192, 1, 144, 140, 141}
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+	expectedMessages := []string{"1128=9|35=0|34=10|52=11|", "1128=9|35=0|34=12|52=13|"}
+	expectedRanges := []MessageRange{{Start: 0, End: 5}, {Start: 5, End: 10}}
+
+	// Act
+	iterator := fastEngine.DecodeAll(datagram)
+
+	// Assert
+	for index := range expectedMessages {
+		if !iterator.Next() {
+			t.Fatalf("Expected message %d to be decoded, but got: %v", index, iterator.Err())
+		}
+		if iterator.Index() != index {
+			t.Errorf("Expected message index and actual index were not equal, expected: %d, actual: %d", index, iterator.Index())
+		}
+		if iterator.Message().String() != expectedMessages[index] {
+			t.Errorf("Expected message and actual message were not equal, expected: %s, actual: %s", expectedMessages[index], iterator.Message().String())
+		}
+		if iterator.Range() != expectedRanges[index] {
+			t.Errorf("Expected message range and actual range were not equal, expected: %#v, actual: %#v", expectedRanges[index], iterator.Range())
+		}
+	}
+	if iterator.Next() {
+		t.Errorf("Expected no more messages in datagram, but got: %s", iterator.Message().String())
+	}
+	if iterator.Err() != nil {
+		t.Errorf("Expected no error decoding datagram, but got: %v", iterator.Err())
+	}
+}
+
+func TestDecodeAllOfEmptyDatagramReturnsNoMessages(t *testing.T) {
+	// Arrange
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+
+	// Act
+	iterator := fastEngine.DecodeAll([]byte{})
+
+	// Assert
+	if iterator.Next() {
+		t.Errorf("Expected no messages in empty datagram, but got: %s", iterator.Message().String())
+	}
+	if iterator.Err() != nil {
+		t.Errorf("Expected no error decoding empty datagram, but got: %v", iterator.Err())
+	}
+}
+
+func TestDecodeAllStopsWithErrorNamingMessageIndex(t *testing.T) {
+	// Arrange
+	/*
+		Message format:
+		11000000           pmap
+		00000001 10010000  template 144
+		10001010           34 = 10
+		10001011           52 = 11
+		11000000           pmap
+		00000001 10010110  template 150 (not in store)
+		10001010           34 = 10
+		10001011           52 = 11
+		11000000           pmap
+		00000001 10010000  template 144
+		10001010           34 = 10
+		10001011           52 = 11
+	*/
+	datagram := []byte{192, 1, 144, 138, 139, 192, 1, 150, 138, 139, 192, 1, 144, 138, 139}
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+
+	// Act
+	iterator := fastEngine.DecodeAll(datagram)
+	decoded := 0
+	for iterator.Next() {
+		decoded++
+	}
+
+	// Assert
+	if decoded != 1 {
+		t.Errorf("Expected a single message to be decoded before the error, but decoded: %d", decoded)
+	}
+	if iterator.Err() == nil || !strings.Contains(iterator.Err().Error(), "message 1 ") {
+		t.Errorf("Expected error naming message 1 as the message that failed to decode, but got: %v", iterator.Err())
+	}
+	if iterator.Message() != nil {
+		t.Errorf("Expected no message once decoding has stopped, but got: %s", iterator.Message().String())
+	}
+}
+
+func TestDecodeAllStopsWithErrorOnTruncatedMessage(t *testing.T) {
+	// Arrange
+	/*
+		Message format:
+		11000000           pmap
+		00000001 10010000  template 144
+		10001010           34 = 10
+		10001011           52 = 11
+		11000000           pmap
+		00000001 10010000  template 144
+		00001010           34 = truncated
+	*/
+	datagram := []byte{192, 1, 144, 138, 139, 192, 1, 144, 10}
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+
+	// Act
+	iterator := fastEngine.DecodeAll(datagram)
+	for iterator.Next() {
+	}
+
+	// Assert
+	if iterator.Err() == nil || !strings.Contains(iterator.Err().Error(), "message 1 ") {
+		t.Errorf("Expected error naming message 1 as the message that failed to decode, but got: %v", iterator.Err())
+	}
+}
+
+func TestCanDecodeAllConcatenatedSnapshotMessages(t *testing.T) {
+	// Arrange
+	file, _ := os.Open("../../test/example-decoding-tests/snapshot-messages-hex.txt")
+	defer file.Close()
+
+	logger := log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)
+	engine, err := NewFromTemplateFile("../../test/example-decoding-tests/templates.xml", WithLogger(logger))
+	if err != nil {
+		t.Fatalf("unable to load engine: %v", err)
+	}
+
+	datagram := []byte{}
+	expectedRanges := []MessageRange{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		message, _ := hex.DecodeString(scanner.Text())
+		expectedRanges = append(expectedRanges, MessageRange{Start: len(datagram), End: len(datagram) + len(message)})
+		datagram = append(datagram, message...)
+	}
+
+	// Act
+	iterator := engine.DecodeAll(datagram)
+	decoded := 0
+	for iterator.Next() {
+		// Assert
+		if iterator.Range() != expectedRanges[decoded] {
+			t.Fatalf("Expected message range and actual range were not equal, expected: %#v, actual: %#v", expectedRanges[decoded], iterator.Range())
+		}
+		decoded++
+	}
+
+	// Assert
+	if iterator.Err() != nil {
+		t.Errorf("Expected no error decoding datagram, but got: %v", iterator.Err())
+	}
+	if decoded != len(expectedRanges) {
+		t.Errorf("Expected all messages in datagram to be decoded, expected: %d, actual: %d", len(expectedRanges), decoded)
+	}
+}
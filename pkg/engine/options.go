@@ -0,0 +1,153 @@
+package engine
+
+import (
+	"log"
+	"os"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
+)
+
+// ResetPolicy decides when the dictionary of previous values an engine holds is reset
+type ResetPolicy int
+
+const (
+	// ResetPerMessage resets the dictionary before every message is serialised/deserialised, this is the default
+	ResetPerMessage ResetPolicy = iota
+	// ResetNever keeps the dictionary for the lifetime of the engine, so previous values carry over from one message to the next as in a FAST stream. The
+	// dictionary is only reset when Reset is called, or by a message encoded with the reset template (ResetTemplateID, unless set by WithResetTemplateID)
+	// or a template marked reset="Y", as in the FAST session control protocol.
+	ResetNever
+	// ResetPerPacket keeps the dictionary between the messages of a packet, resetting it before the first message of each datagram decoded by DecodeAll.
+	// Callers framing packets or blocks of messages themselves call Reset at the start of each one.
+	ResetPerPacket
+)
+
+// ResetTemplateID is the template id of the reset message of the FAST session control protocol, which resets every dictionary, and is the default reset
+// template id of an engine. The reset template has no fields, so when it is not defined within the templates given to an engine, reset messages are
+// decoded and encoded as an empty message rather than as an unknown template.
+const ResetTemplateID uint32 = 120
+
+// Option configures a FAST engine, or every engine within a Pool
+type Option func(*options)
+
+type options struct {
+	logger          *log.Logger
+	strict          bool
+	maxSequence     uint32
+	dictionaryReset ResetPolicy
+	warn            func(warning error)
+	projections     map[uint32][]uint64
+	zeroCopy        bool
+	counters        *decoder.Counters
+	resetTemplateID uint32
+	onReset         func(templateID uint32)
+}
+
+// WithLogger that every error and warning is logged to, by default this is stderr
+func WithLogger(logger *log.Logger) Option {
+	return func(options *options) {
+		options.logger = logger
+	}
+}
+
+// WithStrict decides whether any violation of the FAST spec fails decoding (true, the default), or recoverable violations such as overlong encodings (R6)
+// are decoded and reported as warnings (false). Presence maps that are overlong (R7) or too long (R8) are counted rather than reported when not strict,
+// see Counters.
+func WithStrict(strict bool) Option {
+	return func(options *options) {
+		options.strict = strict
+	}
+}
+
+// WithMaxSequenceLength fails decoding of any sequence with more than maxLength repeating groups, 0 (the default) means there is no limit
+func WithMaxSequenceLength(maxLength uint32) Option {
+	return func(options *options) {
+		options.maxSequence = maxLength
+	}
+}
+
+// WithDictionaryReset sets when the dictionary of previous values is reset, by default this is ResetPerMessage
+func WithDictionaryReset(policy ResetPolicy) Option {
+	return func(options *options) {
+		options.dictionaryReset = policy
+	}
+}
+
+// WithWarningHandler is called with each recoverable spec violation decoded when not strict, by default these are logged
+func WithWarningHandler(warn func(warning error)) Option {
+	return func(options *options) {
+		options.warn = warn
+	}
+}
+
+// WithProjection only stores the tags given in messages decoded with the template with templateID. Every other field is still decoded, so previous values
+// stay correct, but is neither boxed nor stored in the fix message returned. Tags within a sequence store the sequence with only those tags in each
+// repeating group.
+func WithProjection(templateID uint32, tags ...uint64) Option {
+	return func(options *options) {
+		if options.projections == nil {
+			options.projections = make(map[uint32][]uint64)
+		}
+		options.projections[templateID] = tags
+	}
+}
+
+// WithZeroCopy decodes byte vectors as views into the message being decoded rather than copies, when messages are decoded from a decoder.Cursor (as
+// DecodeAll and DecodeBatch do). This is off by default. The message is only read from, never written to, and byte vectors decoded this way are only valid
+// until the bytes of the message are reused, so must be copied to be kept any longer. ASCII strings are always copied, as their characters can not be
+// viewed without changing the message. Messages decoded by DeserialiseReusing copy their values into the message they are decoded into, so are unaffected.
+func WithZeroCopy(zeroCopy bool) Option {
+	return func(options *options) {
+		options.zeroCopy = zeroCopy
+	}
+}
+
+// WithResetTemplateID sets the template id of the reset message, which resets the dictionary whatever the reset policy, by default this is ResetTemplateID
+func WithResetTemplateID(templateID uint32) Option {
+	return func(options *options) {
+		options.resetTemplateID = templateID
+	}
+}
+
+// WithResetHandler is called with the template id of each message decoded that resets the dictionary of previous values, which is the reset message, or
+// a message encoded with a template marked reset="Y", whatever the reset policy. By default these resets are not reported
+func WithResetHandler(onReset func(templateID uint32)) Option {
+	return func(options *options) {
+		options.onReset = onReset
+	}
+}
+
+func newOptions(engineOptions []Option) options {
+	resolved := options{
+		logger:          log.New(os.Stderr, "", log.LstdFlags),
+		strict:          true,
+		dictionaryReset: ResetPerMessage,
+		counters:        &decoder.Counters{},
+		resetTemplateID: ResetTemplateID,
+	}
+	for _, option := range engineOptions {
+		option(&resolved)
+	}
+
+	if resolved.warn == nil {
+		logger := resolved.logger
+		resolved.warn = func(warning error) {
+			logger.Printf("warning: %v", warning)
+		}
+	}
+
+	return resolved
+}
+
+// settings values are read off a message with, these are only applied if they differ from the strict defaults
+func (options options) settings() (decoder.Settings, bool) {
+	settings := decoder.Settings{
+		Lenient:           !options.strict,
+		MaxSequenceLength: options.maxSequence,
+		Warn:              options.warn,
+		ZeroCopy:          options.zeroCopy,
+		Counters:          options.counters,
+	}
+
+	return settings, settings.Lenient || settings.MaxSequenceLength > 0 || settings.ZeroCopy
+}
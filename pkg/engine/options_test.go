@@ -0,0 +1,479 @@
+package engine
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
+	"github.com/Guardian-Development/fastengine/pkg/fast/errors"
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+/*
+Message format:
+11000000                                               pmap
+00000001 10010000                                      template 144
+00000000 00000000 00000000 00000000 00000000 10001010  34 = 10 (overlong)
+10001011                                               52 = 11
+*/
+var overlongHeartbeat = []byte{192, 1, 144, 0, 0, 0, 0, 0, 138, 139}
+
+func TestStrictEngineReturnsErrorForOverlongEncoding(t *testing.T) {
+	// Arrange
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+
+	// Act
+	_, err := fastEngine.Deserialise(bytes.NewBuffer(overlongHeartbeat))
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), errors.R6) {
+		t.Errorf("Expected error about the overlong encoding, but got: %v", err)
+	}
+}
+
+func TestLenientEngineDecodesOverlongEncodingWithWarning(t *testing.T) {
+	// Arrange
+	var warnings []error
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml",
+		WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)),
+		WithStrict(false),
+		WithWarningHandler(func(warning error) { warnings = append(warnings, warning) }))
+
+	// Act
+	fixMessage, err := fastEngine.Deserialise(bytes.NewBuffer(overlongHeartbeat))
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+	if fixMessageAsString := fixMessage.String(); fixMessageAsString != "1128=9|35=0|34=10|52=11|" {
+		t.Errorf("Expected message and actual message were not equal, actual: %s", fixMessageAsString)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Error(), errors.R6) {
+		t.Errorf("Expected a single warning about the overlong encoding, actual: %v", warnings)
+	}
+}
+
+func TestLenientPoolDecodesOverlongEncoding(t *testing.T) {
+	// Arrange
+	pool, _ := NewPoolFromTemplateFile("../../test/test_heartbeat_template.xml",
+		WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)),
+		WithStrict(false))
+
+	// Act
+	fixMessage, err := pool.Deserialise(bytes.NewBuffer(overlongHeartbeat))
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+	if fixMessageAsString := fixMessage.String(); fixMessageAsString != "1128=9|35=0|34=10|52=11|" {
+		t.Errorf("Expected message and actual message were not equal, actual: %s", fixMessageAsString)
+	}
+}
+
+/*
+Message format:
+11100000                                               pmap, the bit after the template id is set but no field reads it
+00000001 10010000                                      template 144
+10001010                                               34 = 10
+10001011                                               52 = 11
+*/
+var tooLongPmapHeartbeat = []byte{224, 1, 144, 138, 139}
+
+/*
+Message format:
+01000000 10000000                                      pmap, the last byte has no bits set
+00000001 10010000                                      template 144
+10001010                                               34 = 10
+10001011                                               52 = 11
+*/
+var overlongPmapHeartbeat = []byte{64, 128, 1, 144, 138, 139}
+
+func TestStrictEngineReturnsErrorForInvalidPresenceMaps(t *testing.T) {
+	cases := []struct {
+		message  []byte
+		expected string
+	}{
+		{tooLongPmapHeartbeat, errors.R8},
+		{overlongPmapHeartbeat, errors.R7},
+	}
+
+	for _, table := range cases {
+		// Arrange
+		fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+
+		// Act
+		_, err := fastEngine.Deserialise(bytes.NewBuffer(table.message))
+		_, reusingErr := fastEngine.DeserialiseReusing(bytes.NewBuffer(table.message), &fix.Message{})
+
+		// Assert
+		if err == nil || !strings.Contains(err.Error(), table.expected) {
+			t.Errorf("Expected error %s decoding %v, but got: %v", table.expected, table.message, err)
+		}
+		if reusingErr == nil || !strings.Contains(reusingErr.Error(), table.expected) {
+			t.Errorf("Expected error %s decoding %v into a reused message, but got: %v", table.expected, table.message, reusingErr)
+		}
+	}
+}
+
+func TestLenientPoolCountsInvalidPresenceMaps(t *testing.T) {
+	// Arrange
+	pool, _ := NewPoolFromTemplateFile("../../test/test_heartbeat_template.xml",
+		WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)),
+		WithStrict(false))
+
+	// Act
+	for _, message := range [][]byte{tooLongPmapHeartbeat, overlongPmapHeartbeat, tooLongPmapHeartbeat} {
+		fixMessage, err := pool.Deserialise(bytes.NewBuffer(message))
+
+		// Assert
+		if err != nil {
+			t.Errorf("Got an error decoding %v when none was expected: %s", message, err)
+		} else if fixMessageAsString := fixMessage.String(); fixMessageAsString != "1128=9|35=0|34=10|52=11|" {
+			t.Errorf("Expected message and actual message were not equal, actual: %s", fixMessageAsString)
+		}
+	}
+	if counters := pool.Counters(); counters != (decoder.Counters{OverlongPresenceMaps: 1, TooLongPresenceMaps: 2}) {
+		t.Errorf("Expected the invalid presence maps to be counted, counters: %#v", counters)
+	}
+}
+
+func TestEngineResetPerMessageDoesNotKeepPreviousTemplateID(t *testing.T) {
+	// Arrange second message has no template id: 10000000 pmap, 10001100 34 = 12, 10001101 52 = 13
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+	fastEngine.Deserialise(bytes.NewBuffer([]byte{192, 1, 144, 138, 139}))
+
+	// Act
+	_, err := fastEngine.Deserialise(bytes.NewBuffer([]byte{128, 140, 141}))
+
+	// Assert
+	if err == nil {
+		t.Errorf("Expected an error decoding a message without a template id after the dictionary was reset")
+	}
+}
+
+func TestEngineResetNeverKeepsPreviousValuesBetweenMessages(t *testing.T) {
+	// Arrange second message has no template id: 10000000 pmap, 10001100 34 = 12, 10001101 52 = 13
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml",
+		WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)),
+		WithDictionaryReset(ResetNever))
+	fastEngine.Deserialise(bytes.NewBuffer([]byte{192, 1, 144, 138, 139}))
+
+	// Act
+	fixMessage, err := fastEngine.Deserialise(bytes.NewBuffer([]byte{128, 140, 141}))
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+	if fixMessageAsString := fixMessage.String(); fixMessageAsString != "1128=9|35=0|34=12|52=13|" {
+		t.Errorf("Expected message and actual message were not equal, actual: %s", fixMessageAsString)
+	}
+}
+
+func TestEngineResetNeverEncodesWithPreviousValuesBetweenMessages(t *testing.T) {
+	// Arrange
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml",
+		WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)),
+		WithDictionaryReset(ResetNever))
+	first, _ := fastEngine.Deserialise(bytes.NewBuffer([]byte{192, 1, 144, 138, 139}))
+	fastEngine.Serialise(first, 144)
+
+	// Act
+	encoded, err := fastEngine.Serialise(first, 144)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+	if !bytes.Equal(encoded, []byte{128, 138, 139}) {
+		t.Errorf("Expected the template id to be copied from the previous message, actual: %v", encoded)
+	}
+}
+
+func TestEngineResetMakesPreviousValuesUndefined(t *testing.T) {
+	// Arrange second message has no template id: 10000000 pmap, 10001100 34 = 12, 10001101 52 = 13
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml",
+		WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)),
+		WithDictionaryReset(ResetNever))
+	fastEngine.Deserialise(bytes.NewBuffer([]byte{192, 1, 144, 138, 139}))
+
+	// Act
+	fastEngine.Reset()
+	_, err := fastEngine.Deserialise(bytes.NewBuffer([]byte{128, 140, 141}))
+
+	// Assert
+	if err == nil {
+		t.Errorf("Expected an error decoding a message without a template id after the engine was reset")
+	}
+}
+
+func TestEngineResetMakesPreviousEncodedValuesUndefined(t *testing.T) {
+	// Arrange
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml",
+		WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)),
+		WithDictionaryReset(ResetNever))
+	first, _ := fastEngine.Deserialise(bytes.NewBuffer([]byte{192, 1, 144, 138, 139}))
+	fastEngine.Serialise(first, 144)
+
+	// Act
+	fastEngine.Reset()
+	encoded, err := fastEngine.Serialise(first, 144)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+	if !bytes.Equal(encoded, []byte{192, 1, 144, 138, 139}) {
+		t.Errorf("Expected the template id to be encoded after the engine was reset, actual: %v", encoded)
+	}
+}
+
+func TestPoolRejectsEveryResetPolicyButResetPerMessage(t *testing.T) {
+	for _, policy := range []ResetPolicy{ResetNever, ResetPerPacket} {
+		// Act
+		pool, err := NewPoolFromTemplateFile("../../test/test_heartbeat_template.xml",
+			WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)),
+			WithDictionaryReset(policy))
+
+		// Assert
+		if err == nil || pool != nil {
+			t.Errorf("Expected an error creating a pool with reset policy %d, pool: %v", policy, pool)
+		}
+	}
+}
+
+func TestEngineResetPerPacketKeepsPreviousValuesWithinDatagramOnly(t *testing.T) {
+	// Arrange second message has no template id: 10000000 pmap, 10001100 34 = 12, 10001101 52 = 13
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml",
+		WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)),
+		WithDictionaryReset(ResetPerPacket))
+	first := fastEngine.DecodeAll([]byte{192, 1, 144, 138, 139, 128, 140, 141})
+	for first.Next() {
+	}
+
+	// Act
+	second := fastEngine.DecodeAll([]byte{128, 140, 141})
+	for second.Next() {
+	}
+
+	// Assert
+	if err := first.Err(); err != nil {
+		t.Errorf("Got an error decoding the first datagram when none was expected: %s", err)
+	}
+	if second.Err() == nil {
+		t.Errorf("Expected an error decoding a datagram starting without a template id")
+	}
+}
+
+func TestEngineResetNeverKeepsPreviousValuesUntilResetMessage(t *testing.T) {
+	// Arrange reset message: 11000000 pmap, 11111000 template id = 120
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_reset_template.xml",
+		WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)),
+		WithDictionaryReset(ResetNever))
+	fastEngine.Deserialise(bytes.NewBuffer([]byte{192, 1, 144, 138, 139}))
+
+	// Act
+	beforeReset, beforeErr := fastEngine.Deserialise(bytes.NewBuffer([]byte{128, 140, 141}))
+	_, resetErr := fastEngine.Deserialise(bytes.NewBuffer([]byte{192, 248}))
+	_, afterErr := fastEngine.Deserialise(bytes.NewBuffer([]byte{128, 140, 141}))
+
+	// Assert
+	if beforeErr != nil || resetErr != nil {
+		t.Errorf("Got an error when none was expected, before reset: %v, reset: %v", beforeErr, resetErr)
+	}
+	if beforeErr == nil && beforeReset.String() != "1128=9|35=0|34=12|52=13|" {
+		t.Errorf("Expected message and actual message were not equal, actual: %s", beforeReset.String())
+	}
+	if afterErr == nil {
+		t.Errorf("Expected an error decoding a message without a template id after the reset message")
+	}
+}
+
+func TestEngineResetNeverEncodesTemplateIDAfterResetMessage(t *testing.T) {
+	// Arrange
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_reset_template.xml",
+		WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)),
+		WithDictionaryReset(ResetNever))
+	first, _ := fastEngine.Deserialise(bytes.NewBuffer([]byte{192, 1, 144, 138, 139}))
+	fastEngine.Serialise(first, 144)
+
+	// Act
+	resetMessage := fix.New()
+	encodedReset, resetErr := fastEngine.Serialise(&resetMessage, ResetTemplateID)
+	encoded, err := fastEngine.Serialise(first, 144)
+
+	// Assert
+	if resetErr != nil || err != nil {
+		t.Errorf("Got an error when none was expected, reset: %v, message: %v", resetErr, err)
+	}
+	if !bytes.Equal(encodedReset, []byte{192, 248}) {
+		t.Errorf("Expected the reset message to be encoded with the reset template id, actual: %v", encodedReset)
+	}
+	if !bytes.Equal(encoded, []byte{192, 1, 144, 138, 139}) {
+		t.Errorf("Expected the template id to be encoded after the reset message, actual: %v", encoded)
+	}
+}
+
+func TestEngineDecodesResetTemplateNotWithinTemplatesAsEmptyMessage(t *testing.T) {
+	// Arrange reset message: 11000000 pmap, 11111000 template id = 120
+	var resets []uint32
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml",
+		WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)),
+		WithDictionaryReset(ResetNever),
+		WithResetHandler(func(templateID uint32) { resets = append(resets, templateID) }))
+	fastEngine.Deserialise(bytes.NewBuffer([]byte{192, 1, 144, 138, 139}))
+
+	// Act
+	templateID, resetMessage, resetErr := fastEngine.DeserialiseWithTemplateID(bytes.NewBuffer([]byte{192, 248}))
+	_, afterErr := fastEngine.Deserialise(bytes.NewBuffer([]byte{128, 140, 141}))
+
+	// Assert
+	if resetErr != nil {
+		t.Fatalf("Got an error decoding the reset message when none was expected: %s", resetErr)
+	}
+	if templateID != ResetTemplateID || resetMessage.String() != "" {
+		t.Errorf("Expected the reset message to be decoded as an empty message, template id: %d, message: %s", templateID, resetMessage)
+	}
+	if len(resets) != 1 || resets[0] != ResetTemplateID {
+		t.Errorf("Expected the reset handler to be called with the reset template id, resets: %v", resets)
+	}
+	if afterErr == nil {
+		t.Errorf("Expected an error decoding a message without a template id after the reset message")
+	}
+}
+
+func TestEngineWithResetNeverResetsOnResetTemplate(t *testing.T) {
+	// Arrange reset message: 11000000 pmap, 11111000 template id = 120
+	var resets []uint32
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml",
+		WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)),
+		WithDictionaryReset(ResetNever),
+		WithResetHandler(func(templateID uint32) { resets = append(resets, templateID) }))
+	fastEngine.Deserialise(bytes.NewBuffer([]byte{192, 1, 144, 138, 139}))
+
+	// Act
+	_, beforeErr := fastEngine.Deserialise(bytes.NewBuffer([]byte{128, 140, 141}))
+	templateID, _, resetErr := fastEngine.DeserialiseWithTemplateID(bytes.NewBuffer([]byte{192, 248}))
+	_, afterErr := fastEngine.Deserialise(bytes.NewBuffer([]byte{128, 140, 141}))
+
+	// Assert
+	if beforeErr != nil {
+		t.Errorf("Got an error copying the template id before the reset message when none was expected: %s", beforeErr)
+	}
+	if resetErr != nil || templateID != ResetTemplateID {
+		t.Errorf("Expected the reset message to be decoded, template id: %d, error: %v", templateID, resetErr)
+	}
+	if len(resets) != 1 || resets[0] != ResetTemplateID {
+		t.Errorf("Expected the reset handler to be called with the reset template id, resets: %v", resets)
+	}
+	if afterErr == nil {
+		t.Errorf("Expected an error decoding a message without a template id after the reset message")
+	}
+}
+
+func TestEngineWithResetTemplateIDResetsOnThatTemplateOnly(t *testing.T) {
+	// Arrange reset message: 11000000 pmap, 11111001 template id = 121
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml",
+		WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)),
+		WithDictionaryReset(ResetNever),
+		WithResetTemplateID(121))
+	fastEngine.Deserialise(bytes.NewBuffer([]byte{192, 1, 144, 138, 139}))
+
+	// Act
+	templateID, _, resetErr := fastEngine.DeserialiseWithTemplateID(bytes.NewBuffer([]byte{192, 249}))
+	_, afterErr := fastEngine.Deserialise(bytes.NewBuffer([]byte{128, 140, 141}))
+	_, _, unknownErr := fastEngine.DeserialiseWithTemplateID(bytes.NewBuffer([]byte{192, 248}))
+
+	// Assert
+	if resetErr != nil || templateID != 121 {
+		t.Errorf("Expected the reset message to be decoded with the configured template id, template id: %d, error: %v", templateID, resetErr)
+	}
+	if afterErr == nil {
+		t.Errorf("Expected an error decoding a message without a template id after the reset message")
+	}
+	if _, isUnknown := unknownErr.(UnknownTemplateError); !isUnknown {
+		t.Errorf("Expected template 120 to be unknown once the reset template id is 121, error: %v", unknownErr)
+	}
+}
+
+func TestEngineSerialisesResetTemplateNotWithinTemplates(t *testing.T) {
+	// Arrange
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml",
+		WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)),
+		WithDictionaryReset(ResetNever))
+
+	// Act
+	resetMessage := fix.New()
+	encoded, err := fastEngine.Serialise(&resetMessage, ResetTemplateID)
+
+	// Assert
+	if err != nil || !bytes.Equal(encoded, []byte{192, 248}) {
+		t.Errorf("Expected the reset message to be encoded with only the reset template id, encoded: %v, error: %v", encoded, err)
+	}
+}
+
+func TestEngineResetsDictionaryBeforeDecodingTemplateMarkedReset(t *testing.T) {
+	// Arrange 11100000 pmap, template 144, 34 = 5; 11000000 pmap, template 144, 34 copied; 11000000 pmap, template 145, 36 = 1
+	var resets []uint32
+	fastEngine, _ := NewFromTemplateFile("../../test/test_reset_attribute_template.xml",
+		WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)),
+		WithDictionaryReset(ResetNever),
+		WithResetHandler(func(templateID uint32) { resets = append(resets, templateID) }))
+	fastEngine.Deserialise(bytes.NewBuffer([]byte{224, 1, 144, 133}))
+
+	// Act
+	beforeReset, beforeErr := fastEngine.Deserialise(bytes.NewBuffer([]byte{192, 1, 144}))
+	resetMessage, resetErr := fastEngine.Deserialise(bytes.NewBuffer([]byte{192, 1, 145, 129}))
+	_, afterErr := fastEngine.Deserialise(bytes.NewBuffer([]byte{192, 1, 144}))
+
+	// Assert
+	if beforeErr != nil || beforeReset.String() != "34=5|" {
+		t.Errorf("Expected the sequence number to be copied before the reset, message: %v, error: %v", beforeReset, beforeErr)
+	}
+	if resetErr != nil || resetMessage.String() != "36=1|" {
+		t.Errorf("Expected the message marked reset to be decoded, message: %v, error: %v", resetMessage, resetErr)
+	}
+	if len(resets) != 1 || resets[0] != 145 {
+		t.Errorf("Expected the reset handler to be called with the template marked reset, resets: %v", resets)
+	}
+	if afterErr == nil {
+		t.Errorf("Expected an error copying the sequence number after the message marked reset")
+	}
+}
+
+func TestZeroCopyEngineDecodesSameMessagesAsCopyingEngine(t *testing.T) {
+	// Arrange
+	logger := log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)
+	messages := readHexMessages(t, "../../test/example-decoding-tests/snapshot-messages-hex.txt")
+	copyingEngine, _ := NewFromTemplateFile("../../test/example-decoding-tests/templates.xml", WithLogger(logger))
+	zeroCopyEngine, _ := NewFromTemplateFile("../../test/example-decoding-tests/templates.xml", WithLogger(logger), WithZeroCopy(true))
+
+	for index, message := range messages {
+		original := append([]byte{}, message...)
+		expected, err := copyingEngine.Deserialise(decoder.NewCursor(original))
+		if err != nil {
+			t.Fatalf("unable to decode message %d: %v", index, err)
+		}
+
+		// Act
+		iterator := zeroCopyEngine.DecodeAll(message)
+		iterator.Next()
+		actual := iterator.Message()
+
+		// Assert
+		if iterator.Err() != nil {
+			t.Fatalf("Got an error decoding message %d with zero copy when none was expected: %s", index, iterator.Err())
+		}
+		if actual.String() != expected.String() {
+			t.Fatalf("Expected message %d decoded with zero copy to equal the message copied, expected: %s, actual: %s", index, expected, actual)
+		}
+		if !bytes.Equal(message, original) {
+			t.Fatalf("Expected message %d to be unchanged by decoding it with zero copy, expected: %x, actual: %x", index, original, message)
+		}
+	}
+}
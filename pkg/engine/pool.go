@@ -0,0 +1,144 @@
+package engine
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
+	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
+	"github.com/Guardian-Development/fastengine/pkg/fast/template/program"
+	"github.com/Guardian-Development/fastengine/pkg/fast/template/store"
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+// Pool of FAST engines that is safe to use from many goroutines. Every engine in the pool shares the same template store, which is only read from
+// once loaded, while each call is given an engine (and therefore dictionary of previous values) that no other goroutine is using for the length of the call.
+// Engines that are not in use may be dropped by the pool at any time, so the dictionary of previous values is reset for every message, see NewPool.
+type Pool struct {
+	templateStore store.Store
+	programs      map[uint32]program.Program
+	engines       sync.Pool
+
+	options options
+	logger  *log.Logger
+}
+
+// Deserialise takes a FAST encoded FIX message in bytes, decodes and turns it into a FIX message, using an engine from the pool
+func (pool *Pool) Deserialise(message decoder.Reader) (*fix.Message, error) {
+	engine := pool.get()
+	defer pool.put(engine)
+
+	return engine.Deserialise(message)
+}
+
+// DeserialiseWithTemplateID decodes a FAST encoded FIX message, also returning the template ID the message was encoded with, using an engine from the pool
+func (pool *Pool) DeserialiseWithTemplateID(message decoder.Reader) (uint32, *fix.Message, error) {
+	engine := pool.get()
+	defer pool.put(engine)
+
+	return engine.DeserialiseWithTemplateID(message)
+}
+
+// DeserialiseInto decodes a FAST encoded FIX message and unmarshals it into v, using an engine from the pool
+func (pool *Pool) DeserialiseInto(message decoder.Reader, v interface{}) (uint32, error) {
+	engine := pool.get()
+	defer pool.put(engine)
+
+	return engine.DeserialiseInto(message, v)
+}
+
+// DeserialiseReusing decodes a FAST encoded FIX message into the message given, reusing its storage, using an engine from the pool
+func (pool *Pool) DeserialiseReusing(message decoder.Reader, fixMessage *fix.Message) (uint32, error) {
+	engine := pool.get()
+	defer pool.put(engine)
+
+	return engine.DeserialiseReusing(message, fixMessage)
+}
+
+// Visit decodes a FAST encoded FIX message, passing each value to the visitor as it is decoded, using an engine from the pool
+func (pool *Pool) Visit(message decoder.Reader, visitor store.Visitor) (uint32, error) {
+	engine := pool.get()
+	defer pool.put(engine)
+
+	return engine.Visit(message, visitor)
+}
+
+// Serialise takes a FIX message, and encodes it into FAST encoded bytes using the template with the given templateID, using an engine from the pool
+func (pool *Pool) Serialise(message *fix.Message, templateID uint32) ([]byte, error) {
+	engine := pool.get()
+	defer pool.put(engine)
+
+	return engine.Serialise(message, templateID)
+}
+
+// Counters of the recoverable spec violations counted while decoding messages, summed across every engine in the pool as they share the same counters
+func (pool *Pool) Counters() decoder.Counters {
+	return pool.options.counters.Load()
+}
+
+// DeserialiseRecording decodes a FAST encoded FIX message, also returning every write to the dictionary of previous values made while decoding it,
+// using an engine from the pool
+func (pool *Pool) DeserialiseRecording(message decoder.Reader) (*fix.Message, []dictionary.Write, error) {
+	engine := pool.get()
+	defer pool.put(engine)
+
+	return engine.DeserialiseRecording(message)
+}
+
+// DecodeAll returns an iterator over every FAST encoded message within the datagram. The iterator holds an engine from the pool until it has
+// decoded every message in the datagram or stopped with an error, and should only be used from a single goroutine
+func (pool *Pool) DecodeAll(datagram []byte) *MessageIterator {
+	engine := pool.get()
+	iterator := engine.DecodeAll(datagram)
+	iterator.release = func() { pool.put(engine) }
+	return iterator
+}
+
+func (pool *Pool) get() *fastEngine {
+	return pool.engines.Get().(*fastEngine)
+}
+
+func (pool *Pool) put(engine FastEngine) {
+	pool.engines.Put(engine)
+}
+
+// NewPool of FAST engines, that can serialise/deserialise FAST messages concurrently using the template store provided. Every engine in the pool
+// is configured by the options given. Each call is made with whichever engine is free, and an engine may be dropped by the pool while it is not in use,
+// so previous values can not be kept between messages: only ResetPerMessage, the default, is supported and any other reset policy returns an error. A
+// stream whose messages depend on the previous values of the messages before them should be decoded by an engine of its own, see New.
+func NewPool(templateStore store.Store, engineOptions ...Option) (*Pool, error) {
+	return newPool(templateStore, newOptions(engineOptions))
+}
+
+func newPool(templateStore store.Store, options options) (*Pool, error) {
+	templateStore = withSlots(templateStore)
+	if options.dictionaryReset != ResetPerMessage {
+		options.logger.Printf("unable to create pool with reset policy %d, a pool only supports ResetPerMessage", options.dictionaryReset)
+		return nil, fmt.Errorf("unable to create pool with reset policy %d, a pool only supports ResetPerMessage", options.dictionaryReset)
+	}
+
+	pool := &Pool{
+		templateStore: templateStore,
+		programs:      program.Compile(templateStore),
+		options:       options,
+		logger:        options.logger,
+	}
+	pool.engines.New = func() interface{} {
+		return newEngine(pool.templateStore, pool.programs, pool.options)
+	}
+
+	return pool, nil
+}
+
+// NewPoolFromTemplateFile of FAST engines, that can serialise/deserialise FAST messages concurrently using the template file provided.
+// This file should be xml, if we are unable to find the file or parse it, or the options given are not supported by a pool, an error is returned
+func NewPoolFromTemplateFile(templateFile string, engineOptions ...Option) (*Pool, error) {
+	resolvedOptions := newOptions(engineOptions)
+	templateStore, err := loadTemplateFile(templateFile, resolvedOptions.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPool(templateStore, resolvedOptions)
+}
@@ -0,0 +1,213 @@
+package engine
+
+import (
+	"bufio"
+	"encoding/hex"
+	"log"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+func TestPoolCanDeserialiseHeartbeatMessage(t *testing.T) {
+	// Arrange
+	/*
+		Message format:
+		11000000           pmap
+		00000001 10010000  template 144
+		10001010           34 = 10
+		10001011           52 = 11
+	*/
+	message := decoder.NewCursor([]byte{192, 1, 144, 138, 139})
+	pool, err := NewPoolFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+	if err != nil {
+		t.Fatalf("unable to load pool: %v", err)
+	}
+
+	// Act
+	fixMessage, err := pool.Deserialise(message)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+	if fixMessage.String() != "1128=9|35=0|34=10|52=11|" {
+		t.Errorf("Expected message and actual message were not equal, actual: %s", fixMessage.String())
+	}
+}
+
+func TestPoolFromMissingTemplateFileReturnsError(t *testing.T) {
+	// Act
+	_, err := NewPoolFromTemplateFile("../../test/does_not_exist.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+
+	// Assert
+	if err == nil {
+		t.Errorf("Expected error loading pool from missing template file, but got none")
+	}
+}
+
+func TestPoolCanDeserialiseConcurrentlyWithoutSharingState(t *testing.T) {
+	// Arrange
+	logger := log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)
+	messages := readHexMessages(t, "../../test/example-decoding-tests/snapshot-messages-hex.txt")
+	messages = append(messages, readHexMessages(t, "../../test/example-decoding-tests/instrument-messages-hex.txt")...)
+	expected := decodeSequentially(t, messages, logger)
+	pool, err := NewPoolFromTemplateFile("../../test/example-decoding-tests/templates.xml", WithLogger(logger))
+	if err != nil {
+		t.Fatalf("unable to load pool: %v", err)
+	}
+
+	// Act
+	results := make([][]string, 8)
+	wait := sync.WaitGroup{}
+	for worker := range results {
+		wait.Add(1)
+		go func(worker int) {
+			defer wait.Done()
+			// each worker starts at a different message, so workers are decoding different templates at the same time
+			for i := range messages {
+				index := (i + worker*len(messages)/len(results)) % len(messages)
+				fixMessage, err := pool.Deserialise(decoder.NewCursor(messages[index]))
+				if err != nil {
+					t.Errorf("unable to decode message %d: %v", index, err)
+					return
+				}
+				if results[worker] == nil {
+					results[worker] = make([]string, len(messages))
+				}
+				results[worker][index] = fixMessage.String()
+			}
+		}(worker)
+	}
+	wait.Wait()
+
+	// Assert
+	for worker, workerResults := range results {
+		for index, result := range workerResults {
+			if result != expected[index] {
+				t.Fatalf("Expected message and concurrently decoded message were not equal, worker: %d, message: %d, expected: %s, actual: %s", worker, index, expected[index], result)
+			}
+		}
+	}
+}
+
+func TestPoolCanSerialiseConcurrentlyWithoutSharingState(t *testing.T) {
+	// Arrange
+	logger := log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)
+	messages := readHexMessages(t, "../../test/example-decoding-tests/instrument-messages-hex.txt")
+	expected := decodeSequentially(t, messages, logger)
+	pool, err := NewPoolFromTemplateFile("../../test/example-decoding-tests/templates.xml", WithLogger(logger))
+	if err != nil {
+		t.Fatalf("unable to load pool: %v", err)
+	}
+
+	decoded := make([]*fix.Message, len(messages))
+	for index, message := range messages {
+		decoded[index], _ = pool.Deserialise(decoder.NewCursor(message))
+	}
+
+	// Act
+	wait := sync.WaitGroup{}
+	for worker := 0; worker < 8; worker++ {
+		wait.Add(1)
+		go func(worker int) {
+			defer wait.Done()
+			for i := range decoded {
+				index := (i + worker*len(decoded)/8) % len(decoded)
+				encoded, err := pool.Serialise(decoded[index], templateIDOf(messages[index]))
+				if err != nil {
+					t.Errorf("unable to encode message %d: %v", index, err)
+					return
+				}
+
+				roundTripped, err := pool.Deserialise(decoder.NewCursor(encoded))
+
+				// Assert
+				if err != nil {
+					t.Errorf("unable to decode encoded message %d: %v", index, err)
+					return
+				}
+				if roundTripped.String() != expected[index] {
+					t.Errorf("Expected message and concurrently round tripped message were not equal, worker: %d, message: %d, expected: %s, actual: %s", worker, index, expected[index], roundTripped.String())
+					return
+				}
+			}
+		}(worker)
+	}
+	wait.Wait()
+}
+
+func TestPoolCanDecodeAllConcurrently(t *testing.T) {
+	// Arrange
+	logger := log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)
+	messages := readHexMessages(t, "../../test/example-decoding-tests/snapshot-messages-hex.txt")
+	expected := decodeSequentially(t, messages, logger)
+	pool, err := NewPoolFromTemplateFile("../../test/example-decoding-tests/templates.xml", WithLogger(logger))
+	if err != nil {
+		t.Fatalf("unable to load pool: %v", err)
+	}
+
+	datagram := []byte{}
+	for _, message := range messages {
+		datagram = append(datagram, message...)
+	}
+
+	// Act
+	wait := sync.WaitGroup{}
+	for worker := 0; worker < 8; worker++ {
+		wait.Add(1)
+		go func(worker int) {
+			defer wait.Done()
+			iterator := pool.DecodeAll(datagram)
+			for iterator.Next() {
+				// Assert
+				if iterator.Message().String() != expected[iterator.Index()] {
+					t.Errorf("Expected message and concurrently decoded message were not equal, worker: %d, message: %d, expected: %s, actual: %s", worker, iterator.Index(), expected[iterator.Index()], iterator.Message().String())
+					return
+				}
+			}
+			if iterator.Err() != nil {
+				t.Errorf("unable to decode datagram: %v", iterator.Err())
+			}
+		}(worker)
+	}
+	wait.Wait()
+}
+
+func readHexMessages(t testing.TB, messagesFile string) [][]byte {
+	file, err := os.Open(messagesFile)
+	if err != nil {
+		t.Fatalf("unable to open messages file: %v", err)
+	}
+	defer file.Close()
+
+	messages := [][]byte{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		message, _ := hex.DecodeString(scanner.Text())
+		messages = append(messages, message)
+	}
+
+	return messages
+}
+
+func decodeSequentially(t *testing.T, messages [][]byte, logger *log.Logger) []string {
+	engine, err := NewFromTemplateFile("../../test/example-decoding-tests/templates.xml", WithLogger(logger))
+	if err != nil {
+		t.Fatalf("unable to load engine: %v", err)
+	}
+
+	decoded := make([]string, len(messages))
+	for index, message := range messages {
+		fixMessage, err := engine.Deserialise(decoder.NewCursor(message))
+		if err != nil {
+			t.Fatalf("unable to decode message %d: %v", index, err)
+		}
+		decoded[index] = fixMessage.String()
+	}
+
+	return decoded
+}
@@ -0,0 +1,135 @@
+package engine
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+func TestProjectionOnlyStoresProjectedTags(t *testing.T) {
+	// Arrange
+	logger := log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)
+	messages := readHexMessages(t, "../../test/example-decoding-tests/snapshot-messages-hex.txt")
+	fastEngine, _ := NewFromTemplateFile("../../test/example-decoding-tests/templates.xml", WithLogger(logger), WithProjection(153, 48, 269, 270, 271))
+
+	// Act
+	fixMessage, err := fastEngine.Deserialise(decoder.NewCursor(messages[0]))
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+	expected := "48=100000085592|268=4|269=5|270=3999.96|271=nil|269=C|270=nil|271=257510|269=h|270=nil|271=nil|269=c|270=nil|271=nil|"
+	if fixMessageAsString := fixMessage.String(); fixMessageAsString != expected {
+		t.Errorf("Expected message and projected message were not equal, expected: %s, actual: %s", expected, fixMessageAsString)
+	}
+}
+
+func TestProjectedValuesMatchFullyDecodedValues(t *testing.T) {
+	// Arrange
+	logger := log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)
+	messages := readHexMessages(t, "../../test/example-decoding-tests/snapshot-messages-hex.txt")
+	fullEngine, _ := NewFromTemplateFile("../../test/example-decoding-tests/templates.xml", WithLogger(logger))
+	projectingEngine, _ := NewFromTemplateFile("../../test/example-decoding-tests/templates.xml", WithLogger(logger), WithProjection(153, 48, 269, 270, 271))
+	topLevelTags := map[uint64]bool{48: true, 268: true}
+	groupTags := map[uint64]bool{269: true, 270: true, 271: true}
+
+	for index, message := range messages {
+		// Act
+		full, err := fullEngine.Deserialise(decoder.NewCursor(message))
+		if err != nil {
+			t.Fatalf("unable to decode message %d: %v", index, err)
+		}
+		projected, err := projectingEngine.Deserialise(decoder.NewCursor(message))
+		if err != nil {
+			t.Fatalf("unable to decode projected message %d: %v", index, err)
+		}
+
+		// Assert
+		if templateType, _ := full.GetTag(35); templateType != "W" {
+			if !reflect.DeepEqual(full, projected) {
+				t.Fatalf("Expected message %d of a template without a projection to be decoded in full, expected: %s, actual: %s", index, full, projected)
+			}
+			continue
+		}
+		assertProjected(t, index, full, projected, topLevelTags)
+
+		fullGroups := full.Tags[268].(fix.SequenceValue).Values
+		projectedGroups := projected.Tags[268].(fix.SequenceValue).Values
+		if len(fullGroups) != len(projectedGroups) {
+			t.Fatalf("Expected message %d to have %d repeating groups, actual: %d", index, len(fullGroups), len(projectedGroups))
+		}
+		for group := range fullGroups {
+			assertProjected(t, index, &fullGroups[group], &projectedGroups[group], groupTags)
+		}
+	}
+}
+
+func TestProjectionDoesNotAllocateOnceWarmedUp(t *testing.T) {
+	// Arrange
+	logger := log.New(ioutil.Discard, "", 0)
+	messages := readHexMessages(t, "../../test/example-decoding-tests/snapshot-messages-hex.txt")
+	fastEngine, _ := NewFromTemplateFile("../../test/example-decoding-tests/templates.xml", WithLogger(logger), WithProjection(153, 48, 269, 270, 271))
+	fixMessage := fix.New()
+	cursor := decoder.NewCursor(nil)
+	decodeAll := func() {
+		for _, message := range messages {
+			cursor.Reset(message)
+			if _, err := fastEngine.DeserialiseReusing(cursor, &fixMessage); err != nil {
+				t.Fatalf("unable to decode message: %v", err)
+			}
+		}
+	}
+	decodeAll()
+
+	// Act
+	allocations := testing.AllocsPerRun(10, decodeAll)
+
+	// Assert
+	if allocations != 0 {
+		t.Errorf("Expected decoding the projected snapshot messages into the same message to make no allocations, made: %v", allocations)
+	}
+}
+
+func TestProjectionOnlyAllocatesProjectedValues(t *testing.T) {
+	// Arrange
+	logger := log.New(ioutil.Discard, "", 0)
+	messages := readHexMessages(t, "../../test/example-decoding-tests/snapshot-messages-hex.txt")
+	fullEngine, _ := NewFromTemplateFile("../../test/example-decoding-tests/templates.xml", WithLogger(logger))
+	projectingEngine, _ := NewFromTemplateFile("../../test/example-decoding-tests/templates.xml", WithLogger(logger), WithProjection(153, 48))
+	decodeWith := func(fastEngine FastEngine) func() {
+		return func() {
+			if _, err := fastEngine.Deserialise(decoder.NewCursor(messages[0])); err != nil {
+				t.Fatalf("unable to decode message: %v", err)
+			}
+		}
+	}
+
+	// Act
+	full := testing.AllocsPerRun(10, decodeWith(fullEngine))
+	projected := testing.AllocsPerRun(10, decodeWith(projectingEngine))
+
+	// Assert
+	if projected >= full/4 {
+		t.Errorf("Expected projecting a single tag to allocate far less than decoding the whole message, full: %v, projected: %v", full, projected)
+	}
+}
+
+func assertProjected(t *testing.T, index int, full *fix.Message, projected *fix.Message, tags map[uint64]bool) {
+	if len(projected.Tags) != len(tags) {
+		t.Fatalf("Expected message %d to only store the projected tags %v, actual: %s", index, tags, projected)
+	}
+	for tag := range tags {
+		if _, isSequence := full.Tags[tag].(fix.SequenceValue); isSequence {
+			continue
+		}
+		if !reflect.DeepEqual(full.Tags[tag], projected.Tags[tag]) {
+			t.Fatalf("Expected tag %d of message %d to be %s, actual: %s", tag, index, full.Tags[tag], projected.Tags[tag])
+		}
+	}
+}
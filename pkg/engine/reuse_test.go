@@ -0,0 +1,118 @@
+package engine
+
+import (
+	"io/ioutil"
+	"log"
+	"testing"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+func TestDeserialiseReusingDecodesSameMessagesAsDeserialise(t *testing.T) {
+	for _, messagesFile := range []string{
+		"../../test/example-decoding-tests/snapshot-messages-hex.txt",
+		"../../test/example-decoding-tests/instrument-messages-hex.txt",
+	} {
+		// Arrange
+		logger := log.New(ioutil.Discard, "", 0)
+		messages := readHexMessages(t, messagesFile)
+		fastEngine, _ := NewFromTemplateFile("../../test/example-decoding-tests/templates.xml", WithLogger(logger))
+		reusingEngine, _ := NewFromTemplateFile("../../test/example-decoding-tests/templates.xml", WithLogger(logger))
+		reusedMessage := fix.New()
+
+		for index, message := range messages {
+			// Act
+			expectedTemplateID, expected, err := fastEngine.DeserialiseWithTemplateID(decoder.NewCursor(message))
+			if err != nil {
+				t.Fatalf("unable to decode message %d: %v", index, err)
+			}
+			templateID, err := reusingEngine.DeserialiseReusing(decoder.NewCursor(message), &reusedMessage)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Got an error decoding message %d into a reused message when none was expected: %s", index, err)
+			}
+			if templateID != expectedTemplateID || reusedMessage.String() != expected.String() {
+				t.Fatalf("Expected message %d decoded into a reused message to equal the message decoded, expected: %d %s, actual: %d %s",
+					index, expectedTemplateID, expected, templateID, reusedMessage.String())
+			}
+		}
+	}
+}
+
+func TestDeserialiseReusingReturnsUnknownTemplateError(t *testing.T) {
+	// Arrange
+	logger := log.New(ioutil.Discard, "", 0)
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(logger))
+	reusedMessage := fix.New()
+
+	// Act
+	templateID, err := fastEngine.DeserialiseReusing(decoder.NewCursor([]byte{192, 129, 131}), &reusedMessage)
+
+	// Assert
+	if _, isUnknown := err.(UnknownTemplateError); !isUnknown || templateID != 1 {
+		t.Errorf("Expected an UnknownTemplateError for template 1, got: %d %v", templateID, err)
+	}
+}
+
+func TestDeserialiseReusingDoesNotAllocateOnceWarmedUp(t *testing.T) {
+	// Arrange
+	logger := log.New(ioutil.Discard, "", 0)
+	messages := readHexMessages(t, "../../test/example-decoding-tests/snapshot-messages-hex.txt")
+	fastEngine, _ := NewFromTemplateFile("../../test/example-decoding-tests/templates.xml", WithLogger(logger))
+	reusedMessage := fix.New()
+	cursor := decoder.NewCursor(nil)
+	decodeAll := func() {
+		for _, message := range messages {
+			cursor.Reset(message)
+			if _, err := fastEngine.DeserialiseReusing(cursor, &reusedMessage); err != nil {
+				t.Fatalf("unable to decode message: %v", err)
+			}
+		}
+	}
+	decodeAll()
+
+	// Act
+	allocations := testing.AllocsPerRun(10, decodeAll)
+
+	// Assert
+	if allocations != 0 {
+		t.Errorf("Expected decoding the snapshot messages into a reused message to make no allocations, made: %v", allocations)
+	}
+}
+
+func BenchmarkDeserialiseSnapshotMessages(b *testing.B) {
+	messages := readHexMessages(b, "../../test/example-decoding-tests/snapshot-messages-hex.txt")
+	fastEngine, _ := NewFromTemplateFile("../../test/example-decoding-tests/templates.xml", WithLogger(log.New(ioutil.Discard, "", 0)))
+	cursor := decoder.NewCursor(nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cursor.Reset(messages[i%len(messages)])
+		if _, err := fastEngine.Deserialise(cursor); err != nil {
+			b.Fatalf("unable to decode message: %v", err)
+		}
+	}
+}
+
+func BenchmarkDeserialiseReusingSnapshotMessages(b *testing.B) {
+	messages := readHexMessages(b, "../../test/example-decoding-tests/snapshot-messages-hex.txt")
+	fastEngine, _ := NewFromTemplateFile("../../test/example-decoding-tests/templates.xml", WithLogger(log.New(ioutil.Discard, "", 0)))
+	reusedMessage := fix.New()
+	cursor := decoder.NewCursor(nil)
+	for _, message := range messages {
+		cursor.Reset(message)
+		fastEngine.DeserialiseReusing(cursor, &reusedMessage)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cursor.Reset(messages[i%len(messages)])
+		if _, err := fastEngine.DeserialiseReusing(cursor, &reusedMessage); err != nil {
+			b.Fatalf("unable to decode message: %v", err)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package engine
+
+import (
+	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+// msgTypeTag is the FIX tag holding the type of a message (35)
+const msgTypeTag = 35
+
+// Handler of a message decoded by a Router
+type Handler func(message *fix.Message) error
+
+// FallbackHandler of a message a Router has no handler for. If the template ID is not within the template store the message can not be decoded, and is nil.
+type FallbackHandler func(templateID uint32, message *fix.Message) error
+
+// MessageDecoder decodes a FAST encoded message along with the template ID it was encoded with, both a FastEngine and a Pool are message decoders
+type MessageDecoder interface {
+	DeserialiseWithTemplateID(message decoder.Reader) (uint32, *fix.Message, error)
+}
+
+// Router decodes messages with a FAST engine and passes each one to the handler for its template ID, or if there is none, the handler for its MsgType (tag 35).
+// Handlers should all be added before messages are routed, the router is then as safe to use from many goroutines as the engine it decodes with.
+type Router struct {
+	engine           MessageDecoder
+	templateHandlers map[uint32]Handler
+	msgTypeHandlers  map[string]Handler
+	fallback         FallbackHandler
+}
+
+// HandleTemplate routes every message encoded with the template with templateID to handler
+func (router *Router) HandleTemplate(templateID uint32, handler Handler) {
+	router.templateHandlers[templateID] = handler
+}
+
+// HandleMsgType routes every message with a MsgType (tag 35) of msgType to handler, unless there is a handler for the template the message was encoded with
+func (router *Router) HandleMsgType(msgType string, handler Handler) {
+	router.msgTypeHandlers[msgType] = handler
+}
+
+// HandleFallback routes every message without a handler to handler, including messages encoded with a template ID that is not within the template store.
+// Without a fallback handler these messages are ignored. The body of a message with an unknown template ID can not be read, so the reader is left part way
+// through the message, and Route returns an UnknownTemplateError whether or not there is a fallback handler.
+func (router *Router) HandleFallback(handler FallbackHandler) {
+	router.fallback = handler
+}
+
+// Route decodes the next message from the reader and passes it to its handler, returning the error from decoding the message or from the handler itself.
+// A message with an unknown template ID is passed to the fallback handler, and an UnknownTemplateError is returned unless the handler returns an error.
+func (router *Router) Route(message decoder.Reader) error {
+	templateID, fixMessage, err := router.engine.DeserialiseWithTemplateID(message)
+	if err != nil {
+		if _, unknownTemplate := err.(UnknownTemplateError); unknownTemplate && router.fallback != nil {
+			if fallbackErr := router.fallback(templateID, nil); fallbackErr != nil {
+				return fallbackErr
+			}
+		}
+		return err
+	}
+
+	if handler, exists := router.templateHandlers[templateID]; exists {
+		return handler(fixMessage)
+	}
+	if msgType, err := fixMessage.GetTag(msgTypeTag); err == nil {
+		if msgTypeAsString, isString := msgType.(string); isString {
+			if handler, exists := router.msgTypeHandlers[msgTypeAsString]; exists {
+				return handler(fixMessage)
+			}
+		}
+	}
+	if router.fallback != nil {
+		return router.fallback(templateID, fixMessage)
+	}
+
+	return nil
+}
+
+// NewRouter that decodes messages using fastEngine, or a Pool, with no handlers
+func NewRouter(fastEngine MessageDecoder) *Router {
+	return &Router{
+		engine:           fastEngine,
+		templateHandlers: make(map[uint32]Handler),
+		msgTypeHandlers:  make(map[string]Handler),
+	}
+}
@@ -0,0 +1,142 @@
+package engine
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+func TestRouterPassesMessageToTemplateHandler(t *testing.T) {
+	// Arrange
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+	router := NewRouter(fastEngine)
+	var handled *fix.Message
+	router.HandleTemplate(144, func(message *fix.Message) error {
+		handled = message
+		return nil
+	})
+	router.HandleMsgType("0", func(message *fix.Message) error {
+		t.Errorf("Expected the template handler to take precedence over the MsgType handler")
+		return nil
+	})
+
+	// Act
+	err := router.Route(bytes.NewBuffer([]byte{192, 1, 144, 138, 139}))
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+	if handled == nil || handled.String() != "1128=9|35=0|34=10|52=11|" {
+		t.Errorf("Expected the heartbeat message to be handled, actual: %v", handled)
+	}
+}
+
+func TestRouterPassesMessageToMsgTypeHandler(t *testing.T) {
+	// Arrange
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+	router := NewRouter(fastEngine)
+	handled := 0
+	router.HandleMsgType("0", func(message *fix.Message) error {
+		handled++
+		return nil
+	})
+
+	// Act
+	err := router.Route(bytes.NewBuffer([]byte{192, 1, 144, 138, 139}))
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+	if handled != 1 {
+		t.Errorf("Expected the heartbeat message to be handled once by its MsgType, actual: %d", handled)
+	}
+}
+
+func TestRouterPassesUnknownTemplateToFallbackHandler(t *testing.T) {
+	// Arrange
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+	router := NewRouter(fastEngine)
+	var fallbackTemplateID uint32
+	router.HandleFallback(func(templateID uint32, message *fix.Message) error {
+		fallbackTemplateID = templateID
+		if message != nil {
+			t.Errorf("Expected no message for a template that is not within the template store, actual: %s", message)
+		}
+		return nil
+	})
+
+	// Act
+	err := router.Route(bytes.NewBuffer([]byte{192, 1, 150, 130, 210, 129, 210, 130, 131}))
+
+	// Assert
+	if unknownTemplate, ok := err.(UnknownTemplateError); !ok || unknownTemplate.TemplateID != 150 {
+		t.Errorf("Expected an unknown template error for template id 150 once the fallback handler was called, actual: %v", err)
+	}
+	if fallbackTemplateID != 150 {
+		t.Errorf("Expected the fallback handler to be given template id 150, actual: %d", fallbackTemplateID)
+	}
+}
+
+func TestRouterWithoutFallbackReturnsUnknownTemplateError(t *testing.T) {
+	// Arrange
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+	router := NewRouter(fastEngine)
+
+	// Act
+	err := router.Route(bytes.NewBuffer([]byte{192, 1, 150, 130, 210, 129, 210, 130, 131}))
+
+	// Assert
+	if unknownTemplate, ok := err.(UnknownTemplateError); !ok || unknownTemplate.TemplateID != 150 {
+		t.Errorf("Expected an unknown template error for template id 150, actual: %v", err)
+	}
+}
+
+func TestRouterReturnsErrorFromHandler(t *testing.T) {
+	// Arrange
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+	router := NewRouter(fastEngine)
+	handlerErr := fmt.Errorf("unable to handle heartbeat")
+	router.HandleTemplate(144, func(message *fix.Message) error {
+		return handlerErr
+	})
+
+	// Act
+	err := router.Route(bytes.NewBuffer([]byte{192, 1, 144, 138, 139}))
+
+	// Assert
+	if err != handlerErr {
+		t.Errorf("Expected the error returned by the handler, actual: %v", err)
+	}
+}
+
+func TestRouterRoutesEveryTemplateInMessageFile(t *testing.T) {
+	// Arrange
+	logger := log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)
+	messages := readHexMessages(t, "../../test/example-decoding-tests/instrument-messages-hex.txt")
+	pool, _ := NewPoolFromTemplateFile("../../test/example-decoding-tests/templates.xml", WithLogger(logger))
+	router := NewRouter(pool)
+	routed := map[uint32]int{}
+	router.HandleFallback(func(templateID uint32, message *fix.Message) error {
+		routed[templateID]++
+		return nil
+	})
+
+	// Act
+	for index, message := range messages {
+		if err := router.Route(decoder.NewCursor(message)); err != nil {
+			t.Fatalf("Got an error routing message %d when none was expected: %s", index, err)
+		}
+	}
+
+	// Assert
+	if routed[141] == 0 || routed[122] == 0 || routed[141]+routed[122] != len(messages) {
+		t.Errorf("Expected every security list (141) and sequence reset (122) message to be routed to the fallback handler, actual: %v", routed)
+	}
+}
@@ -0,0 +1,101 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
+	"github.com/Guardian-Development/fastengine/pkg/fast/header"
+	"github.com/Guardian-Development/fastengine/pkg/fast/template/program"
+)
+
+// snapshotMagic begins every snapshot of the dictionaries of an engine
+var snapshotMagic = []byte("FASTDICT")
+
+// snapshotVersion is the version of the format of snapshots written by Snapshot, Restore rejects snapshots of any other version
+const snapshotVersion uint16 = 1
+
+// Snapshot of the dictionaries of previous values of the engine, so an engine loaded with the same templates can Restore them and carry on decoding and
+// encoding the same stream, for example a standby taking over from a feed handler that has failed. The snapshot begins with its format version and the
+// fingerprint of the templates of the engine, followed by the entry of every dictionary key of the templates, in the dictionaries used to deserialise and
+// then serialise messages. Each entry is written with its state, and an assigned entry with the type and value it holds.
+func (engine *fastEngine) Snapshot() ([]byte, error) {
+	snapshot := append([]byte{}, snapshotMagic...)
+	snapshot = append(snapshot, byte(snapshotVersion>>8), byte(snapshotVersion))
+
+	var fingerprint [8]byte
+	binary.BigEndian.PutUint64(fingerprint[:], engine.fingerprint())
+	snapshot = append(snapshot, fingerprint[:]...)
+
+	keys := engine.keys()
+	snapshot = engine.globalDictionary.AppendSnapshot(snapshot, keys)
+	snapshot = engine.encoderDictionary.AppendSnapshot(snapshot, keys)
+	return snapshot, nil
+}
+
+// Restore the dictionaries of previous values of the engine from a snapshot taken by Snapshot. The snapshot is rejected if it is of another version, or
+// was taken by an engine with different templates, in which case the dictionaries of the engine are left as they were.
+func (engine *fastEngine) Restore(snapshot []byte) error {
+	headerLength := len(snapshotMagic) + 2 + 8
+	if len(snapshot) < headerLength || !bytes.Equal(snapshot[:len(snapshotMagic)], snapshotMagic) {
+		engine.logger.Printf("unable to restore dictionaries, the bytes given are not a snapshot")
+		return fmt.Errorf("unable to restore dictionaries, the bytes given are not a snapshot")
+	}
+
+	version := binary.BigEndian.Uint16(snapshot[len(snapshotMagic):])
+	if version != snapshotVersion {
+		engine.logger.Printf("unable to restore dictionaries from snapshot of version %d, only version %d is supported", version, snapshotVersion)
+		return fmt.Errorf("unable to restore dictionaries from snapshot of version %d, only version %d is supported", version, snapshotVersion)
+	}
+
+	fingerprint := binary.BigEndian.Uint64(snapshot[len(snapshotMagic)+2:])
+	if expected := engine.fingerprint(); fingerprint != expected {
+		engine.logger.Printf("unable to restore dictionaries from snapshot of different templates, snapshot fingerprint: %x, engine fingerprint: %x", fingerprint, expected)
+		return fmt.Errorf("unable to restore dictionaries from snapshot of different templates, snapshot fingerprint: %x, engine fingerprint: %x", fingerprint, expected)
+	}
+
+	globalDictionary, encoderDictionary := dictionary.NewOf(engine.templateStore.Slots), dictionary.NewOf(engine.templateStore.Slots)
+	rest, err := globalDictionary.Restore(snapshot[headerLength:])
+	if err == nil {
+		rest, err = encoderDictionary.Restore(rest)
+	}
+	if err == nil && len(rest) > 0 {
+		err = fmt.Errorf("snapshot has %d bytes after the end of the dictionaries", len(rest))
+	}
+	if err != nil {
+		engine.logger.Printf("unable to restore dictionaries from snapshot: %v", err)
+		return fmt.Errorf("unable to restore dictionaries from snapshot, reason: %v", err)
+	}
+
+	engine.globalDictionary, engine.encoderDictionary = globalDictionary, encoderDictionary
+	return nil
+}
+
+// fingerprint of the templates of the engine, which is computed from their programs so the same templates have the same fingerprint in any process
+func (engine *fastEngine) fingerprint() uint64 {
+	return program.Fingerprint(engine.programs)
+}
+
+// keys of every dictionary entry used by the engine, which is the template id of the header and the key of each field of the templates
+func (engine *fastEngine) keys() []dictionary.Key {
+	keys := []dictionary.Key{header.TemplateIDKey}
+	seen := map[dictionary.Key]bool{header.TemplateIDKey: true}
+	templateIDs := make([]uint32, 0, len(engine.programs))
+	for templateID := range engine.programs {
+		templateIDs = append(templateIDs, templateID)
+	}
+	sort.Slice(templateIDs, func(i, j int) bool { return templateIDs[i] < templateIDs[j] })
+
+	for _, templateID := range templateIDs {
+		for _, key := range engine.programs[templateID].Keys() {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	return keys
+}
@@ -0,0 +1,116 @@
+package engine
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/fielddecimal"
+	"github.com/Guardian-Development/fastengine/pkg/fast/template/loader"
+	"github.com/Guardian-Development/fastengine/pkg/fast/template/store"
+)
+
+func TestRestoredEngineCarriesOnWithPreviousValuesOfSnapshot(t *testing.T) {
+	// Arrange second message has no template id: 10000000 pmap, 10001100 34 = 12, 10001101 52 = 13
+	logger := log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)
+	primary, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(logger), WithDictionaryReset(ResetNever))
+	first, _ := primary.Deserialise(bytes.NewBuffer([]byte{192, 1, 144, 138, 139}))
+	primary.Serialise(first, 144)
+	snapshot, _ := primary.Snapshot()
+	standby, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(logger), WithDictionaryReset(ResetNever))
+
+	// Act
+	err := standby.Restore(snapshot)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Got an error restoring the snapshot when none was expected: %s", err)
+	}
+	fixMessage, err := standby.Deserialise(bytes.NewBuffer([]byte{128, 140, 141}))
+	if err != nil || fixMessage.String() != "1128=9|35=0|34=12|52=13|" {
+		t.Errorf("Expected the template id to be copied from the snapshot, message: %v, error: %v", fixMessage, err)
+	}
+	encoded, err := standby.Serialise(first, 144)
+	if err != nil || !bytes.Equal(encoded, []byte{128, 138, 139}) {
+		t.Errorf("Expected the template id to be encoded as copied from the snapshot, encoded: %v, error: %v", encoded, err)
+	}
+}
+
+// fallbackDecimal is a decimal the compiler does not know, so it is decoded by the unit itself rather than by the instructions of the program
+type fallbackDecimal struct {
+	fielddecimal.FieldDecimal
+}
+
+// storeOfFallbackDecimal holds template 144, which is a decimal with a copy operator on its exponent and mantissa that is decoded by the unit itself
+func storeOfFallbackDecimal(t *testing.T, logger *log.Logger) store.Store {
+	file, _ := os.Open("../../test/template-loader-tests/test_load_copy_operation_on_all_supported_types.xml")
+	defer file.Close()
+	templateStore, err := loader.Load(file, logger)
+	if err != nil {
+		t.Fatalf("Got an error loading templates when none was expected: %s", err)
+	}
+
+	template := templateStore.Templates[144]
+	template.TemplateUnits = []store.Unit{fallbackDecimal{template.TemplateUnits[6].(fielddecimal.FieldDecimal)}}
+	return store.Store{Templates: map[uint32]store.Template{144: template}}
+}
+
+func TestRestoredEngineCarriesOnWithPreviousValuesOfUnitsDecodedByTheUnit(t *testing.T) {
+	// Arrange first message: 11110000 pmap, 00000001 10010000 template id = 144, 11111110 exponent = -2, 10000011 mantissa = 3
+	logger := log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)
+	primary := New(storeOfFallbackDecimal(t, logger), WithLogger(logger), WithDictionaryReset(ResetNever))
+	primary.Deserialise(bytes.NewBuffer([]byte{240, 1, 144, 254, 131}))
+	snapshot, _ := primary.Snapshot()
+	expected, _ := primary.Deserialise(bytes.NewBuffer([]byte{128}))
+	standby := New(storeOfFallbackDecimal(t, logger), WithLogger(logger), WithDictionaryReset(ResetNever))
+
+	// Act
+	err := standby.Restore(snapshot)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Got an error restoring the snapshot when none was expected: %s", err)
+	}
+	fixMessage, err := standby.Deserialise(bytes.NewBuffer([]byte{128}))
+	if err != nil || expected == nil || fixMessage.String() != expected.String() {
+		t.Errorf("Expected the exponent and mantissa to be copied from the snapshot, expected: %v, message: %v, error: %v", expected, fixMessage, err)
+	}
+}
+
+func TestRestoreRejectsSnapshotOfDifferentTemplates(t *testing.T) {
+	// Arrange
+	logger := log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)
+	primary, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(logger), WithDictionaryReset(ResetNever))
+	primary.Deserialise(bytes.NewBuffer([]byte{192, 1, 144, 138, 139}))
+	snapshot, _ := primary.Snapshot()
+	standby, _ := NewFromTemplateFile("../../test/test_shared_key_template.xml", WithLogger(logger), WithDictionaryReset(ResetNever))
+	standby.Deserialise(bytes.NewBuffer([]byte{224, 129, 133}))
+
+	// Act
+	err := standby.Restore(snapshot)
+
+	// Assert
+	if err == nil {
+		t.Errorf("Expected an error restoring a snapshot of different templates")
+	}
+	fixMessage, err := standby.Deserialise(bytes.NewBuffer([]byte{128}))
+	if err != nil || fixMessage.String() != "134=5|135=5|" {
+		t.Errorf("Expected the dictionaries to be left as they were, message: %v, error: %v", fixMessage, err)
+	}
+}
+
+func TestRestoreRejectsSnapshotOfAnotherVersion(t *testing.T) {
+	// Arrange
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+	snapshot, _ := fastEngine.Snapshot()
+	snapshot[len(snapshotMagic)+1]++
+
+	// Act
+	err := fastEngine.Restore(snapshot)
+
+	// Assert
+	if err == nil {
+		t.Errorf("Expected an error restoring a snapshot of another version")
+	}
+}
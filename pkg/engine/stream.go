@@ -0,0 +1,71 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+// Result of decoding a single message from a stream. Offset is the number of bytes into the stream the message started at.
+type Result struct {
+	Message *fix.Message
+	Err     error
+	Offset  int64
+}
+
+// DecodeStream decodes the FAST encoded messages read from r one after another using fastEngine, delivering a Result for each message in order on the
+// returned channel. At most capacity results are buffered, once the channel is full decoding waits for the consumer to catch up.
+// The channel is closed once r ends cleanly between two messages, once ctx is cancelled (results not yet received may be dropped), or after a Result
+// with an error is sent. If r ends part way through a message the error wraps io.ErrUnexpectedEOF.
+// Cancelling ctx cannot interrupt a Read that is blocked on r, if r can block indefinitely (a network connection for example) it should also be closed to stop decoding.
+func DecodeStream(ctx context.Context, fastEngine FastEngine, r io.Reader, capacity int) <-chan Result {
+	if capacity < 0 {
+		capacity = 0
+	}
+	results := make(chan Result, capacity)
+
+	go func() {
+		defer close(results)
+
+		reader := decoder.NewCountingReader(r)
+		for ctx.Err() == nil {
+			offset := reader.Offset()
+			if _, err := reader.Peek(1); err != nil {
+				if err != io.EOF {
+					send(ctx, results, Result{Err: fmt.Errorf("unable to read message at offset %d, reason: %v", offset, err), Offset: offset})
+				}
+				return
+			}
+
+			message, err := fastEngine.Deserialise(reader)
+			if err != nil {
+				if _, peekErr := reader.Peek(1); peekErr == io.EOF {
+					err = fmt.Errorf("%w: message at offset %d ended before it was fully decoded, reason: %v", io.ErrUnexpectedEOF, offset, err)
+				} else {
+					err = fmt.Errorf("unable to decode message at offset %d, reason: %v", offset, err)
+				}
+				send(ctx, results, Result{Err: err, Offset: offset})
+				return
+			}
+
+			if !send(ctx, results, Result{Message: message, Offset: offset}) {
+				return
+			}
+		}
+	}()
+
+	return results
+}
+
+// send the result, waiting for room on the results channel unless ctx is cancelled first. Returns whether the result was sent.
+func send(ctx context.Context, results chan<- Result, result Result) bool {
+	select {
+	case results <- result:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
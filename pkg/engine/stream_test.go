@@ -0,0 +1,177 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+// heartbeat messages (pmap, template 144, 34 = 10, 52 = 11) and (pmap, template 144, 34 = 12, 52 = 13)
+var streamOfHeartbeats = []byte{192, 1, 144, 138, 139, 192, 1, 144, 140, 141}
+
+// repeatingReader endlessly repeats the message it was given
+type repeatingReader struct {
+	message []byte
+	offset  int
+}
+
+func (reader *repeatingReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = reader.message[reader.offset%len(reader.message)]
+		reader.offset++
+	}
+	return len(p), nil
+}
+
+// countingEngine counts the number of messages it has been asked to decode
+type countingEngine struct {
+	FastEngine
+	decoded int64
+}
+
+func (engine *countingEngine) Deserialise(message decoder.Reader) (*fix.Message, error) {
+	atomic.AddInt64(&engine.decoded, 1)
+	return engine.FastEngine.Deserialise(message)
+}
+
+func TestCanDecodeStreamOfMessagesInOrder(t *testing.T) {
+	// Arrange
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+	expectedOffsets := []int64{0, 5}
+	expectedMessages := []string{"1128=9|35=0|34=10|52=11|", "1128=9|35=0|34=12|52=13|"}
+
+	// Act
+	results := DecodeStream(context.Background(), fastEngine, iotest.HalfReader(bytes.NewReader(streamOfHeartbeats)), 1)
+
+	// Assert
+	index := 0
+	for result := range results {
+		if result.Err != nil {
+			t.Fatalf("Got an error when none was expected: %s", result.Err)
+		}
+		if result.Offset != expectedOffsets[index] {
+			t.Errorf("Expected offset and actual offset were not equal, expected: %d, actual: %d", expectedOffsets[index], result.Offset)
+		}
+		if result.Message.String() != expectedMessages[index] {
+			t.Errorf("Expected message and actual message were not equal, expected: %s, actual: %s", expectedMessages[index], result.Message.String())
+		}
+		index++
+	}
+	if index != len(expectedMessages) {
+		t.Errorf("Expected all messages in stream to be decoded, expected: %d, actual: %d", len(expectedMessages), index)
+	}
+}
+
+func TestDecodeStreamOfEmptyReaderClosesWithoutResult(t *testing.T) {
+	// Arrange
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+
+	// Act
+	results := DecodeStream(context.Background(), fastEngine, bytes.NewReader([]byte{}), 0)
+
+	// Assert
+	for result := range results {
+		t.Errorf("Expected no results from empty stream, but got: %#v", result)
+	}
+}
+
+func TestDecodeStreamReportsTruncatedMessageAsUnexpectedEOF(t *testing.T) {
+	// Arrange
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+	stream := append(append([]byte{}, streamOfHeartbeats...), 192, 1, 144, 10)
+
+	// Act
+	results := DecodeStream(context.Background(), fastEngine, bytes.NewReader(stream), 4)
+
+	// Assert
+	var last Result
+	count := 0
+	for result := range results {
+		last = result
+		count++
+	}
+	if count != 3 {
+		t.Errorf("Expected two messages and an error, but got %d results", count)
+	}
+	if !errors.Is(last.Err, io.ErrUnexpectedEOF) {
+		t.Errorf("Expected truncated message to be reported as io.ErrUnexpectedEOF, but got: %v", last.Err)
+	}
+	if last.Offset != 10 {
+		t.Errorf("Expected offset of truncated message to be reported, expected: 10, actual: %d", last.Offset)
+	}
+}
+
+func TestDecodeStreamStopsAtMessageThatFailsToDecode(t *testing.T) {
+	// Arrange template 150 is not in the store
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+	stream := append([]byte{192, 1, 150, 138, 139}, streamOfHeartbeats...)
+
+	// Act
+	results := DecodeStream(context.Background(), fastEngine, bytes.NewReader(stream), 4)
+
+	// Assert
+	result, open := <-results
+	if !open || result.Err == nil || !strings.Contains(result.Err.Error(), "offset 0") {
+		t.Errorf("Expected error decoding message at offset 0, but got: %#v", result)
+	}
+	if _, open := <-results; open {
+		t.Errorf("Expected stream to be closed after error")
+	}
+}
+
+func TestDecodeStreamWaitsForConsumerOnceCapacityReached(t *testing.T) {
+	// Arrange
+	heartbeatEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+	fastEngine := &countingEngine{FastEngine: heartbeatEngine}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Act
+	results := DecodeStream(ctx, fastEngine, &repeatingReader{message: streamOfHeartbeats}, 2)
+	time.Sleep(50 * time.Millisecond)
+
+	// Assert the channel holds 2 results, and a third is waiting to be sent
+	if decoded := atomic.LoadInt64(&fastEngine.decoded); decoded > 3 {
+		t.Errorf("Expected decoding to wait for the consumer once capacity was reached, but decoded: %d", decoded)
+	}
+	for i := 0; i < 10; i++ {
+		if result := <-results; result.Err != nil {
+			t.Errorf("Got an error when none was expected: %s", result.Err)
+		}
+	}
+}
+
+func TestDecodeStreamClosesWhenContextCancelled(t *testing.T) {
+	// Arrange
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+	ctx, cancel := context.WithCancel(context.Background())
+	results := DecodeStream(ctx, fastEngine, &repeatingReader{message: streamOfHeartbeats}, 1)
+	<-results
+
+	// Act
+	cancel()
+
+	// Assert
+	closed := make(chan struct{})
+	go func() {
+		for range results {
+		}
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Errorf("Expected stream to be closed once context was cancelled")
+	}
+}
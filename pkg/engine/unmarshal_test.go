@@ -0,0 +1,43 @@
+package engine
+
+import (
+	"log"
+	"os"
+	"testing"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
+)
+
+func TestCanDeserialiseIntoStructTaggedWithNames(t *testing.T) {
+	// Arrange
+	logger := log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)
+	messages := readHexMessages(t, "../../test/example-decoding-tests/snapshot-messages-hex.txt")
+	fastEngine, _ := NewFromTemplateFile("../../test/example-decoding-tests/templates.xml", WithLogger(logger))
+	var snapshot struct {
+		MsgType    string `fast:"35"`
+		SecurityID uint64 `fast:"SecurityID"`
+		MDEntries  []struct {
+			MDEntryType string   `fast:"MDEntryType"`
+			MDEntryPx   *float64 `fast:"MDEntryPx"`
+			MDEntrySize *int64   `fast:"271"`
+		} `fast:"MDEntries"`
+	}
+
+	// Act
+	templateID, err := fastEngine.DeserialiseInto(decoder.NewCursor(messages[0]), &snapshot)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+	if templateID != 153 || snapshot.MsgType != "W" || snapshot.SecurityID != 100000085592 || len(snapshot.MDEntries) != 4 {
+		t.Fatalf("Expected the snapshot message to be unmarshalled, actual: %d %+v", templateID, snapshot)
+	}
+	first := snapshot.MDEntries[0]
+	if first.MDEntryType != "5" || first.MDEntryPx == nil || *first.MDEntryPx != 3999.96 || first.MDEntrySize != nil {
+		t.Errorf("Expected the first repeating group to be unmarshalled, actual: %+v", first)
+	}
+	if second := snapshot.MDEntries[1]; second.MDEntryPx != nil || second.MDEntrySize == nil || *second.MDEntrySize != 257510 {
+		t.Errorf("Expected the second repeating group to be unmarshalled, actual: %+v", second)
+	}
+}
@@ -0,0 +1,202 @@
+package engine
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+// stringVisitor builds the same string representation as fix.Message, from the values it is visited with
+type stringVisitor struct {
+	builder strings.Builder
+	names   []string
+	depth   int
+}
+
+func (visitor *stringVisitor) OnField(tag uint64, name string, value fix.Value) {
+	visitor.builder.WriteString(strconv.FormatUint(tag, 10))
+	visitor.builder.WriteString("=")
+	visitor.builder.WriteString(value.String())
+	visitor.names = append(visitor.names, name)
+}
+
+func (visitor *stringVisitor) OnSequenceStart(tag uint64, length uint32) {
+	visitor.builder.WriteString(strconv.FormatUint(tag, 10))
+	visitor.builder.WriteString("=")
+	visitor.builder.WriteString(strconv.FormatUint(uint64(length), 10))
+	visitor.builder.WriteString("|")
+	visitor.depth++
+}
+
+func (visitor *stringVisitor) OnGroupStart(index uint32) {
+}
+
+func (visitor *stringVisitor) OnSequenceEnd() {
+	visitor.depth--
+}
+
+// countingVisitor counts the parts of the messages it is visited with, without allocating
+type countingVisitor struct {
+	fields, sequences, groups int
+}
+
+func (visitor *countingVisitor) OnField(tag uint64, name string, value fix.Value) {
+	visitor.fields++
+}
+
+func (visitor *countingVisitor) OnSequenceStart(tag uint64, length uint32) {
+	visitor.sequences++
+}
+
+func (visitor *countingVisitor) OnGroupStart(index uint32) {
+	visitor.groups++
+}
+
+func (visitor *countingVisitor) OnSequenceEnd() {
+}
+
+func TestCanVisitHeartbeatMessage(t *testing.T) {
+	// Arrange
+	/*
+		Message format:
+		11000000           pmap
+		00000001 10010000  template 144
+		10001010           34 = 10
+		10001011           52 = 11
+	*/
+	message := bytes.NewBuffer([]byte{192, 1, 144, 138, 139})
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+	visitor := &stringVisitor{}
+
+	// Act
+	templateID, err := fastEngine.Visit(message, visitor)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+	if templateID != 144 {
+		t.Errorf("Expected template id 144, actual: %d", templateID)
+	}
+	if visited := visitor.builder.String(); visited != "1128=9|35=0|34=10|52=11|" {
+		t.Errorf("Expected message and visited message were not equal, actual: %s", visited)
+	}
+	if names := strings.Join(visitor.names, ","); names != "ApplVerID,MsgType,MsgSeqNum,SendingTime" {
+		t.Errorf("Expected the name of each field from the template, actual: %s", names)
+	}
+}
+
+func TestVisitingMessagesWithSequencesMatchesDeserialise(t *testing.T) {
+	// Arrange
+	logger := log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)
+	messages := readHexMessages(t, "../../test/example-decoding-tests/snapshot-messages-hex.txt")
+	messages = append(messages, readHexMessages(t, "../../test/example-decoding-tests/instrument-messages-hex.txt")...)
+	expected := decodeSequentially(t, messages, logger)
+	fastEngine, _ := NewFromTemplateFile("../../test/example-decoding-tests/templates.xml", WithLogger(logger))
+
+	for index, message := range messages {
+		visitor := &stringVisitor{}
+
+		// Act
+		_, err := fastEngine.Visit(decoder.NewCursor(message), visitor)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Got an error visiting message %d when none was expected: %s", index, err)
+		}
+		if visited := visitor.builder.String(); visited != expected[index] {
+			t.Fatalf("Expected message and visited message %d were not equal, expected: %s, actual: %s", index, expected[index], visited)
+		}
+		if visitor.depth != 0 {
+			t.Fatalf("Expected every sequence started in message %d to be ended, unended sequences: %d", index, visitor.depth)
+		}
+	}
+}
+
+func TestVisitUnknownTemplateReturnsError(t *testing.T) {
+	// Arrange
+	message := bytes.NewBuffer([]byte{192, 1, 150, 130, 210, 129, 210, 130, 131})
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+
+	// Act
+	_, err := fastEngine.Visit(message, &stringVisitor{})
+
+	// Assert
+	if err == nil {
+		t.Errorf("Expected error informing user template ID is not found in store for message")
+	}
+}
+
+func TestVisitMessageWithoutTemplateIDReturnsHeaderError(t *testing.T) {
+	// Arrange 10000000 pmap without the template id bit, so the template id is copied from a previous message when there is none
+	message := bytes.NewBuffer([]byte{128, 138, 139})
+	fastEngine, _ := NewFromTemplateFile("../../test/test_heartbeat_template.xml", WithLogger(log.New(os.Stdout, "engine: ", log.Ldate|log.Ltime|log.Lshortfile)))
+	visitor := &countingVisitor{}
+
+	// Act
+	templateID, err := fastEngine.Visit(message, visitor)
+
+	// Assert
+	if _, isHeaderError := err.(HeaderError); !isHeaderError || templateID != 0 {
+		t.Errorf("Expected a HeaderError for a message without a template id, got: %d %v", templateID, err)
+	}
+	if visitor.fields != 0 {
+		t.Errorf("Expected no fields to be visited when the header could not be read, visited: %d", visitor.fields)
+	}
+}
+
+func TestVisitDoesNotAllocateOnceWarmedUp(t *testing.T) {
+	// Arrange
+	logger := log.New(ioutil.Discard, "", 0)
+	messages := readHexMessages(t, "../../test/example-decoding-tests/snapshot-messages-hex.txt")
+	fastEngine, _ := NewFromTemplateFile("../../test/example-decoding-tests/templates.xml", WithLogger(logger))
+	visitor := &countingVisitor{}
+	cursor := decoder.NewCursor(nil)
+	visitAll := func() {
+		for _, message := range messages {
+			cursor.Reset(message)
+			if _, err := fastEngine.Visit(cursor, visitor); err != nil {
+				t.Fatalf("unable to visit message: %v", err)
+			}
+		}
+	}
+	visitAll()
+
+	// Act
+	allocations := testing.AllocsPerRun(10, visitAll)
+
+	// Assert
+	if allocations != 0 {
+		t.Errorf("Expected visiting the snapshot messages to make no allocations, made: %v", allocations)
+	}
+	if visitor.fields == 0 || visitor.sequences == 0 || visitor.groups == 0 {
+		t.Errorf("Expected the fields and sequences of the snapshot messages to be visited, visited: %+v", *visitor)
+	}
+}
+
+func BenchmarkVisitSnapshotMessages(b *testing.B) {
+	messages := readHexMessages(b, "../../test/example-decoding-tests/snapshot-messages-hex.txt")
+	fastEngine, _ := NewFromTemplateFile("../../test/example-decoding-tests/templates.xml", WithLogger(log.New(ioutil.Discard, "", 0)))
+	visitor := &countingVisitor{}
+	cursor := decoder.NewCursor(nil)
+	for _, message := range messages {
+		cursor.Reset(message)
+		fastEngine.Visit(cursor, visitor)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cursor.Reset(messages[i%len(messages)])
+		if _, err := fastEngine.Visit(cursor, visitor); err != nil {
+			b.Fatalf("unable to visit message: %v", err)
+		}
+	}
+}
@@ -1,8 +1,9 @@
 package decoder
 
 import (
-	"bytes"
 	"fmt"
+	"io"
+	"math"
 	"math/big"
 	"strings"
 
@@ -12,8 +13,12 @@ import (
 
 // ReadUInt32 reads the next FAST encoded value off the inputSource, treating it as a uint32 value. If the next value would overflow a uint32 an err is returned.
 // i.e. 00010010 10001000 would become 100100001000
-func ReadUInt32(inputSource *bytes.Buffer) (value.UInt32Value, error) {
-	var readValue uint32 = 0
+func ReadUInt32(inputSource Reader) (value.UInt32Value, error) {
+	if readValue, ok := fastUnsigned(inputSource, 5); ok {
+		return value.UInt32Value{Value: uint32(readValue)}, nil
+	}
+
+	var readValue uint64 = 0
 
 	for i := 0; i < 5; i++ {
 		b, err := inputSource.ReadByte()
@@ -23,22 +28,26 @@ func ReadUInt32(inputSource *bytes.Buffer) (value.UInt32Value, error) {
 
 		// 128 = 10000000, this will equal 128 if we have a stop bit present (most significant bit is 1)
 		if result := b & 128; result == 128 {
-			removedStopBit := uint32(b & 127)
+			removedStopBit := uint64(b & 127)
 			readValue = readValue<<7 | removedStopBit
-			return value.UInt32Value{Value: readValue}, nil
+			return value.UInt32Value{Value: uint32(readValue)}, nil
 		}
 
 		// no stop bit present so 0 in most significant bit, add this byte to the uint we are reading
-		readValue = readValue<<7 | uint32(b)
+		readValue = readValue<<7 | uint64(b)
 	}
 
-	return value.UInt32Value{}, fmt.Errorf("%s, uint32", errors.R6)
+	readValue, err := readOverlongUnsigned(inputSource, readValue, true, math.MaxUint32, "uint32")
+	if err != nil {
+		return value.UInt32Value{}, err
+	}
+	return value.UInt32Value{Value: uint32(readValue)}, nil
 }
 
 // ReadOptionalUInt32 reads a uint32 off the buffer. If the value returned is 0, this is marked as nil, and nil is returned.
 // Due to needing to use 0 as a nil value for optionals, the value returned by this is: value - 1.
 // i.e. 10000000 would become nil, 10000001 would become 0
-func ReadOptionalUInt32(inputSource *bytes.Buffer) (value.Value, error) {
+func ReadOptionalUInt32(inputSource Reader) (value.Value, error) {
 	readValue, err := ReadUInt64(inputSource) // allow for overflow
 	if err != nil {
 		return value.NullValue{}, fmt.Errorf("unable to read value before assesing nullability, reason: %s", err)
@@ -53,49 +62,54 @@ func ReadOptionalUInt32(inputSource *bytes.Buffer) (value.Value, error) {
 
 // ReadInt32 reads the next FAST encoded value off the inputSource, treating it as an int32 value (2's compliment encoded). If the next value would overflow an int32 an err is returned.
 // i.e. 11111111 01001110 would become 11111111001110 -> 11001110 -> -50
-func ReadInt32(inputSource *bytes.Buffer) (value.Int32Value, error) {
-	var readValue int32 = 0
+func ReadInt32(inputSource Reader) (value.Int32Value, error) {
+	if readValue, ok := fastSigned(inputSource, 5); ok {
+		return value.Int32Value{Value: int32(readValue)}, nil
+	}
+
+	var readValue int64 = 0
 
 	b, err := inputSource.ReadByte()
 	if err != nil {
 		return value.Int32Value{}, fmt.Errorf("unable to read byte off byte buffer, reason: %s", err)
 	}
 
-	// 64 = 01000000, indicating this is negative so we should start with all 1's int32 (-1)
+	// 64 = 01000000, indicating this is negative so we should start with all 1's (-1)
 	if isNegative := b & 64; isNegative == 64 {
 		readValue = -1
 	}
 
-	// reset byte buffer by the one byte we had to read to determine negative/positive number
-	err = inputSource.UnreadByte()
-	if err != nil {
-		return value.Int32Value{}, fmt.Errorf("unable to rewind byte buffer, reason: %s", err)
-	}
-
 	for i := 0; i < 5; i++ {
-		b, err := inputSource.ReadByte()
-		if err != nil {
-			return value.Int32Value{}, fmt.Errorf("unable to read byte off byte buffer, reason: %s", err)
+		// the first byte has already been read to determine negative/positive number
+		if i > 0 {
+			b, err = inputSource.ReadByte()
+			if err != nil {
+				return value.Int32Value{}, fmt.Errorf("unable to read byte off byte buffer, reason: %s", err)
+			}
 		}
 
 		// 128 = 10000000, this will equal 128 if we have a stop bit present (most significant bit is 1)
 		if result := b & 128; result == 128 {
-			removedStopBit := int32(b & 127)
+			removedStopBit := int64(b & 127)
 			readValue = readValue<<7 | removedStopBit
-			return value.Int32Value{Value: readValue}, nil
+			return value.Int32Value{Value: int32(readValue)}, nil
 		}
 
 		// no stop bit present so 0 in most significant bit, add this byte to the int we are reading
-		readValue = readValue<<7 | int32(b)
+		readValue = readValue<<7 | int64(b)
 	}
 
-	return value.Int32Value{}, fmt.Errorf("%s, int32", errors.R6)
+	readValue, err = readOverlongSigned(inputSource, readValue, true, math.MinInt32, math.MaxInt32, "int32")
+	if err != nil {
+		return value.Int32Value{}, err
+	}
+	return value.Int32Value{Value: int32(readValue)}, nil
 }
 
 // ReadOptionalInt32 reads an int32 off the buffer. If the value returned is 0, this is marked as nil, and nil is returned.
 // Due to needing to use 0 as a nil value for optionals, the value returned by this is: value - 1 for positive numbers only.
 // i.e. 10000000 would become nil, 10000001 would become 0
-func ReadOptionalInt32(inputSource *bytes.Buffer) (value.Value, error) {
+func ReadOptionalInt32(inputSource Reader) (value.Value, error) {
 	readValue, err := ReadInt64(inputSource) // allow for overflow
 	if err != nil {
 		return value.Int32Value{}, fmt.Errorf("unable to read value before assesing nullability, reason: %s", err)
@@ -114,13 +128,26 @@ func ReadOptionalInt32(inputSource *bytes.Buffer) (value.Value, error) {
 
 // ReadUInt64 reads the next FAST encoded value off the inputSource, treating it as a uint64 value. If the next value would overflow a uint64 an err is returned.
 // i.e. 00010010 10001000 would become 100100001000
-func ReadUInt64(inputSource *bytes.Buffer) (value.UInt64Value, error) {
+func ReadUInt64(inputSource Reader) (value.UInt64Value, error) {
+	if readValue, ok := fastUnsigned(inputSource, 10); ok {
+		return value.UInt64Value{Value: readValue}, nil
+	}
+
 	var readValue uint64 = 0
 
+	first, err := inputSource.ReadByte()
+	if err != nil {
+		return value.UInt64Value{}, fmt.Errorf("unable to read byte off byte buffer, reason: %s", err)
+	}
+
+	b := first
 	for i := 0; i < 10; i++ {
-		b, err := inputSource.ReadByte()
-		if err != nil {
-			return value.UInt64Value{}, fmt.Errorf("unable to read byte off byte buffer, reason: %s", err)
+		// the first byte has already been read, so it can be checked if the stop bit is not found
+		if i > 0 {
+			b, err = inputSource.ReadByte()
+			if err != nil {
+				return value.UInt64Value{}, fmt.Errorf("unable to read byte off byte buffer, reason: %s", err)
+			}
 		}
 
 		// 128 = 10000000, this will equal 128 if we have a stop bit present (most significant bit is 1)
@@ -134,13 +161,18 @@ func ReadUInt64(inputSource *bytes.Buffer) (value.UInt64Value, error) {
 		readValue = readValue<<7 | uint64(b)
 	}
 
-	return value.UInt64Value{}, fmt.Errorf("%s, uint64", errors.R6)
+	// 10 bytes hold 70 bits, so the most significant bits of the first byte have been shifted out of the uint64 unless they were 0
+	readValue, err = readOverlongUnsigned(inputSource, readValue, first&127 == 0, math.MaxUint64, "uint64")
+	if err != nil {
+		return value.UInt64Value{}, err
+	}
+	return value.UInt64Value{Value: readValue}, nil
 }
 
 // ReadOptionalUInt64 reads a uint64 off the buffer. If the value returned is 0, this is marked as nil, and nil is returned.
 // Due to needing to use 0 as a nil value for optionals, the value returned by this is: value - 1.
 // i.e. 10000000 would become nil, 10000001 would become 0
-func ReadOptionalUInt64(inputSource *bytes.Buffer) (value.Value, error) {
+func ReadOptionalUInt64(inputSource Reader) (value.Value, error) {
 	readValue, err := ReadBigUInt(inputSource)
 	if err != nil {
 		return value.UInt64Value{}, fmt.Errorf("unable to read value before assesing nullability, reason: %s", err)
@@ -162,29 +194,31 @@ func ReadOptionalUInt64(inputSource *bytes.Buffer) (value.Value, error) {
 
 // ReadInt64 reads the next FAST encoded value off the inputSource, treating it as an int64 value (2's compliment encoded). If the next value would overflow an int64 an err is returned.
 // i.e. 11111111 01001110 would become 11111111001110 -> 11001110 -> -50
-func ReadInt64(inputSource *bytes.Buffer) (value.Int64Value, error) {
+func ReadInt64(inputSource Reader) (value.Int64Value, error) {
+	if readValue, ok := fastSigned(inputSource, 10); ok {
+		return value.Int64Value{Value: readValue}, nil
+	}
+
 	var readValue int64 = 0
 
-	b, err := inputSource.ReadByte()
+	first, err := inputSource.ReadByte()
 	if err != nil {
 		return value.Int64Value{}, fmt.Errorf("unable to read byte off byte buffer, reason: %s", err)
 	}
+	b := first
 
 	// 64 = 01000000, indicating this is negative so we should start with all 1's int64 (-1)
-	if isNegative := b & 64; isNegative == 64 {
+	if isNegative := first & 64; isNegative == 64 {
 		readValue = -1
 	}
 
-	// reset byte buffer by the one byte we had to read to determine negative/positive number
-	err = inputSource.UnreadByte()
-	if err != nil {
-		return value.Int64Value{}, fmt.Errorf("unable to rewind byte buffer, reason: %s", err)
-	}
-
 	for i := 0; i < 10; i++ {
-		b, err := inputSource.ReadByte()
-		if err != nil {
-			return value.Int64Value{}, fmt.Errorf("unable to read byte off byte buffer, reason: %s", err)
+		// the first byte has already been read to determine negative/positive number
+		if i > 0 {
+			b, err = inputSource.ReadByte()
+			if err != nil {
+				return value.Int64Value{}, fmt.Errorf("unable to read byte off byte buffer, reason: %s", err)
+			}
 		}
 
 		// 128 = 10000000, this will equal 128 if we have a stop bit present (most significant bit is 1)
@@ -198,13 +232,18 @@ func ReadInt64(inputSource *bytes.Buffer) (value.Int64Value, error) {
 		readValue = readValue<<7 | int64(b)
 	}
 
-	return value.Int64Value{}, fmt.Errorf("%s, int64", errors.R6)
+	// 10 bytes hold 70 bits, so the most significant bits of the first byte have been shifted out of the int64 unless they were all sign bits
+	readValue, err = readOverlongSigned(inputSource, readValue, first&127 == 0 || first&127 == 127, math.MinInt64, math.MaxInt64, "int64")
+	if err != nil {
+		return value.Int64Value{}, err
+	}
+	return value.Int64Value{Value: readValue}, nil
 }
 
 // ReadOptionalInt64 reads an int64 off the buffer. If the value returned is 0, this is marked as nil, and nil is returned.
 // Due to needing to use 0 as a nil value for optionals, the value returned by this is: value - 1 for positive numbers only.
 // i.e. 10000000 would become nil, 10000001 would become 0
-func ReadOptionalInt64(inputSource *bytes.Buffer) (value.Value, error) {
+func ReadOptionalInt64(inputSource Reader) (value.Value, error) {
 	readValue, err := ReadBigInt(inputSource) // allow for overflow
 	if err != nil {
 		return value.Int64Value{}, fmt.Errorf("unable to read value before assesing nullability, reason: %s", err)
@@ -228,55 +267,92 @@ func ReadOptionalInt64(inputSource *bytes.Buffer) (value.Value, error) {
 
 // ReadBigUInt reads the next FAST encoded value off the inputSource, treating it as an uint64 value. However, this value may overflow an uint64 by 1 byte (for delta encoding)
 // and therefore we can return a value.BigInt if this happens. The least significant byte is in the overflow value. If the next value would till overflow this structure an err is returned.
-func ReadBigUInt(inputSource *bytes.Buffer) (value.BigInt, error) {
+func ReadBigUInt(inputSource Reader) (value.BigInt, error) {
+	readValue, bigValue, err := readUnsignedOrBig(inputSource)
+	if err != nil {
+		return value.BigInt{}, err
+	}
+
+	if bigValue == nil {
+		bigValue = big.NewInt(0).SetUint64(readValue)
+	}
+	return value.BigInt{Value: bigValue}, nil
+}
+
+// readUnsignedOrBig reads the same value as ReadBigUInt, only building a big.Int when the value is encoded in 10 or more bytes, so may overflow a uint64.
+// Otherwise the big.Int returned is nil.
+func readUnsignedOrBig(inputSource Reader) (uint64, *big.Int, error) {
+	if readValue, ok := fastUnsigned(inputSource, 9); ok {
+		return readValue, nil, nil
+	}
+
 	var readValue uint64 = 0
 
-	i := 1
-	for ; i < 10; i++ {
+	for i := 1; i < 10; i++ {
 		b, err := inputSource.ReadByte()
 		if err != nil {
-			return value.BigInt{}, fmt.Errorf("unable to read byte off byte buffer, reason: %s", err)
+			return 0, nil, fmt.Errorf("unable to read byte off byte buffer, reason: %s", err)
 		}
 
 		// 128 = 10000000, this will equal 128 if we have a stop bit present (most significant bit is 1)
 		if result := b & 128; result == 128 {
 			removedStopBit := uint64(b & 127)
 			readValue = readValue<<7 | removedStopBit
-			return value.BigInt{Value: big.NewInt(0).SetUint64(readValue)}, nil
+			return readValue, nil, nil
 		}
 
 		// no stop bit present so 0 in most significant bit, add this byte to the int we are reading
 		readValue = readValue<<7 | uint64(b)
 	}
 
-	// we try to read one more byte (for the overflow), if this does not read a stop bit we return an error
-	if i == 10 {
-		b, err := inputSource.ReadByte()
-		if err != nil {
-			return value.BigInt{}, fmt.Errorf("unable to read byte off byte buffer, reason: %s", err)
-		}
+	// we try to read one more byte (for the overflow), if this does not read a stop bit the value may be an overlong encoding
+	b, err := inputSource.ReadByte()
+	if err != nil {
+		return 0, nil, fmt.Errorf("unable to read byte off byte buffer, reason: %s", err)
+	}
 
-		// 128 = 10000000, this will equal 128 if we have a stop bit present (most significant bit is 1)
-		if result := b & 128; result == 128 {
-			removedStopBit := int64(b & 127)
-			resultBig := big.NewInt(0).SetUint64(readValue)
-			resultBig = resultBig.Lsh(resultBig, 7)                         // readValue << 7
-			resultBig = resultBig.Or(resultBig, big.NewInt(removedStopBit)) // result | removedStopBit
-			return value.BigInt{Value: resultBig}, nil
-		}
+	resultBig := big.NewInt(0).SetUint64(readValue)
+	resultBig = resultBig.Lsh(resultBig, 7)                       // readValue << 7
+	resultBig = resultBig.Or(resultBig, big.NewInt(int64(b&127))) // result | removedStopBit
+
+	// 128 = 10000000, this will equal 128 if we have a stop bit present (most significant bit is 1)
+	if result := b & 128; result == 128 {
+		return 0, resultBig, nil
 	}
 
-	return value.BigInt{}, fmt.Errorf("%s, uint64", errors.R6)
+	resultBig, err = readOverlongBig(inputSource, resultBig, false, "uint64")
+	if err != nil {
+		return 0, nil, err
+	}
+	return 0, resultBig, nil
 }
 
 // ReadBigInt reads the next FAST encoded value off the inputSource, treating it as an int64 value. However, this value may overflow an int64 by 1 byte (for delta encoding)
 // and therefore we can return a value.BigInt if this happens. The least significant byte is in the overflow value. If the next value would till overflow this structure an err is returned.
-func ReadBigInt(inputSource *bytes.Buffer) (value.BigInt, error) {
+func ReadBigInt(inputSource Reader) (value.BigInt, error) {
+	readValue, bigValue, err := ReadInt64OrBigInt(inputSource)
+	if err != nil {
+		return value.BigInt{}, err
+	}
+
+	if bigValue == nil {
+		bigValue = big.NewInt(readValue)
+	}
+	return value.BigInt{Value: bigValue}, nil
+}
+
+// ReadInt64OrBigInt reads the same value as ReadBigInt, only building a big.Int when the value is encoded in 10 or more bytes, so may overflow an int64.
+// Otherwise the value is returned as an int64, and the big.Int returned is nil.
+func ReadInt64OrBigInt(inputSource Reader) (int64, *big.Int, error) {
+	if readValue, ok := fastSigned(inputSource, 9); ok {
+		return readValue, nil, nil
+	}
+
 	var readValue int64 = 0
 
 	b, err := inputSource.ReadByte()
 	if err != nil {
-		return value.BigInt{}, fmt.Errorf("unable to read byte off byte buffer, reason: %s", err)
+		return 0, nil, fmt.Errorf("unable to read byte off byte buffer, reason: %s", err)
 	}
 
 	// 64 = 01000000, indicating this is negative so we should start with all 1's int64 (-1)
@@ -284,53 +360,51 @@ func ReadBigInt(inputSource *bytes.Buffer) (value.BigInt, error) {
 		readValue = -1
 	}
 
-	// reset byte buffer by the one byte we had to read to determine negative/positive number
-	err = inputSource.UnreadByte()
-	if err != nil {
-		return value.BigInt{}, fmt.Errorf("unable to rewind byte buffer, reason: %s", err)
-	}
-
-	i := 1
-	for ; i < 10; i++ {
-		b, err := inputSource.ReadByte()
-		if err != nil {
-			return value.BigInt{}, fmt.Errorf("unable to read byte off byte buffer, reason: %s", err)
+	for i := 1; i < 10; i++ {
+		// the first byte has already been read to determine negative/positive number
+		if i > 1 {
+			b, err = inputSource.ReadByte()
+			if err != nil {
+				return 0, nil, fmt.Errorf("unable to read byte off byte buffer, reason: %s", err)
+			}
 		}
 
 		// 128 = 10000000, this will equal 128 if we have a stop bit present (most significant bit is 1)
 		if result := b & 128; result == 128 {
 			removedStopBit := int64(b & 127)
 			readValue = readValue<<7 | removedStopBit
-			return value.BigInt{Value: big.NewInt(readValue)}, nil
+			return readValue, nil, nil
 		}
 
 		// no stop bit present so 0 in most significant bit, add this byte to the int we are reading
 		readValue = readValue<<7 | int64(b)
 	}
 
-	// we try to read one more byte (for the overflow), if this does not read a stop bit we return an error
-	if i == 10 {
-		b, err := inputSource.ReadByte()
-		if err != nil {
-			return value.BigInt{}, fmt.Errorf("unable to read byte off byte buffer, reason: %s", err)
-		}
+	// we try to read one more byte (for the overflow), if this does not read a stop bit the value may be an overlong encoding
+	b, err = inputSource.ReadByte()
+	if err != nil {
+		return 0, nil, fmt.Errorf("unable to read byte off byte buffer, reason: %s", err)
+	}
 
-		// 128 = 10000000, this will equal 128 if we have a stop bit present (most significant bit is 1)
-		if result := b & 128; result == 128 {
-			removedStopBit := int64(b & 127)
-			resultBig := big.NewInt(readValue)
-			resultBig = resultBig.Lsh(resultBig, 7)                         // readValue << 7
-			resultBig = resultBig.Or(resultBig, big.NewInt(removedStopBit)) // result | removedStopBit
-			return value.BigInt{Value: resultBig}, nil
-		}
+	resultBig := big.NewInt(readValue)
+	resultBig = resultBig.Lsh(resultBig, 7)                       // readValue << 7
+	resultBig = resultBig.Or(resultBig, big.NewInt(int64(b&127))) // result | removedStopBit
+
+	// 128 = 10000000, this will equal 128 if we have a stop bit present (most significant bit is 1)
+	if result := b & 128; result == 128 {
+		return 0, resultBig, nil
 	}
 
-	return value.BigInt{}, fmt.Errorf("%s, int64", errors.R6)
+	resultBig, err = readOverlongBig(inputSource, resultBig, true, "int64")
+	if err != nil {
+		return 0, nil, err
+	}
+	return 0, resultBig, nil
 }
 
 // ReadOptionalBigInt reads a value.BigInt off the input buffer. If the value returned is 0, this is marked as nil, and nil is returned.
 // Due to needing to use 0 as a nil value for optionals, the value returned by this is: value - 1 for positive numbers only.
-func ReadOptionalBigInt(inputSource *bytes.Buffer) (value.Value, error) {
+func ReadOptionalBigInt(inputSource Reader) (value.Value, error) {
 	readValue, err := ReadBigInt(inputSource)
 	if err != nil {
 		return value.BigInt{}, fmt.Errorf("unable to read value before assesing nullability, reason: %s", err)
@@ -349,14 +423,14 @@ func ReadOptionalBigInt(inputSource *bytes.Buffer) (value.Value, error) {
 }
 
 // ReadString reads an ASCII encoded string off the buffer. This can be done as ASCII is a subset of UTF-8 which is what GO uses to represent strings.
-func ReadString(inputSource *bytes.Buffer) (value.StringValue, error) {
+func ReadString(inputSource Reader) (value.StringValue, error) {
 	stringBuilder := strings.Builder{}
 	return readString(inputSource, &stringBuilder)
 }
 
 // ReadOptionalString reads an ASCII encoded string off the buffer. If the first value is 10000000, this is seen as null. If the first values are
 // 00000000 10000000 this is seen as an empty string.
-func ReadOptionalString(inputSource *bytes.Buffer) (value.Value, error) {
+func ReadOptionalString(inputSource Reader) (value.Value, error) {
 	possibleNullIndiciator, err := inputSource.ReadByte()
 	if err != nil {
 		return value.StringValue{}, fmt.Errorf("unable to read byte off byte buffer, reason: %s", err)
@@ -402,7 +476,7 @@ func ReadOptionalString(inputSource *bytes.Buffer) (value.Value, error) {
 	return readString(inputSource, &stringBuilder)
 }
 
-func readString(inputSource *bytes.Buffer, stringBuilder *strings.Builder) (value.StringValue, error) {
+func readString(inputSource Reader, stringBuilder *strings.Builder) (value.StringValue, error) {
 	for {
 		b, err := inputSource.ReadByte()
 		if err != nil {
@@ -430,19 +504,25 @@ func appendNotNullChar(char byte, stringBuilder *strings.Builder) {
 
 // ReadByteVector reads a uint32 length off the buffer which represents the length of the vector to then read. The vector read is not stop bit encoded.
 // i.e. 10000010 00000001 00000010 would become (length 2) -> [1, 2]
-func ReadByteVector(inputSource *bytes.Buffer) (value.ByteVector, error) {
+// With the ZeroCopy setting the vector is a view of the bytes read, see ViewByteVector.
+func ReadByteVector(inputSource Reader) (value.ByteVector, error) {
+	if SettingsOf(inputSource).ZeroCopy {
+		view, err := ViewByteVector(inputSource)
+		if err != nil {
+			return value.ByteVector{}, err
+		}
+		return value.ByteVector{Value: view}, nil
+	}
+
 	length, err := ReadUInt32(inputSource)
 	if err != nil {
 		return value.ByteVector{}, fmt.Errorf("unable to read byte off byte buffer, reason: %s", err)
 	}
 
 	byteVector := make([]byte, length.Value)
-	number, err := inputSource.Read(byteVector)
+	number, err := io.ReadFull(inputSource, byteVector)
 	if err != nil {
-		return value.ByteVector{}, fmt.Errorf("unable to read multiple bytes [%d] off byte buffer, reason: %s", length.Value, err)
-	}
-	if number != int(length.Value) {
-		return value.ByteVector{}, fmt.Errorf("did not read full length of byte vector, expected to read: %d, but actually read %d", length.Value, number)
+		return value.ByteVector{}, fmt.Errorf("did not read full length of byte vector, expected to read: %d, but actually read %d, reason: %s", length.Value, number, err)
 	}
 
 	return value.ByteVector{Value: byteVector}, nil
@@ -452,7 +532,19 @@ func ReadByteVector(inputSource *bytes.Buffer) (value.ByteVector, error) {
 // length is not null.
 // i.e. 10000010 00000001 would become (length 1) -> [1]
 // i.e. 10000000 would become 0, and be marked as null
-func ReadOptionalByteVector(inputSource *bytes.Buffer) (value.Value, error) {
+// With the ZeroCopy setting the vector is a view of the bytes read, see ViewByteVector.
+func ReadOptionalByteVector(inputSource Reader) (value.Value, error) {
+	if SettingsOf(inputSource).ZeroCopy {
+		view, isNull, err := ViewNullableByteVector(inputSource)
+		if err != nil {
+			return nil, err
+		}
+		if isNull {
+			return value.NullValue{}, nil
+		}
+		return value.ByteVector{Value: view}, nil
+	}
+
 	length, err := ReadOptionalUInt32(inputSource)
 	if err != nil {
 		return nil, fmt.Errorf("unable to read value before assesing nullability, reason: %s", err)
@@ -463,12 +555,9 @@ func ReadOptionalByteVector(inputSource *bytes.Buffer) (value.Value, error) {
 		return t, nil
 	case value.UInt32Value:
 		byteVector := make([]byte, t.Value)
-		number, err := inputSource.Read(byteVector)
+		number, err := io.ReadFull(inputSource, byteVector)
 		if err != nil {
-			return value.ByteVector{}, fmt.Errorf("unable to read multiple bytes [%d] off byte buffer, reason: %s", t.Value, err)
-		}
-		if number != int(t.Value) {
-			return value.ByteVector{}, fmt.Errorf("did not read full length of byte vector, expected to read: %d, but actually read %d", t.Value, number)
+			return value.ByteVector{}, fmt.Errorf("did not read full length of byte vector, expected to read: %d, but actually read %d, reason: %s", t.Value, number, err)
 		}
 		return value.ByteVector{Value: byteVector}, nil
 	default:
@@ -477,9 +566,12 @@ func ReadOptionalByteVector(inputSource *bytes.Buffer) (value.Value, error) {
 }
 
 // ReadValue reads the values off the byte buffer until a stop but is detected. Stop bits are not removed from the bytes returned.
-func ReadValue(inputSource *bytes.Buffer) ([]byte, error) {
-	readValue := make([]byte, 0)
+func ReadValue(inputSource Reader) ([]byte, error) {
+	return AppendValue(make([]byte, 0), inputSource)
+}
 
+// AppendValue reads the values off the byte buffer in the same way as ReadValue, appending them to buffer rather than allocating a new slice
+func AppendValue(buffer []byte, inputSource Reader) ([]byte, error) {
 	for {
 		b, err := inputSource.ReadByte()
 		if err != nil {
@@ -488,10 +580,10 @@ func ReadValue(inputSource *bytes.Buffer) ([]byte, error) {
 
 		// 128 = 10000000, this will equal 128 if we have a stop bit present (most significant bit is 1)
 		if result := b & 128; result == 128 {
-			readValue = append(readValue, b)
-			return readValue, nil
+			buffer = append(buffer, b)
+			return buffer, nil
 		}
 
-		readValue = append(readValue, b)
+		buffer = append(buffer, b)
 	}
 }
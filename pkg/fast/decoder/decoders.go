@@ -1,14 +1,13 @@
 package decoder
 
 import (
-	"bytes"
 	"github.com/Guardian-Development/fastengine/pkg/fast/value"
 )
 
 // Decoder is used to couple the reading of required and optional values of the same type
 type Decoder interface {
-	ReadValue(inputSource *bytes.Buffer) (value.Value, error)
-	ReadOptionalValue(inputSource *bytes.Buffer) (value.Value, error)
+	ReadValue(inputSource Reader) (value.Value, error)
+	ReadOptionalValue(inputSource Reader) (value.Value, error)
 }
 
 // Int32Decoder performs a read/optional read of a FAST encoded int32
@@ -16,12 +15,12 @@ type Int32Decoder struct {
 }
 
 // ReadValue fast encoded int32
-func (Int32Decoder) ReadValue(inputSource *bytes.Buffer) (value.Value, error) {
+func (Int32Decoder) ReadValue(inputSource Reader) (value.Value, error) {
 	return ReadInt32(inputSource)
 }
 
 // ReadOptionalValue fast encoded optional int32
-func (Int32Decoder) ReadOptionalValue(inputSource *bytes.Buffer) (value.Value, error) {
+func (Int32Decoder) ReadOptionalValue(inputSource Reader) (value.Value, error) {
 	return ReadOptionalInt32(inputSource)
 }
 
@@ -30,12 +29,12 @@ type UInt32Decoder struct {
 }
 
 // ReadValue fast encoded uint32
-func (UInt32Decoder) ReadValue(inputSource *bytes.Buffer) (value.Value, error) {
+func (UInt32Decoder) ReadValue(inputSource Reader) (value.Value, error) {
 	return ReadUInt32(inputSource)
 }
 
 // ReadOptionalValue fast encoded optional uint32
-func (UInt32Decoder) ReadOptionalValue(inputSource *bytes.Buffer) (value.Value, error) {
+func (UInt32Decoder) ReadOptionalValue(inputSource Reader) (value.Value, error) {
 	return ReadOptionalUInt32(inputSource)
 }
 
@@ -44,12 +43,12 @@ type Int64Decoder struct {
 }
 
 // ReadValue fast encoded int64
-func (Int64Decoder) ReadValue(inputSource *bytes.Buffer) (value.Value, error) {
+func (Int64Decoder) ReadValue(inputSource Reader) (value.Value, error) {
 	return ReadInt64(inputSource)
 }
 
 // ReadOptionalValue fast encoded optional int64
-func (Int64Decoder) ReadOptionalValue(inputSource *bytes.Buffer) (value.Value, error) {
+func (Int64Decoder) ReadOptionalValue(inputSource Reader) (value.Value, error) {
 	return ReadOptionalInt64(inputSource)
 }
 
@@ -58,12 +57,12 @@ type UInt64Decoder struct {
 }
 
 // ReadValue fast encoded uint64
-func (UInt64Decoder) ReadValue(inputSource *bytes.Buffer) (value.Value, error) {
+func (UInt64Decoder) ReadValue(inputSource Reader) (value.Value, error) {
 	return ReadUInt64(inputSource)
 }
 
 // ReadOptionalValue fast encoded optional uint64
-func (UInt64Decoder) ReadOptionalValue(inputSource *bytes.Buffer) (value.Value, error) {
+func (UInt64Decoder) ReadOptionalValue(inputSource Reader) (value.Value, error) {
 	return ReadOptionalUInt64(inputSource)
 }
 
@@ -72,12 +71,12 @@ type BitIntDecoder struct {
 }
 
 // ReadValue fast encoded int64 with allowed overflow
-func (BitIntDecoder) ReadValue(inputSource *bytes.Buffer) (value.Value, error) {
+func (BitIntDecoder) ReadValue(inputSource Reader) (value.Value, error) {
 	return ReadBigInt(inputSource)
 }
 
 // ReadOptionalValue fast encoded optional int64 with allowed overflow
-func (BitIntDecoder) ReadOptionalValue(inputSource *bytes.Buffer) (value.Value, error) {
+func (BitIntDecoder) ReadOptionalValue(inputSource Reader) (value.Value, error) {
 	return ReadOptionalBigInt(inputSource)
 }
 
@@ -86,12 +85,12 @@ type AsciiStringDecoder struct {
 }
 
 // ReadValue fast encoded string
-func (AsciiStringDecoder) ReadValue(inputSource *bytes.Buffer) (value.Value, error) {
+func (AsciiStringDecoder) ReadValue(inputSource Reader) (value.Value, error) {
 	return ReadString(inputSource)
 }
 
 // ReadOptionalValue fast encoded optional string
-func (AsciiStringDecoder) ReadOptionalValue(inputSource *bytes.Buffer) (value.Value, error) {
+func (AsciiStringDecoder) ReadOptionalValue(inputSource Reader) (value.Value, error) {
 	return ReadOptionalString(inputSource)
 }
 
@@ -100,7 +99,7 @@ type AsciiStringDeltaDecoder struct {
 }
 
 // ReadValue fast encoded string delta
-func (AsciiStringDeltaDecoder) ReadValue(inputSource *bytes.Buffer) (value.Value, error) {
+func (AsciiStringDeltaDecoder) ReadValue(inputSource Reader) (value.Value, error) {
 	subtractionLength, err := ReadInt32(inputSource)
 	if err != nil {
 		return nil, err
@@ -115,7 +114,7 @@ func (AsciiStringDeltaDecoder) ReadValue(inputSource *bytes.Buffer) (value.Value
 }
 
 // ReadOptionalValue fast encoded string delta
-func (AsciiStringDeltaDecoder) ReadOptionalValue(inputSource *bytes.Buffer) (value.Value, error) {
+func (AsciiStringDeltaDecoder) ReadOptionalValue(inputSource Reader) (value.Value, error) {
 	subtractionLength, err := ReadOptionalInt32(inputSource)
 	if err != nil {
 		return nil, err
@@ -140,12 +139,12 @@ type ByteVectorDecoder struct {
 }
 
 // ReadValue fast encoded byte vector
-func (ByteVectorDecoder) ReadValue(inputSource *bytes.Buffer) (value.Value, error) {
+func (ByteVectorDecoder) ReadValue(inputSource Reader) (value.Value, error) {
 	return ReadByteVector(inputSource)
 }
 
 // ReadOptionalValue fast encoded byte vector
-func (ByteVectorDecoder) ReadOptionalValue(inputSource *bytes.Buffer) (value.Value, error) {
+func (ByteVectorDecoder) ReadOptionalValue(inputSource Reader) (value.Value, error) {
 	return ReadOptionalByteVector(inputSource)
 }
 
@@ -154,7 +153,7 @@ type ByteVectorDeltaDecoder struct {
 }
 
 // ReadValue fast encoded byte vector delta
-func (ByteVectorDeltaDecoder) ReadValue(inputSource *bytes.Buffer) (value.Value, error) {
+func (ByteVectorDeltaDecoder) ReadValue(inputSource Reader) (value.Value, error) {
 	subtractionLength, err := ReadInt32(inputSource)
 	if err != nil {
 		return nil, err
@@ -169,7 +168,7 @@ func (ByteVectorDeltaDecoder) ReadValue(inputSource *bytes.Buffer) (value.Value,
 }
 
 // ReadOptionalValue fast encoded byte vector delta
-func (ByteVectorDeltaDecoder) ReadOptionalValue(inputSource *bytes.Buffer) (value.Value, error) {
+func (ByteVectorDeltaDecoder) ReadOptionalValue(inputSource Reader) (value.Value, error) {
 	subtractionLength, err := ReadOptionalInt32(inputSource)
 	if err != nil {
 		return nil, err
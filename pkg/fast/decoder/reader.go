@@ -0,0 +1,107 @@
+package decoder
+
+import (
+	"bufio"
+	"io"
+)
+
+// Reader is the source all FAST encoded values are read from. Values are read a byte at a time, apart from byte vectors which are read in full once their length is known.
+// *bytes.Buffer, *bufio.Reader, *Cursor and *CountingReader all satisfy this interface.
+type Reader interface {
+	io.ByteReader
+	io.Reader
+}
+
+// Cursor reads FAST encoded values directly from a byte slice, without copying it, tracking the offset of the next byte to be read
+type Cursor struct {
+	data   []byte
+	offset int
+}
+
+// ReadByte reads the byte at the current offset, moving the offset on by one. If there are no bytes left io.EOF is returned.
+func (cursor *Cursor) ReadByte() (byte, error) {
+	if cursor.offset >= len(cursor.data) {
+		return 0, io.EOF
+	}
+
+	b := cursor.data[cursor.offset]
+	cursor.offset++
+	return b, nil
+}
+
+// Read copies up to len(p) bytes from the current offset into p, moving the offset on by the number of bytes read. If there are no bytes left io.EOF is returned.
+func (cursor *Cursor) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if cursor.offset >= len(cursor.data) {
+		return 0, io.EOF
+	}
+
+	number := copy(p, cursor.data[cursor.offset:])
+	cursor.offset += number
+	return number, nil
+}
+
+// Offset of the next byte to be read, this is equal to the number of bytes read so far
+func (cursor *Cursor) Offset() int {
+	return cursor.offset
+}
+
+// Len is the number of bytes left to read
+func (cursor *Cursor) Len() int {
+	return len(cursor.data) - cursor.offset
+}
+
+// Reset the cursor to read from the start of data. The cursor never writes to the data it reads, so views read from the previous data stay valid for as
+// long as that data is not reused.
+func (cursor *Cursor) Reset(data []byte) {
+	cursor.data = data
+	cursor.offset = 0
+}
+
+// NewCursor that reads from the start of data
+func NewCursor(data []byte) *Cursor {
+	return &Cursor{data: data}
+}
+
+// CountingReader reads FAST encoded values from a buffered io.Reader, such as a network connection, tracking the number of bytes read
+type CountingReader struct {
+	reader *bufio.Reader
+	offset int64
+}
+
+// ReadByte reads the next byte from the underlying reader
+func (countingReader *CountingReader) ReadByte() (byte, error) {
+	b, err := countingReader.reader.ReadByte()
+	if err == nil {
+		countingReader.offset++
+	}
+	return b, err
+}
+
+// Read copies up to len(p) bytes from the underlying reader into p
+func (countingReader *CountingReader) Read(p []byte) (int, error) {
+	number, err := countingReader.reader.Read(p)
+	countingReader.offset += int64(number)
+	return number, err
+}
+
+// Peek returns the next n bytes without reading them, this does not move the offset on
+func (countingReader *CountingReader) Peek(n int) ([]byte, error) {
+	return countingReader.reader.Peek(n)
+}
+
+// Offset is the number of bytes read so far
+func (countingReader *CountingReader) Offset() int64 {
+	return countingReader.offset
+}
+
+// NewCountingReader that reads from reader. If reader is already a *bufio.Reader it is used directly, otherwise it is wrapped in one.
+func NewCountingReader(reader io.Reader) *CountingReader {
+	if bufferedReader, ok := reader.(*bufio.Reader); ok {
+		return &CountingReader{reader: bufferedReader}
+	}
+
+	return &CountingReader{reader: bufio.NewReader(reader)}
+}
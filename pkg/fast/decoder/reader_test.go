@@ -0,0 +1,135 @@
+package decoder
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+	"testing/iotest"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/value"
+)
+
+func TestCursorTracksOffsetOfBytesRead(t *testing.T) {
+	// Arrange 138 = (10001010) 10 = (00001010) 139 = (10001011)
+	cursor := NewCursor([]byte{138, 10, 139})
+
+	// Act
+	first, err := ReadUInt32(cursor)
+	if err != nil {
+		t.Errorf("Got an error reading uint32 when none was expected: %s", err)
+	}
+	offsetAfterFirst := cursor.Offset()
+	second, err := ReadUInt32(cursor)
+	if err != nil {
+		t.Errorf("Got an error reading uint32 when none was expected: %s", err)
+	}
+
+	// Assert
+	if first.Value != 10 || second.Value != 1291 {
+		t.Errorf("Did not read the expected uint32 values, result: %#v, %#v", first, second)
+	}
+	if offsetAfterFirst != 1 || cursor.Offset() != 3 {
+		t.Errorf("Did not track the expected offsets, expected: 1 then 3, result: %d then %d", offsetAfterFirst, cursor.Offset())
+	}
+	if cursor.Len() != 0 {
+		t.Errorf("Expected no bytes left to read, but got: %d", cursor.Len())
+	}
+}
+
+func TestCursorReturnsEOFOnceAllBytesRead(t *testing.T) {
+	// Arrange
+	cursor := NewCursor([]byte{1})
+	_, _ = cursor.ReadByte()
+
+	// Act
+	_, byteErr := cursor.ReadByte()
+	_, readErr := cursor.Read(make([]byte, 1))
+
+	// Assert
+	if byteErr != io.EOF || readErr != io.EOF {
+		t.Errorf("Expected io.EOF once all bytes read, but got: %v, %v", byteErr, readErr)
+	}
+}
+
+func TestCursorCanBeResetToReadNewData(t *testing.T) {
+	// Arrange
+	cursor := NewCursor([]byte{129})
+	_, _ = ReadUInt32(cursor)
+
+	// Act
+	cursor.Reset([]byte{130})
+	result, err := ReadUInt32(cursor)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error reading uint32 when none was expected: %s", err)
+	}
+	if result.Value != 2 || cursor.Offset() != 1 {
+		t.Errorf("Did not read from the start of the new data, result: %#v, offset: %d", result, cursor.Offset())
+	}
+}
+
+func TestCountingReaderTracksOffsetOfBytesRead(t *testing.T) {
+	// Arrange 130 = (10000010) [1, 2] 206 = (11001110)
+	reader := NewCountingReader(iotest.OneByteReader(bytes.NewReader([]byte{130, 1, 2, 206})))
+
+	// Act
+	byteVector, err := ReadByteVector(reader)
+	if err != nil {
+		t.Errorf("Got an error reading byte vector when none was expected: %s", err)
+	}
+	offsetAfterByteVector := reader.Offset()
+	signed, err := ReadInt32(reader)
+	if err != nil {
+		t.Errorf("Got an error reading int32 when none was expected: %s", err)
+	}
+
+	// Assert
+	if !reflect.DeepEqual([]byte{1, 2}, byteVector.Value) || signed.Value != -50 {
+		t.Errorf("Did not read the expected values, result: %#v, %#v", byteVector, signed)
+	}
+	if offsetAfterByteVector != 3 || reader.Offset() != 4 {
+		t.Errorf("Did not track the expected offsets, expected: 3 then 4, result: %d then %d", offsetAfterByteVector, reader.Offset())
+	}
+}
+
+func TestReadByteVectorReturnsErrorIfReaderEndsBeforeFullLength(t *testing.T) {
+	// Arrange 131 = (10000011) [1, 2]
+	cursor := NewCursor([]byte{131, 1, 2})
+
+	// Act
+	_, err := ReadByteVector(cursor)
+
+	// Assert
+	if err == nil {
+		t.Errorf("Expected error reading byte vector longer than the bytes available, but got none")
+	}
+}
+
+func TestAllReadersDecodeTheSameValues(t *testing.T) {
+	// Arrange -50 = (11001110) 64 = (00000000 11000000) 9223372036854775807 + 1 = (00000001 00000000 00000000 00000000 00000000 00000000 00000000 00000000 00000000 10000000) TEST1 = (01010100 01000101 01010011 01010100 10110001)
+	message := []byte{206, 0, 192, 1, 0, 0, 0, 0, 0, 0, 0, 0, 128, 84, 69, 83, 84, 177}
+	expectedValues := []value.Value{value.Int32Value{Value: -50}, value.Int64Value{Value: 64}, value.Int64Value{Value: 9223372036854775807}, value.StringValue{Value: "TEST1"}}
+	readers := []Reader{
+		bytes.NewBuffer(message),
+		NewCursor(message),
+		bufio.NewReader(bytes.NewReader(message)),
+		NewCountingReader(iotest.OneByteReader(bytes.NewReader(message))),
+	}
+
+	for _, reader := range readers {
+		// Act
+		int32Value, _ := Int32Decoder{}.ReadValue(reader)
+		int64Value, _ := Int64Decoder{}.ReadValue(reader)
+		optionalInt64Value, _ := Int64Decoder{}.ReadOptionalValue(reader)
+		stringValue, _ := AsciiStringDecoder{}.ReadValue(reader)
+
+		// Assert
+		result := []value.Value{int32Value, int64Value, optionalInt64Value, stringValue}
+		if !reflect.DeepEqual(expectedValues, result) {
+			t.Errorf("Did not read the expected values from %T, expected: %#v, result: %#v", reader, expectedValues, result)
+		}
+	}
+}
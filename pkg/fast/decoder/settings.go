@@ -0,0 +1,176 @@
+package decoder
+
+import (
+	"fmt"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/errors"
+)
+
+// Settings control how values are read off a Reader. The zero value is strict, failing on any spec violation, with no limits applied.
+type Settings struct {
+	// Lenient reads values with recoverable spec violations, such as overlong encodings (R6), reporting them to Warn rather than failing
+	Lenient bool
+	// MaxSequenceLength is the largest number of repeating groups a sequence can have, 0 means there is no limit
+	MaxSequenceLength uint32
+	// Warn is called with each recoverable spec violation read in lenient mode
+	Warn func(warning error)
+	// ZeroCopy reads byte vectors from a Cursor as views into its byte slice rather than copies, see ViewByteVector. Values read this way are only valid
+	// until the byte slice of the Cursor is reused. ASCII strings are always copied.
+	ZeroCopy bool
+	// Counters of the recoverable spec violations that are counted in lenient mode rather than reported to Warn, nil means they are not counted
+	Counters *Counters
+}
+
+// Counters of recoverable spec violations read in lenient mode. Counts are added atomically, so the same Counters can be shared by readers on many
+// goroutines, and should be read with Load.
+type Counters struct {
+	// OverlongPresenceMaps is the number of presence maps read whose last byte had no bits set (R7)
+	OverlongPresenceMaps uint64
+	// TooLongPresenceMaps is the number of presence maps read with bits set beyond the bits read by their template or repeating group (R8)
+	TooLongPresenceMaps uint64
+}
+
+// Load a copy of the counters, reading each count atomically
+func (counters *Counters) Load() Counters {
+	return Counters{
+		OverlongPresenceMaps: atomic.LoadUint64(&counters.OverlongPresenceMaps),
+		TooLongPresenceMaps:  atomic.LoadUint64(&counters.TooLongPresenceMaps),
+	}
+}
+
+// SettingsReader is a Reader that carries the Settings values should be read with. Readers that are not a SettingsReader are read with the zero value Settings.
+type SettingsReader struct {
+	Reader
+	Settings Settings
+}
+
+// SettingsOf the reader, if it is not a SettingsReader the zero value (strict) Settings are returned
+func SettingsOf(reader Reader) Settings {
+	if settingsReader, ok := reader.(*SettingsReader); ok {
+		return settingsReader.Settings
+	}
+
+	return Settings{}
+}
+
+func (settings Settings) warn(warning error) {
+	if settings.Warn != nil {
+		settings.Warn(warning)
+	}
+}
+
+// readOverlongUnsigned is called when the stop bit of an unsigned integer has not been found within the number of bytes its type can be encoded in.
+// In strict mode this is an R6 error. In lenient mode the rest of the integer is read, and if the extra bytes were redundant (leading 0's) the value is returned.
+// isExact should be false if bits read so far have been lost from readValue.
+func readOverlongUnsigned(inputSource Reader, readValue uint64, isExact bool, maxValue uint64, typeName string) (uint64, error) {
+	settings := SettingsOf(inputSource)
+	if !settings.Lenient || !isExact {
+		return 0, fmt.Errorf("%s, %s", errors.R6, typeName)
+	}
+
+	for {
+		b, err := inputSource.ReadByte()
+		if err != nil {
+			return 0, fmt.Errorf("unable to read byte off byte buffer, reason: %s", err)
+		}
+
+		// the 7 most significant bits are about to be shifted out, so they must be 0 for the value to fit
+		if readValue>>57 != 0 {
+			return 0, fmt.Errorf("%s, %s", errors.R6, typeName)
+		}
+		readValue = readValue<<7 | uint64(b&127)
+
+		// 128 = 10000000, this will equal 128 if we have a stop bit present (most significant bit is 1)
+		if b&128 == 128 {
+			break
+		}
+	}
+
+	if readValue > maxValue {
+		return 0, fmt.Errorf("%s, %s", errors.R6, typeName)
+	}
+
+	settings.warn(fmt.Errorf("%s, %s read from an overlong encoding", errors.R6, typeName))
+	return readValue, nil
+}
+
+// readOverlongSigned is called when the stop bit of a signed integer has not been found within the number of bytes its type can be encoded in.
+// In strict mode this is an R6 error. In lenient mode the rest of the integer is read, and if the extra bytes were redundant (leading sign bits) the value is returned.
+// isExact should be false if bits read so far have been lost from readValue.
+func readOverlongSigned(inputSource Reader, readValue int64, isExact bool, minValue int64, maxValue int64, typeName string) (int64, error) {
+	settings := SettingsOf(inputSource)
+	if !settings.Lenient || !isExact {
+		return 0, fmt.Errorf("%s, %s", errors.R6, typeName)
+	}
+
+	for {
+		b, err := inputSource.ReadByte()
+		if err != nil {
+			return 0, fmt.Errorf("unable to read byte off byte buffer, reason: %s", err)
+		}
+
+		// the 7 most significant bits are about to be shifted out, they and the new sign bit must all be sign bits for the value to fit
+		if top := readValue >> 56; top != 0 && top != -1 {
+			return 0, fmt.Errorf("%s, %s", errors.R6, typeName)
+		}
+		readValue = readValue<<7 | int64(b&127)
+
+		// 128 = 10000000, this will equal 128 if we have a stop bit present (most significant bit is 1)
+		if b&128 == 128 {
+			break
+		}
+	}
+
+	if readValue < minValue || readValue > maxValue {
+		return 0, fmt.Errorf("%s, %s", errors.R6, typeName)
+	}
+
+	settings.warn(fmt.Errorf("%s, %s read from an overlong encoding", errors.R6, typeName))
+	return readValue, nil
+}
+
+// readOverlongBig is called when the stop bit of an integer with allowed overflow has not been found within 10 bytes. In strict mode this is an R6 error.
+// In lenient mode the rest of the integer is read, and if the extra bytes were redundant the value is returned.
+func readOverlongBig(inputSource Reader, readValue *big.Int, isSigned bool, typeName string) (*big.Int, error) {
+	settings := SettingsOf(inputSource)
+	if !settings.Lenient {
+		return nil, fmt.Errorf("%s, %s", errors.R6, typeName)
+	}
+
+	for {
+		b, err := inputSource.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read byte off byte buffer, reason: %s", err)
+		}
+
+		readValue = readValue.Lsh(readValue, 7)
+		readValue = readValue.Or(readValue, big.NewInt(int64(b&127)))
+		if !fitsInAllowedOverflow(readValue, isSigned) {
+			return nil, fmt.Errorf("%s, %s", errors.R6, typeName)
+		}
+
+		// 128 = 10000000, this will equal 128 if we have a stop bit present (most significant bit is 1)
+		if b&128 == 128 {
+			break
+		}
+	}
+
+	settings.warn(fmt.Errorf("%s, %s read from an overlong encoding", errors.R6, typeName))
+	return readValue, nil
+}
+
+// fitsInAllowedOverflow returns whether the value could have been encoded in 10 bytes (70 bits)
+func fitsInAllowedOverflow(readValue *big.Int, isSigned bool) bool {
+	if !isSigned {
+		return readValue.BitLen() <= 70
+	}
+	if readValue.Sign() >= 0 {
+		return readValue.BitLen() <= 69
+	}
+
+	// for negative values the magnitude of (-value - 1) is the number of bits needed, excluding the sign bit
+	magnitude := big.NewInt(0).Neg(readValue)
+	return magnitude.Sub(magnitude, big.NewInt(1)).BitLen() <= 69
+}
@@ -0,0 +1,192 @@
+package decoder
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/errors"
+)
+
+func lenientReader(message []byte, warnings *[]error) *SettingsReader {
+	return &SettingsReader{
+		Reader: bytes.NewBuffer(message),
+		Settings: Settings{
+			Lenient: true,
+			Warn: func(warning error) {
+				*warnings = append(*warnings, warning)
+			},
+		},
+	}
+}
+
+func TestSettingsOfReaderWithoutSettingsIsStrict(t *testing.T) {
+	// Arrange
+	reader := bytes.NewBuffer([]byte{128})
+
+	// Act
+	settings := SettingsOf(reader)
+
+	// Assert
+	if settings.Lenient || settings.MaxSequenceLength != 0 {
+		t.Errorf("Expected the zero value settings for a reader without settings, result: %#v", settings)
+	}
+}
+
+func TestStrictOverlongUInt32ReturnsError(t *testing.T) {
+	// Arrange 10 encoded over 6 bytes = 00000000 00000000 00000000 00000000 00000000 10001010
+	message := &SettingsReader{Reader: bytes.NewBuffer([]byte{0, 0, 0, 0, 0, 138})}
+
+	// Act
+	_, err := ReadUInt32(message)
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), errors.R6) {
+		t.Errorf("Expected error about uint32 overflow but got: %#v", err)
+	}
+}
+
+func TestLenientOverlongUInt32IsReadWithWarning(t *testing.T) {
+	// Arrange 10 encoded over 6 bytes = 00000000 00000000 00000000 00000000 00000000 10001010
+	var warnings []error
+	message := lenientReader([]byte{0, 0, 0, 0, 0, 138}, &warnings)
+
+	// Act
+	result, err := ReadUInt32(message)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error reading uint32 when none was expected: %s", err)
+	}
+	if result.Value != 10 {
+		t.Errorf("Did not read the expected uint32, expected: 10, result: %#v", result)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Error(), errors.R6) {
+		t.Errorf("Expected a single R6 warning, result: %v", warnings)
+	}
+}
+
+func TestLenientOverlongUInt32ThatOverflowsReturnsError(t *testing.T) {
+	// Arrange 2^35 = 00000001 00000000 00000000 00000000 00000000 10000000
+	var warnings []error
+	message := lenientReader([]byte{1, 0, 0, 0, 0, 128}, &warnings)
+
+	// Act
+	_, err := ReadUInt32(message)
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), errors.R6) {
+		t.Errorf("Expected error about uint32 overflow but got: %#v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("Expected no warnings when the value could not be read, result: %v", warnings)
+	}
+}
+
+func TestLenientOverlongNegativeInt32IsReadWithWarning(t *testing.T) {
+	// Arrange -1 encoded over 6 bytes = 01111111 01111111 01111111 01111111 01111111 11111111
+	var warnings []error
+	message := lenientReader([]byte{127, 127, 127, 127, 127, 255}, &warnings)
+
+	// Act
+	result, err := ReadInt32(message)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error reading int32 when none was expected: %s", err)
+	}
+	if result.Value != -1 {
+		t.Errorf("Did not read the expected int32, expected: -1, result: %#v", result)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("Expected a single R6 warning, result: %v", warnings)
+	}
+}
+
+func TestLenientOverlongUInt64IsReadWithWarning(t *testing.T) {
+	// Arrange 10 encoded over 11 bytes = 00000000 x 10, 10001010
+	var warnings []error
+	message := lenientReader([]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 138}, &warnings)
+
+	// Act
+	result, err := ReadUInt64(message)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error reading uint64 when none was expected: %s", err)
+	}
+	if result.Value != 10 {
+		t.Errorf("Did not read the expected uint64, expected: 10, result: %#v", result)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("Expected a single R6 warning, result: %v", warnings)
+	}
+}
+
+func TestLenientOverlongUInt64WithLostBitsReturnsError(t *testing.T) {
+	// Arrange first byte has bits that can not fit within a uint64 = 01111111 00000000 x 9, 10001010
+	var warnings []error
+	message := lenientReader([]byte{127, 0, 0, 0, 0, 0, 0, 0, 0, 0, 138}, &warnings)
+
+	// Act
+	_, err := ReadUInt64(message)
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), errors.R6) {
+		t.Errorf("Expected error about uint64 overflow but got: %#v", err)
+	}
+}
+
+func TestLenientOverlongNegativeInt64IsReadWithWarning(t *testing.T) {
+	// Arrange -50 encoded over 11 bytes = 01111111 x 10, 11001110
+	var warnings []error
+	message := lenientReader([]byte{127, 127, 127, 127, 127, 127, 127, 127, 127, 127, 206}, &warnings)
+
+	// Act
+	result, err := ReadInt64(message)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error reading int64 when none was expected: %s", err)
+	}
+	if result.Value != -50 {
+		t.Errorf("Did not read the expected int64, expected: -50, result: %#v", result)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("Expected a single R6 warning, result: %v", warnings)
+	}
+}
+
+func TestLenientOverlongBigIntIsReadWithWarning(t *testing.T) {
+	// Arrange 10 encoded over 12 bytes = 00000000 x 11, 10001010
+	var warnings []error
+	message := lenientReader([]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 138}, &warnings)
+
+	// Act
+	result, err := ReadBigInt(message)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error reading big int when none was expected: %s", err)
+	}
+	if result.Value.Cmp(big.NewInt(10)) != 0 {
+		t.Errorf("Did not read the expected big int, expected: 10, result: %s", result.Value)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("Expected a single R6 warning, result: %v", warnings)
+	}
+}
+
+func TestStrictOverlongBigIntReturnsError(t *testing.T) {
+	// Arrange 10 encoded over 12 bytes = 00000000 x 11, 10001010
+	message := bytes.NewBuffer([]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 138})
+
+	// Act
+	_, err := ReadBigInt(message)
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), errors.R6) {
+		t.Errorf("Expected error about int64 overflow but got: %#v", err)
+	}
+}
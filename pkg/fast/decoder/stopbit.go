@@ -0,0 +1,86 @@
+package decoder
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// The functions in this file decode stop bit encoded integers directly from the byte slice of a Cursor. The stop bit ending an integer is found by loading
+// eight bytes at a time and masking their most significant bits, rather than reading each byte through the Reader interface until one has its stop bit set.
+// Integers are only decoded this way when the stop bit is found within the number of bytes their type can be encoded in, and there are enough bytes left
+// to load whole words, otherwise the integer is read by the byte loops in decoder.go. Both decode exactly the same value from the same bytes.
+
+// stopBits masks the most significant bit of each of the eight bytes of a word
+const stopBits = 0x8080808080808080
+
+// scanStopBit returns the number of bytes up to and including the first byte with its stop bit set from the offset of the cursor, loading a word at a time.
+// False is returned if the stop bit is not within maxLength bytes, or there are not enough bytes left to load the words scanned.
+func (cursor *Cursor) scanStopBit(maxLength int) (int, bool) {
+	data := cursor.data[cursor.offset:]
+	if len(data) < 8 {
+		return 0, false
+	}
+
+	// the first byte is the least significant byte of a little endian word, so the number of trailing zeros finds the first stop bit
+	if stops := binary.LittleEndian.Uint64(data) & stopBits; stops != 0 {
+		length := bits.TrailingZeros64(stops)/8 + 1
+		return length, length <= maxLength
+	}
+	if maxLength <= 8 || len(data) < 16 {
+		return 0, false
+	}
+
+	if stops := binary.LittleEndian.Uint64(data[8:]) & stopBits; stops != 0 {
+		length := bits.TrailingZeros64(stops)/8 + 9
+		return length, length <= maxLength
+	}
+	return 0, false
+}
+
+// fastUnsigned decodes the unsigned integer at the offset of the cursor, in the same way as the byte loops of the unsigned Read functions, if its stop bit
+// is within maxLength bytes. Nothing is read if false is returned.
+func fastUnsigned(inputSource Reader, maxLength int) (uint64, bool) {
+	cursor, ok := cursorOf(inputSource)
+	if !ok {
+		return 0, false
+	}
+	length, ok := cursor.scanStopBit(maxLength)
+	if !ok {
+		return 0, false
+	}
+
+	var readValue uint64 = 0
+	for _, b := range cursor.data[cursor.offset : cursor.offset+length] {
+		readValue = readValue<<7 | uint64(b&127)
+	}
+
+	cursor.offset += length
+	return readValue, true
+}
+
+// fastSigned decodes the 2's compliment integer at the offset of the cursor, in the same way as the byte loops of the signed Read functions, if its stop
+// bit is within maxLength bytes. Nothing is read if false is returned.
+func fastSigned(inputSource Reader, maxLength int) (int64, bool) {
+	cursor, ok := cursorOf(inputSource)
+	if !ok {
+		return 0, false
+	}
+	length, ok := cursor.scanStopBit(maxLength)
+	if !ok {
+		return 0, false
+	}
+
+	var readValue int64 = 0
+	integer := cursor.data[cursor.offset : cursor.offset+length]
+
+	// 64 = 01000000, indicating this is negative so we should start with all 1's (-1)
+	if integer[0]&64 == 64 {
+		readValue = -1
+	}
+	for _, b := range integer {
+		readValue = readValue<<7 | int64(b&127)
+	}
+
+	cursor.offset += length
+	return readValue, true
+}
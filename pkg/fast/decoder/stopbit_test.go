@@ -0,0 +1,206 @@
+package decoder
+
+import (
+	"bytes"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// integerReads are the reads with a fast path for decoding from a Cursor
+var integerReads = []struct {
+	name string
+	read func(Reader) string
+}{
+	{"uint32", func(inputSource Reader) string {
+		readValue, err := ReadUInt32(inputSource)
+		return describe(readValue, err)
+	}},
+	{"int32", func(inputSource Reader) string {
+		readValue, err := ReadInt32(inputSource)
+		return describe(readValue, err)
+	}},
+	{"uint64", func(inputSource Reader) string {
+		readValue, err := ReadUInt64(inputSource)
+		return describe(readValue, err)
+	}},
+	{"int64", func(inputSource Reader) string {
+		readValue, err := ReadInt64(inputSource)
+		return describe(readValue, err)
+	}},
+	{"big uint", func(inputSource Reader) string {
+		readValue, err := ReadBigUInt(inputSource)
+		return describe(readValue, err)
+	}},
+	{"big int", func(inputSource Reader) string {
+		readValue, err := ReadBigInt(inputSource)
+		return describe(readValue, err)
+	}},
+}
+
+// TestCursorReadsTheSameIntegersAsByteLoopOnRandomInput reads random integers from a Cursor, which decodes them from its byte slice, and from a
+// bytes.Buffer, which reads them a byte at a time, reading until the bytes run out. Both must read the same values, errors and number of bytes.
+func TestCursorReadsTheSameIntegersAsByteLoopOnRandomInput(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 20000; i++ {
+		message := randomIntegers(random)
+		for _, integerRead := range integerReads {
+			for _, lenient := range []bool{false, true} {
+				cursor := &SettingsReader{Reader: NewCursor(message), Settings: Settings{Lenient: lenient}}
+				buffer := &SettingsReader{Reader: bytes.NewBuffer(message), Settings: Settings{Lenient: lenient}}
+
+				for read := 0; ; read++ {
+					fromCursor, fromBuffer := integerRead.read(cursor), integerRead.read(buffer)
+					cursorOffset, bufferOffset := cursor.Reader.(*Cursor).Offset(), len(message)-buffer.Reader.(*bytes.Buffer).Len()
+
+					if fromCursor != fromBuffer || cursorOffset != bufferOffset {
+						t.Fatalf("Expected %s %d from %v read from a cursor (lenient: %t) to be the same as the byte loop, expected: %s after %d bytes, result: %s after %d bytes",
+							integerRead.name, read, message, lenient, fromBuffer, bufferOffset, fromCursor, cursorOffset)
+					}
+					if cursorOffset == len(message) || strings.HasPrefix(fromCursor, "error") {
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestCanReadIntegerEndingInSecondWordFromCursor(t *testing.T) {
+	// Arrange the stop bit is on the tenth byte, which is in the second word loaded
+	message := []byte{0, 0, 0, 0, 0, 0, 0, 0, 1, 130, 1, 2, 3, 4, 5, 6}
+
+	// Act
+	result, err := ReadUInt64(NewCursor(message))
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error reading uint64 when none was expected: %s", err)
+	}
+	if result.Value != 130 {
+		t.Errorf("Did not read the expected uint64, expected: %d, result: %d", 130, result.Value)
+	}
+}
+
+// randomIntegers of up to 4 integers, each up to 12 bytes long with its stop bit set on its final byte, unless the integer runs out of bytes. Bytes are
+// biased towards 0 and 127, so integers are often overlong and at the edges of their type.
+func randomIntegers(random *rand.Rand) []byte {
+	message := make([]byte, 0, 48)
+	for integers := random.Intn(4) + 1; integers > 0; integers-- {
+		length := random.Intn(12) + 1
+		for i := 0; i < length; i++ {
+			var b byte
+			switch random.Intn(4) {
+			case 0:
+				b = 0
+			case 1:
+				b = 127
+			default:
+				b = byte(random.Intn(128))
+			}
+			message = append(message, b)
+		}
+
+		if random.Intn(16) != 0 {
+			message[len(message)-1] |= 128
+		}
+	}
+	return message
+}
+
+// benchmarkIntegers is a message of integers 1 to 5 bytes long, similar to the integers of market data messages
+var benchmarkIntegers = bytes.Repeat([]byte{
+	0x81, 0x01, 0x82, 0x01, 0x02, 0x83, 0x01, 0x02, 0x03, 0x84, 0x7f, 0x7f, 0x7f, 0x7f, 0xff, 0x85,
+	0x01, 0x86, 0x01, 0x02, 0x83, 0x01, 0x02, 0x03, 0x84, 0x0f, 0x7f, 0x7f, 0x7f, 0xff, 0x81, 0x82,
+}, 8)
+
+// benchmarkRead every integer of benchmarkIntegers, one integer for each byte with its stop bit set
+func benchmarkRead(b *testing.B, inputSource func() Reader, read func(Reader) error) {
+	integers := 0
+	for _, integer := range benchmarkIntegers {
+		if integer&128 == 128 {
+			integers++
+		}
+	}
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(benchmarkIntegers)))
+	for i := 0; i < b.N; i++ {
+		reader := inputSource()
+		for integer := 0; integer < integers; integer++ {
+			if err := read(reader); err != nil {
+				b.Fatalf("Got an error reading integer %d when none was expected: %s", integer, err)
+			}
+		}
+	}
+}
+
+func BenchmarkReadUInt32FromCursor(b *testing.B) {
+	cursor := NewCursor(nil)
+	benchmarkRead(b, func() Reader {
+		cursor.Reset(benchmarkIntegers)
+		return cursor
+	}, func(inputSource Reader) error {
+		_, err := ReadUInt32(inputSource)
+		return err
+	})
+}
+
+func BenchmarkReadUInt32FromBuffer(b *testing.B) {
+	buffer := bytes.NewBuffer(nil)
+	benchmarkRead(b, func() Reader {
+		buffer.Reset()
+		buffer.Write(benchmarkIntegers)
+		return buffer
+	}, func(inputSource Reader) error {
+		_, err := ReadUInt32(inputSource)
+		return err
+	})
+}
+
+func BenchmarkReadInt64FromCursor(b *testing.B) {
+	cursor := NewCursor(nil)
+	benchmarkRead(b, func() Reader {
+		cursor.Reset(benchmarkIntegers)
+		return cursor
+	}, func(inputSource Reader) error {
+		_, err := ReadInt64(inputSource)
+		return err
+	})
+}
+
+func BenchmarkReadInt64FromBuffer(b *testing.B) {
+	buffer := bytes.NewBuffer(nil)
+	benchmarkRead(b, func() Reader {
+		buffer.Reset()
+		buffer.Write(benchmarkIntegers)
+		return buffer
+	}, func(inputSource Reader) error {
+		_, err := ReadInt64(inputSource)
+		return err
+	})
+}
+
+func BenchmarkReadNullableInt32FromCursor(b *testing.B) {
+	cursor := NewCursor(nil)
+	benchmarkRead(b, func() Reader {
+		cursor.Reset(benchmarkIntegers)
+		return cursor
+	}, func(inputSource Reader) error {
+		_, _, err := ReadNullableInt32(inputSource)
+		return err
+	})
+}
+
+func BenchmarkReadNullableInt32FromBuffer(b *testing.B) {
+	buffer := bytes.NewBuffer(nil)
+	benchmarkRead(b, func() Reader {
+		buffer.Reset()
+		buffer.Write(benchmarkIntegers)
+		return buffer
+	}, func(inputSource Reader) error {
+		_, _, err := ReadNullableInt32(inputSource)
+		return err
+	})
+}
@@ -0,0 +1,218 @@
+package decoder
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/errors"
+)
+
+// The functions in this file read the same values as their Read<Type> and ReadOptional<Type> counterparts, without allocating. Optional values are
+// returned along with whether they were null rather than as a value.Value, and strings and byte vectors are appended to a buffer the caller reuses.
+
+// ReadNullableUInt32 reads the same value as ReadOptionalUInt32, returning true if the value is null
+func ReadNullableUInt32(inputSource Reader) (uint32, bool, error) {
+	readValue, err := ReadUInt64(inputSource) // allow for overflow
+	if err != nil {
+		return 0, false, fmt.Errorf("unable to read value before assesing nullability, reason: %s", err)
+	}
+
+	if readValue.Value == uint64(0) {
+		return 0, true, nil
+	}
+
+	return uint32(readValue.Value - 1), false, nil
+}
+
+// ReadNullableInt32 reads the same value as ReadOptionalInt32, returning true if the value is null
+func ReadNullableInt32(inputSource Reader) (int32, bool, error) {
+	readValue, err := ReadInt64(inputSource) // allow for overflow
+	if err != nil {
+		return 0, false, fmt.Errorf("unable to read value before assesing nullability, reason: %s", err)
+	}
+
+	if readValue.Value == int64(0) {
+		return 0, true, nil
+	}
+
+	if readValue.Value > 0 {
+		readValue.Value = readValue.Value - 1
+	}
+
+	return int32(readValue.Value), false, nil
+}
+
+// ReadNullableUInt64 reads the same value as ReadOptionalUInt64, returning true if the value is null. A big.Int is only built for values encoded in
+// 10 or more bytes.
+func ReadNullableUInt64(inputSource Reader) (uint64, bool, error) {
+	readValue, bigValue, err := readUnsignedOrBig(inputSource)
+	if err != nil {
+		return 0, false, fmt.Errorf("unable to read value before assesing nullability, reason: %s", err)
+	}
+
+	if bigValue == nil {
+		if readValue == 0 {
+			return 0, true, nil
+		}
+		return readValue - 1, false, nil
+	}
+
+	if bigValue.Sign() == 0 {
+		return 0, true, nil
+	}
+
+	bigValue = bigValue.Sub(bigValue, big.NewInt(1))
+	if bigValue.IsUint64() {
+		return bigValue.Uint64(), false, nil
+	}
+
+	return 0, false, fmt.Errorf("%s, uint64", errors.R6)
+}
+
+// ReadNullableInt64 reads the same value as ReadOptionalInt64, returning true if the value is null. A big.Int is only built for values encoded in
+// 10 or more bytes.
+func ReadNullableInt64(inputSource Reader) (int64, bool, error) {
+	readValue, bigValue, isNull, err := ReadNullableInt64OrBigInt(inputSource)
+	if err != nil || isNull || bigValue == nil {
+		return readValue, isNull, err
+	}
+
+	if bigValue.IsInt64() {
+		return bigValue.Int64(), false, nil
+	}
+
+	return 0, false, fmt.Errorf("%s, int64", errors.R6)
+}
+
+// ReadNullableInt64OrBigInt reads the same value as ReadOptionalBigInt, returning true if the value is null. As with ReadInt64OrBigInt the value is
+// returned as an int64 unless it is encoded in 10 or more bytes, in which case it is returned as a big.Int.
+func ReadNullableInt64OrBigInt(inputSource Reader) (int64, *big.Int, bool, error) {
+	readValue, bigValue, err := ReadInt64OrBigInt(inputSource)
+	if err != nil {
+		return 0, nil, false, fmt.Errorf("unable to read value before assesing nullability, reason: %s", err)
+	}
+
+	if bigValue == nil {
+		if readValue == 0 {
+			return 0, nil, true, nil
+		}
+		if readValue > 0 {
+			readValue = readValue - 1
+		}
+		return readValue, nil, false, nil
+	}
+
+	equalToZero := bigValue.Sign()
+	if equalToZero == 0 {
+		return 0, nil, true, nil
+	}
+	if equalToZero > 0 {
+		bigValue = bigValue.Sub(bigValue, big.NewInt(1))
+	}
+
+	return 0, bigValue, false, nil
+}
+
+// AppendString reads the same ASCII string as ReadString, appending its characters to buffer
+func AppendString(buffer []byte, inputSource Reader) ([]byte, error) {
+	for {
+		b, err := inputSource.ReadByte()
+		if err != nil {
+			return buffer, fmt.Errorf("unable to read byte off byte buffer, reason: %s", err)
+		}
+
+		// 128 = 10000000, this will equal 128 if we have a stop bit present (most significant bit is 1)
+		if result := b & 128; result == 128 {
+			return appendNotNullByte(buffer, b&127), nil
+		}
+
+		// no stop bit present so 0 in most significant bit, so just add as 7 bit char to string
+		buffer = append(buffer, b)
+	}
+}
+
+// AppendNullableString reads the same ASCII string as ReadOptionalString, appending its characters to buffer and returning true if the string is null
+func AppendNullableString(buffer []byte, inputSource Reader) ([]byte, bool, error) {
+	possibleNullIndiciator, err := inputSource.ReadByte()
+	if err != nil {
+		return buffer, false, fmt.Errorf("unable to read byte off byte buffer, reason: %s", err)
+	}
+
+	// 128 = 10000000, this is seen as null in optional string
+	if possibleNullIndiciator == 128 {
+		return buffer, true, nil
+	}
+
+	// if this is the end of the string (its a 1 byte string) return result
+	if result := possibleNullIndiciator & 128; result == 128 {
+		return appendNotNullByte(buffer, possibleNullIndiciator&127), false, nil
+	}
+
+	possibleEmptyStringIndicator, err := inputSource.ReadByte()
+	if err != nil {
+		return buffer, false, fmt.Errorf("unable to read byte off byte buffer, reason: %s", err)
+	}
+
+	// if this is the end of the string (its a 2 byte string) return result, which is empty if it is 00000000 10000000
+	if result := possibleEmptyStringIndicator & 128; result == 128 {
+		buffer = appendNotNullByte(buffer, possibleNullIndiciator&127)
+		return appendNotNullByte(buffer, possibleEmptyStringIndicator&127), false, nil
+	}
+
+	// not an empty or null string, append bytes read and read rest of string as normal
+	buffer = appendNotNullByte(buffer, possibleNullIndiciator)
+	buffer = appendNotNullByte(buffer, possibleEmptyStringIndicator)
+
+	buffer, err = AppendString(buffer, inputSource)
+	return buffer, false, err
+}
+
+func appendNotNullByte(buffer []byte, char byte) []byte {
+	if char != 0 {
+		return append(buffer, char)
+	}
+	return buffer
+}
+
+// AppendByteVector reads the same byte vector as ReadByteVector, appending it to buffer
+func AppendByteVector(buffer []byte, inputSource Reader) ([]byte, error) {
+	length, err := ReadUInt32(inputSource)
+	if err != nil {
+		return buffer, fmt.Errorf("unable to read byte off byte buffer, reason: %s", err)
+	}
+
+	return appendBytes(buffer, inputSource, length.Value)
+}
+
+// AppendNullableByteVector reads the same byte vector as ReadOptionalByteVector, appending it to buffer and returning true if the byte vector is null
+func AppendNullableByteVector(buffer []byte, inputSource Reader) ([]byte, bool, error) {
+	length, isNull, err := ReadNullableUInt32(inputSource)
+	if err != nil {
+		return buffer, false, fmt.Errorf("unable to read value before assesing nullability, reason: %s", err)
+	}
+	if isNull {
+		return buffer, true, nil
+	}
+
+	buffer, err = appendBytes(buffer, inputSource, length)
+	return buffer, false, err
+}
+
+// appendBytes reads length bytes, which are not stop bit encoded, onto the end of buffer
+func appendBytes(buffer []byte, inputSource Reader, length uint32) ([]byte, error) {
+	start := len(buffer)
+	end := start + int(length)
+	if end > cap(buffer) {
+		grown := make([]byte, end)
+		copy(grown, buffer)
+		buffer = grown
+	}
+	buffer = buffer[:end]
+
+	number, err := io.ReadFull(inputSource, buffer[start:])
+	if err != nil {
+		return buffer[:start], fmt.Errorf("did not read full length of byte vector, expected to read: %d, but actually read %d, reason: %s", length, number, err)
+	}
+	return buffer, nil
+}
@@ -0,0 +1,200 @@
+package decoder
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/value"
+)
+
+// encodings read by the typed functions, covering nulls, empty values, single and multi byte values, overflow, overlong encodings and running out of bytes
+var encodings = [][]byte{
+	{128},
+	{129},
+	{255},
+	{0, 128},
+	{0, 129},
+	{0, 0, 128},
+	{65, 66, 195},
+	{127, 255},
+	{15, 127, 127, 127, 255},
+	{16, 0, 0, 0, 128},
+	{1, 0, 0, 0, 0, 0, 0, 0, 0, 128},
+	{1, 0, 0, 0, 0, 0, 0, 0, 0, 129},
+	{127, 127, 127, 127, 127, 127, 127, 127, 127, 255},
+	{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 128},
+	{130, 1, 2},
+	{131, 1, 2},
+	{0},
+	{},
+}
+
+func TestTypedReadsReadTheSameValuesAsValueReads(t *testing.T) {
+	for _, encoding := range encodings {
+		assertReadsTheSame(t, "uint32", encoding, func(inputSource Reader) string {
+			readValue, isNull, err := ReadNullableUInt32(inputSource)
+			return describeNullable(value.UInt32Value{Value: readValue}, isNull, err)
+		}, func(inputSource Reader) string {
+			readValue, err := ReadOptionalUInt32(inputSource)
+			return describe(readValue, err)
+		})
+		assertReadsTheSame(t, "int32", encoding, func(inputSource Reader) string {
+			readValue, isNull, err := ReadNullableInt32(inputSource)
+			return describeNullable(value.Int32Value{Value: readValue}, isNull, err)
+		}, func(inputSource Reader) string {
+			readValue, err := ReadOptionalInt32(inputSource)
+			return describe(readValue, err)
+		})
+		assertReadsTheSame(t, "uint64", encoding, func(inputSource Reader) string {
+			readValue, isNull, err := ReadNullableUInt64(inputSource)
+			return describeNullable(value.UInt64Value{Value: readValue}, isNull, err)
+		}, func(inputSource Reader) string {
+			readValue, err := ReadOptionalUInt64(inputSource)
+			return describe(readValue, err)
+		})
+		assertReadsTheSame(t, "int64", encoding, func(inputSource Reader) string {
+			readValue, isNull, err := ReadNullableInt64(inputSource)
+			return describeNullable(value.Int64Value{Value: readValue}, isNull, err)
+		}, func(inputSource Reader) string {
+			readValue, err := ReadOptionalInt64(inputSource)
+			return describe(readValue, err)
+		})
+		assertReadsTheSame(t, "big int", encoding, func(inputSource Reader) string {
+			readValue, bigValue, err := ReadInt64OrBigInt(inputSource)
+			return describeBig(readValue, bigValue, false, err)
+		}, func(inputSource Reader) string {
+			readValue, err := ReadBigInt(inputSource)
+			return describe(readValue, err)
+		})
+		assertReadsTheSame(t, "optional big int", encoding, func(inputSource Reader) string {
+			readValue, bigValue, isNull, err := ReadNullableInt64OrBigInt(inputSource)
+			return describeBig(readValue, bigValue, isNull, err)
+		}, func(inputSource Reader) string {
+			readValue, err := ReadOptionalBigInt(inputSource)
+			return describe(readValue, err)
+		})
+		assertReadsTheSame(t, "string", encoding, func(inputSource Reader) string {
+			readValue, err := AppendString([]byte("previous"), inputSource)
+			return describeAppended(readValue, false, err, false)
+		}, func(inputSource Reader) string {
+			readValue, err := ReadString(inputSource)
+			return describe(readValue, err)
+		})
+		assertReadsTheSame(t, "optional string", encoding, func(inputSource Reader) string {
+			readValue, isNull, err := AppendNullableString([]byte("previous"), inputSource)
+			return describeAppended(readValue, isNull, err, false)
+		}, func(inputSource Reader) string {
+			readValue, err := ReadOptionalString(inputSource)
+			return describe(readValue, err)
+		})
+
+		// byte vectors are only read when their length is small, as the value read allocates the full length before reading it
+		if length, err := ReadUInt64(NewCursor(encoding)); err != nil || length.Value > 16 {
+			continue
+		}
+		assertReadsTheSame(t, "byte vector", encoding, func(inputSource Reader) string {
+			readValue, err := AppendByteVector([]byte("previous"), inputSource)
+			return describeAppended(readValue, false, err, true)
+		}, func(inputSource Reader) string {
+			readValue, err := ReadByteVector(inputSource)
+			return describe(readValue, err)
+		})
+		assertReadsTheSame(t, "optional byte vector", encoding, func(inputSource Reader) string {
+			readValue, isNull, err := AppendNullableByteVector([]byte("previous"), inputSource)
+			return describeAppended(readValue, isNull, err, true)
+		}, func(inputSource Reader) string {
+			readValue, err := ReadOptionalByteVector(inputSource)
+			return describe(readValue, err)
+		})
+	}
+}
+
+func TestAppendValueReusesBuffer(t *testing.T) {
+	// Arrange
+	buffer := make([]byte, 0, 4)
+
+	// Act
+	result, err := AppendValue(buffer, bytes.NewBuffer([]byte{1, 2, 131}))
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error appending value when none was expected: %s", err)
+	}
+	if !bytes.Equal(result, []byte{1, 2, 131}) || &result[0] != &buffer[:1][0] {
+		t.Errorf("Expected the value to be appended to the buffer given, result: %#v", result)
+	}
+}
+
+// assertReadsTheSame value with the typed read as the value read, the typed read is given a copy of the encoding which it must not change
+func assertReadsTheSame(t *testing.T, name string, encoding []byte, typedRead func(Reader) string, valueRead func(Reader) string) {
+	typedEncoding := append([]byte{}, encoding...)
+	typedSource, valueSource := NewCursor(typedEncoding), NewCursor(encoding)
+
+	typed, expected := typedRead(typedSource), valueRead(valueSource)
+	if typedSource.Offset() != valueSource.Offset() {
+		expected = fmt.Sprintf("%s after reading %d bytes", expected, valueSource.Offset())
+		typed = fmt.Sprintf("%s after reading %d bytes", typed, typedSource.Offset())
+	}
+	if !bytes.Equal(typedEncoding, encoding) {
+		typed = fmt.Sprintf("%s leaving the encoding changed to %v", typed, typedEncoding)
+	}
+
+	if typed != expected {
+		t.Errorf("Expected typed read of %s from %v to read the same as the value read, expected: %s, result: %s", name, encoding, expected, typed)
+	}
+}
+
+func describe(readValue value.Value, err error) string {
+	if err != nil {
+		return fmt.Sprintf("error: %s", err)
+	}
+
+	switch t := readValue.(type) {
+	case value.NullValue:
+		return "null"
+	case value.BigInt:
+		return t.Value.String()
+	}
+	return readValue.GetAsFix().String()
+}
+
+func describeNullable(readValue value.Value, isNull bool, err error) string {
+	if isNull && err == nil {
+		return "null"
+	}
+	return describe(readValue, err)
+}
+
+func describeBig(readValue int64, bigValue *big.Int, isNull bool, err error) string {
+	if err != nil {
+		return fmt.Sprintf("error: %s", err)
+	}
+	if isNull {
+		return "null"
+	}
+	if bigValue != nil {
+		return bigValue.String()
+	}
+	return fmt.Sprint(readValue)
+}
+
+// describeAppended value, which must have been appended to the previous value
+func describeAppended(readValue []byte, isNull bool, err error, isByteVector bool) string {
+	if !bytes.HasPrefix(readValue, []byte("previous")) {
+		return fmt.Sprintf("overwrote the buffer: %v", readValue)
+	}
+	readValue = readValue[len("previous"):]
+
+	if err != nil {
+		return fmt.Sprintf("error: %s", err)
+	}
+	if isNull {
+		return "null"
+	}
+	if isByteVector {
+		return value.ByteVector{Value: readValue}.GetAsFix().String()
+	}
+	return value.StringValue{Value: string(readValue)}.GetAsFix().String()
+}
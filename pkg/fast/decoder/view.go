@@ -0,0 +1,73 @@
+package decoder
+
+import (
+	"fmt"
+	"io"
+)
+
+// The functions in this file read byte vectors as views into the byte slice of a Cursor, rather than copying them. Views are capped, so appending to one
+// copies it rather than writing over the bytes that follow it. The byte slice is only ever read from, so a view is valid for as long as the byte slice is
+// not reused. ASCII strings are not viewed, as the last character of a string holds its stop bit, so the characters of a string can not be read from the
+// byte slice without either copying them or changing the byte slice.
+
+// cursorOf the reader, if the reader is a Cursor or a SettingsReader reading from a Cursor
+func cursorOf(reader Reader) (*Cursor, bool) {
+	switch t := reader.(type) {
+	case *Cursor:
+		return t, true
+	case *SettingsReader:
+		cursor, ok := t.Reader.(*Cursor)
+		return cursor, ok
+	}
+
+	return nil, false
+}
+
+// ViewByteVector reads the same byte vector as ReadByteVector, returning a view of it within the byte slice of the Cursor being read from, or a copy if the
+// reader is not a Cursor
+func ViewByteVector(inputSource Reader) ([]byte, error) {
+	if _, ok := cursorOf(inputSource); !ok {
+		return AppendByteVector(nil, inputSource)
+	}
+
+	length, err := ReadUInt32(inputSource)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read byte off byte buffer, reason: %s", err)
+	}
+	return viewBytes(inputSource, length.Value)
+}
+
+// ViewNullableByteVector reads the same byte vector as ReadOptionalByteVector in the same way as ViewByteVector, returning true if the byte vector is null
+func ViewNullableByteVector(inputSource Reader) ([]byte, bool, error) {
+	if _, ok := cursorOf(inputSource); !ok {
+		return AppendNullableByteVector(nil, inputSource)
+	}
+
+	length, isNull, err := ReadNullableUInt32(inputSource)
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to read value before assesing nullability, reason: %s", err)
+	}
+	if isNull {
+		return nil, true, nil
+	}
+
+	view, err := viewBytes(inputSource, length)
+	return view, false, err
+}
+
+// viewBytes of the next length bytes, which are not stop bit encoded, failing in the same way as io.ReadFull if there are not enough bytes left
+func viewBytes(inputSource Reader, length uint32) ([]byte, error) {
+	cursor, _ := cursorOf(inputSource)
+	if remaining := cursor.Len(); uint64(length) > uint64(remaining) {
+		cursor.offset = len(cursor.data)
+		reason := io.ErrUnexpectedEOF
+		if remaining == 0 {
+			reason = io.EOF
+		}
+		return nil, fmt.Errorf("did not read full length of byte vector, expected to read: %d, but actually read %d, reason: %s", length, remaining, reason)
+	}
+
+	start := cursor.offset
+	cursor.offset += int(length)
+	return cursor.data[start:cursor.offset:cursor.offset], nil
+}
@@ -0,0 +1,80 @@
+package decoder
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestViewsReadTheSameValuesAsValueReads(t *testing.T) {
+	for _, encoding := range encodings {
+		// byte vectors are only read when their length is small, as the value read allocates the full length before reading it
+		if length, err := ReadUInt64(NewCursor(encoding)); err != nil || length.Value > 16 {
+			continue
+		}
+		assertReadsTheSame(t, "byte vector view", encoding, func(inputSource Reader) string {
+			view, err := ViewByteVector(inputSource)
+			return describeView(view, false, err, true)
+		}, func(inputSource Reader) string {
+			readValue, err := ReadByteVector(inputSource)
+			return describe(readValue, err)
+		})
+		assertReadsTheSame(t, "optional byte vector view", encoding, func(inputSource Reader) string {
+			view, isNull, err := ViewNullableByteVector(inputSource)
+			return describeView(view, isNull, err, true)
+		}, func(inputSource Reader) string {
+			readValue, err := ReadOptionalByteVector(inputSource)
+			return describe(readValue, err)
+		})
+	}
+}
+
+func TestZeroCopyStringIsCopiedLeavingTheMessageUnchanged(t *testing.T) {
+	// Arrange
+	message := []byte{0x48, 0x69, 0xA1, 0x81}
+	cursor := NewCursor(message)
+
+	// Act
+	readValue, err := ReadString(&SettingsReader{Reader: cursor, Settings: Settings{ZeroCopy: true}})
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error reading string when none was expected: %s", err)
+	}
+	if readValue.Value != "Hi!" || cursor.Offset() != 3 {
+		t.Errorf("Expected to read the string Hi! from the first 3 bytes, read: %s from %d bytes", readValue.Value, cursor.Offset())
+	}
+	if !bytes.Equal(message, []byte{0x48, 0x69, 0xA1, 0x81}) {
+		t.Errorf("Expected the message to be unchanged by reading a string, message: %#v", message)
+	}
+
+	message[0] = 0x4A
+	if readValue.Value != "Hi!" {
+		t.Errorf("Expected the string to be a copy unchanged by the message being reused, string: %s", readValue.Value)
+	}
+}
+
+func TestViewByteVectorIsCappedViewOfTheCursor(t *testing.T) {
+	// Arrange
+	message := []byte{0x82, 1, 2, 3}
+	cursor := NewCursor(message)
+
+	// Act
+	readValue, err := ReadByteVector(&SettingsReader{Reader: cursor, Settings: Settings{ZeroCopy: true}})
+	appended := append(readValue.Value, 9)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error reading byte vector view when none was expected: %s", err)
+	}
+	if &readValue.Value[0] != &message[1] || len(readValue.Value) != 2 {
+		t.Errorf("Expected the byte vector to be a view of the message, read: %#v", readValue.Value)
+	}
+	if message[3] != 3 || !bytes.Equal(appended, []byte{1, 2, 9}) {
+		t.Errorf("Expected appending to the view to copy it rather than write over the message, message: %#v", message)
+	}
+}
+
+// describeView read, checking it reads the same when copied rather than viewed
+func describeView(view []byte, isNull bool, err error, isByteVector bool) string {
+	return describeAppended(append([]byte("previous"), view...), isNull, err, isByteVector)
+}
@@ -1,6 +1,10 @@
 package dictionary
 
-import "github.com/Guardian-Development/fastengine/pkg/fix"
+import (
+	"fmt"
+
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
 
 // Value represents a value associated with a key
 type Value interface {
@@ -19,38 +23,332 @@ type AssignedValue struct {
 	Value fix.Value
 }
 
-// Dictionary represents a key value store of values
+// State of the previous value of a key
+type State uint8
+
+const (
+	// Undefined means the previous value has not been set
+	Undefined State = iota
+	// Empty means the previous value has been evaluated and is nil
+	Empty
+	// Assigned means the previous value has been evaluated and is not nil
+	Assigned
+)
+
+const (
+	// GlobalDictionary is shared by every template, and is the dictionary of any field that does not name one
+	GlobalDictionary = "global"
+	// TemplateDictionary holds the keys of each template apart, a key is only shared by the fields of the template it is in
+	TemplateDictionary = "template"
+	// TypeDictionary holds the keys of each application type apart, a key is shared by the fields of every template and sequence of the same type
+	TypeDictionary = "type"
+	// AnyType is the application type of a template or sequence that does not name one with a <typeRef/>
+	AnyType = "any"
+)
+
+// Key of an entry within the dictionaries of previous values. Fields with the same name only share an entry when their keys are in the same dictionary,
+// and for the template and type dictionaries the same template or application type, which is the scope of the key.
+type Key struct {
+	// Dictionary the key is in, which is the global, template or type dictionary, or any other name for a user named dictionary
+	Dictionary string
+	// Scope of the key within the template or type dictionary, which is the name of the template or application type, and is empty for other dictionaries
+	Scope string
+	// Namespace of the name of the key, keys with the same name in different namespaces are different keys
+	Namespace string
+	// Name of the key
+	Name string
+}
+
+// GlobalKey of the name within the global dictionary
+func GlobalKey(name string) Key {
+	return Key{Dictionary: GlobalDictionary, Name: name}
+}
+
+// KeyOf the name within the dictionary, where the key of a template or type dictionary is scoped to the template or application type given
+func KeyOf(dictionaryName string, templateName string, typeName string, name string) Key {
+	switch dictionaryName {
+	case "", GlobalDictionary:
+		return GlobalKey(name)
+	case TemplateDictionary:
+		return Key{Dictionary: TemplateDictionary, Scope: templateName, Name: name}
+	case TypeDictionary:
+		return Key{Dictionary: TypeDictionary, Scope: typeName, Name: name}
+	}
+
+	return Key{Dictionary: dictionaryName, Name: name}
+}
+
+// String of the key, which is its name for keys within the global dictionary, with the namespace of the name in braces before it if it has one
+func (key Key) String() string {
+	name := key.Name
+	if key.Namespace != "" {
+		name = fmt.Sprintf("{%s}%s", key.Namespace, key.Name)
+	}
+
+	switch {
+	case key.Dictionary == GlobalDictionary:
+		return name
+	case key.Scope != "":
+		return fmt.Sprintf("%s[%s].%s", key.Dictionary, key.Scope, name)
+	}
+
+	return fmt.Sprintf("%s.%s", key.Dictionary, name)
+}
+
+// Slot of the entry of a key within a dictionary. The keys of a template store are resolved to slots as the store is loaded, so the previous value of a
+// field is found by indexing the entries of the dictionary rather than hashing its key for every value decoded.
+type Slot int
+
+// Slots the keys of a template store are resolved to. Keys are only resolved while the store is loaded, after which the slots are only read from, so they
+// are shared by every dictionary of the store without a lock. The zero value holds no keys and is ready to use.
+type Slots struct {
+	ofKey map[Key]Slot
+	keys  []Key
+}
+
+// Resolve the key to the next unused slot the first time it is seen, returning the slot of the key
+func (slots *Slots) Resolve(key Key) Slot {
+	if slot, exists := slots.ofKey[key]; exists {
+		return slot
+	}
+
+	if slots.ofKey == nil {
+		slots.ofKey = make(map[Key]Slot)
+	}
+	slot := Slot(len(slots.keys))
+	slots.ofKey[key] = slot
+	slots.keys = append(slots.keys, key)
+	return slot
+}
+
+// SlotOf the key, returning false if the key has not been resolved
+func (slots *Slots) SlotOf(key Key) (Slot, bool) {
+	if slots == nil {
+		return 0, false
+	}
+
+	slot, exists := slots.ofKey[key]
+	return slot, exists
+}
+
+// KeyOf the slot the key was resolved to
+func (slots *Slots) KeyOf(slot Slot) Key {
+	return slots.keys[slot]
+}
+
+// Len is the number of keys that have been resolved to slots
+func (slots *Slots) Len() int {
+	if slots == nil {
+		return 0
+	}
+
+	return len(slots.keys)
+}
+
+// Entry is the previous value of a key, held by its type so it can be read and set without allocating. An entry is only meaningful while its generation is
+// the generation of its dictionary, an entry of an earlier generation has been reset and is Undefined.
+type Entry struct {
+	generation uint32
+	state      State
+	value      fix.TypedValue
+	boxed      fix.Value
+}
+
+// State of the previous value
+func (entry *Entry) State() State {
+	return entry.state
+}
+
+// Value previously assigned, this should only be read from and is only meaningful when the entry is Assigned
+func (entry *Entry) Value() *fix.TypedValue {
+	return &entry.value
+}
+
+// Set the previous value to a copy of the value, a null value is Empty and any other value is Assigned
+func (entry *Entry) Set(value *fix.TypedValue) {
+	entry.state = Assigned
+	if value.Kind == fix.NullKind {
+		entry.state = Empty
+	}
+	entry.value.Set(value)
+	entry.boxed = nil
+}
+
+// Dictionary represents a key value store of values, holding an entry for each slot. Resetting the dictionary starts a new generation of entries, rather
+// than visiting every entry, and an entry is reset when it is next taken from the dictionary.
 type Dictionary struct {
-	keys map[string]Value
+	// slots of the template store the dictionary holds the previous values of, which are shared with every other dictionary of the store
+	slots *Slots
+	// own slots of keys that are not within the slots of the store, which follow the slots of the store
+	own        Slots
+	entries    []Entry
+	generation uint32
+	// onWrite is called with every write to an entry when set, see OnWrite
+	onWrite func(write Write)
+}
+
+// SlotOf the key within the dictionary, a key that is not within the slots of the store is resolved to a slot of the dictionary's own
+func (dictionary *Dictionary) SlotOf(key Key) Slot {
+	if slot, exists := dictionary.slots.SlotOf(key); exists {
+		return slot
+	}
+
+	return Slot(dictionary.slots.Len()) + dictionary.own.Resolve(key)
+}
+
+// slotOf the key within the dictionary, returning false if the key has not been resolved to a slot
+func (dictionary *Dictionary) slotOf(key Key) (Slot, bool) {
+	if slot, exists := dictionary.slots.SlotOf(key); exists {
+		return slot, true
+	}
+
+	slot, exists := dictionary.own.SlotOf(key)
+	return Slot(dictionary.slots.Len()) + slot, exists
+}
+
+// keyOf the slot within the dictionary
+func (dictionary *Dictionary) keyOf(slot Slot) Key {
+	if storeSlots := Slot(dictionary.slots.Len()); slot >= storeSlots {
+		return dictionary.own.KeyOf(slot - storeSlots)
+	}
+
+	return dictionary.slots.KeyOf(slot)
 }
 
-// SetValue sets the associated value with the key
+// SetValue sets the associated value with the key within the global dictionary
 func (dictionary *Dictionary) SetValue(key string, value fix.Value) {
+	dictionary.SetKeyValue(GlobalKey(key), value)
+}
+
+// SetKeyValue sets the associated value with the key
+func (dictionary *Dictionary) SetKeyValue(key Key, value fix.Value) {
+	slot := dictionary.SlotOf(key)
+	if dictionary.onWrite != nil {
+		before := dictionary.inspect(slot)
+		dictionary.setSlotValue(slot, value)
+		dictionary.recordWrite(slot, before)
+		return
+	}
+
+	dictionary.setSlotValue(slot, value)
+}
+
+func (dictionary *Dictionary) setSlotValue(slot Slot, value fix.Value) {
 	switch t := value.(type) {
 	case fix.NullValue:
-		dictionary.keys[key] = EmptyValue{}
+		entry := dictionary.Entry(slot)
+		entry.state = Empty
+		entry.value.SetNull()
+		entry.boxed = nil
 	case fix.RawValue:
-		dictionary.keys[key] = AssignedValue{Value: t}
+		entry := dictionary.Entry(slot)
+		entry.state = Assigned
+		entry.value.SetValue(t)
+		entry.boxed = t
+		// strings and byte vectors may be views into the message they were read from, so are boxed from the copy the dictionary holds when read
+		if entry.value.Kind == fix.StringKind || entry.value.Kind == fix.ByteVectorKind {
+			entry.boxed = nil
+		}
+	case *fix.TypedValue:
+		if t.Kind != fix.SequenceKind {
+			dictionary.Entry(slot).Set(t)
+		}
 	}
 }
 
-// GetValue gets the associated value with the key. If no value is associated this returns UndefinedValue
-func (dictionary Dictionary) GetValue(key string) Value {
-	if value, exists := dictionary.keys[key]; exists {
-		return value
+// Set the entry of the key resolved to the slot to a copy of the value, as Entry(slot).Set does, recording the write if the dictionary has an OnWrite hook
+func (dictionary *Dictionary) Set(slot Slot, value *fix.TypedValue) {
+	if dictionary.onWrite != nil {
+		before := dictionary.inspect(slot)
+		dictionary.Entry(slot).Set(value)
+		dictionary.recordWrite(slot, before)
+		return
+	}
+
+	dictionary.Entry(slot).Set(value)
+}
+
+// GetValue gets the associated value with the key within the global dictionary. If no value is associated this returns UndefinedValue
+func (dictionary *Dictionary) GetValue(key string) Value {
+	return dictionary.GetKeyValue(GlobalKey(key))
+}
+
+// GetKeyValue gets the associated value with the key. If no value is associated this returns UndefinedValue
+func (dictionary *Dictionary) GetKeyValue(key Key) Value {
+	slot, exists := dictionary.slotOf(key)
+	if !exists || int(slot) >= len(dictionary.entries) {
+		return UndefinedValue{}
+	}
+
+	entry := &dictionary.entries[slot]
+	if entry.generation != dictionary.generation {
+		return UndefinedValue{}
+	}
+
+	switch entry.state {
+	case Empty:
+		return EmptyValue{}
+	case Assigned:
+		if entry.boxed == nil {
+			entry.boxed = entry.value.Boxed()
+		}
+		return AssignedValue{Value: entry.boxed}
 	}
 
 	return UndefinedValue{}
 }
 
-// Reset the internal set of key/value pairs to be empty
+// Entry of the key resolved to the slot, which is Undefined if no value has been associated with the key since the dictionary was reset. The entries of
+// the dictionary grow to hold a slot resolved after the dictionary was created, so a pointer to an entry should not be kept once another entry is taken.
+func (dictionary *Dictionary) Entry(slot Slot) *Entry {
+	if int(slot) >= len(dictionary.entries) {
+		dictionary.grow(int(slot) + 1)
+	}
+
+	entry := &dictionary.entries[slot]
+	if entry.generation != dictionary.generation {
+		entry.generation = dictionary.generation
+		entry.state = Undefined
+		entry.boxed = nil
+	}
+	return entry
+}
+
+// grow the entries to hold at least length slots, new entries are Undefined
+func (dictionary *Dictionary) grow(length int) {
+	if count := dictionary.slots.Len() + dictionary.own.Len(); count > length {
+		length = count
+	}
+	entries := make([]Entry, length)
+	copy(entries, dictionary.entries)
+	dictionary.entries = entries
+}
+
+// Reset every key to be Undefined by starting a new generation of entries. The entries are kept so resetting the dictionary does not allocate, and are
+// only visited when the generation wraps around, so no entry of an earlier generation can be mistaken for an entry of the new generation.
 func (dictionary *Dictionary) Reset() {
-	dictionary.keys = make(map[string]Value)
+	dictionary.generation++
+	if dictionary.generation != 0 {
+		return
+	}
+
+	for index := range dictionary.entries {
+		entry := &dictionary.entries[index]
+		entry.generation = 0
+		entry.state = Undefined
+		entry.boxed = nil
+	}
 }
 
-// New dictionary to hold key/value pairs within
+// New dictionary to hold key/value pairs within, where every key is resolved to a slot of the dictionary's own as it is first set
 func New() Dictionary {
+	return Dictionary{}
+}
+
+// NewOf the slots of a template store, holding an entry for every key of the store
+func NewOf(slots *Slots) Dictionary {
 	return Dictionary{
-		keys: make(map[string]Value),
+		slots:   slots,
+		entries: make([]Entry, slots.Len()),
 	}
 }
@@ -0,0 +1,199 @@
+package dictionary
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+func TestSlotOfKeyIsTheSameEveryTimeItIsResolved(t *testing.T) {
+	// Arrange
+	slots := Slots{}
+	first := slots.Resolve(GlobalKey("SlotOfKeyField"))
+
+	// Act
+	second := slots.Resolve(GlobalKey("SlotOfKeyField"))
+	other := slots.Resolve(GlobalKey("SlotOfOtherKeyField"))
+
+	// Assert
+	if first != second {
+		t.Errorf("Expected the key to resolve to the same slot, first: %d, second: %d", first, second)
+	}
+	if other == first {
+		t.Errorf("Expected different keys to resolve to different slots, both resolved to: %d", first)
+	}
+}
+
+func TestGetValueOfKeyIsValueSetByGlobalKey(t *testing.T) {
+	// Arrange
+	dict := New()
+	dict.SetKeyValue(GlobalKey("SlotValueField"), fix.NewRawValue(uint32(10)))
+
+	// Act
+	result := dict.GetValue("SlotValueField")
+
+	// Assert
+	if !reflect.DeepEqual(result, AssignedValue{Value: fix.NewRawValue(uint32(10))}) {
+		t.Errorf("Expected the value set by its global key to be read by name, result: %#v", result)
+	}
+}
+
+func TestDictionaryOfStoreHoldsAnEntryForEverySlotOfTheStore(t *testing.T) {
+	// Arrange
+	slots := &Slots{}
+	slots.Resolve(GlobalKey("StoreFirstField"))
+	slots.Resolve(GlobalKey("StoreSecondField"))
+	otherSlots := &Slots{}
+	otherSlots.Resolve(GlobalKey("OtherStoreField"))
+
+	// Act
+	dict := NewOf(slots)
+	otherDict := NewOf(otherSlots)
+
+	// Assert
+	if len(dict.entries) != 2 || len(otherDict.entries) != 1 {
+		t.Errorf("Expected each dictionary to hold an entry for each slot of its own store, entries: %d, other entries: %d", len(dict.entries), len(otherDict.entries))
+	}
+	if slot := dict.SlotOf(GlobalKey("StoreSecondField")); slot != 1 {
+		t.Errorf("Expected the key to be the slot it was resolved to by the store, slot: %d", slot)
+	}
+}
+
+func TestResetMakesEveryValueUndefined(t *testing.T) {
+	// Arrange
+	dict := New()
+	dict.SetValue("ResetAssignedField", fix.NewRawValue("value"))
+	dict.SetValue("ResetEmptyField", fix.NullValue{})
+
+	// Act
+	dict.Reset()
+
+	// Assert
+	for _, key := range []string{"ResetAssignedField", "ResetEmptyField"} {
+		if result := dict.GetValue(key); result != (UndefinedValue{}) {
+			t.Errorf("Expected %s to be undefined after reset, result: %#v", key, result)
+		}
+		if state := dict.Entry(dict.SlotOf(GlobalKey(key))).State(); state != Undefined {
+			t.Errorf("Expected the entry of %s to be undefined after reset, result: %d", key, state)
+		}
+	}
+}
+
+func TestValueSetAfterResetIsAssigned(t *testing.T) {
+	// Arrange
+	dict := New()
+	dict.SetValue("ResetThenSetField", fix.NewRawValue(int64(1)))
+	dict.Reset()
+
+	// Act
+	dict.SetValue("ResetThenSetField", fix.NewRawValue(int64(2)))
+
+	// Assert
+	result := dict.GetValue("ResetThenSetField")
+	if !reflect.DeepEqual(result, AssignedValue{Value: fix.NewRawValue(int64(2))}) {
+		t.Errorf("Expected the value set after reset to be assigned, result: %#v", result)
+	}
+}
+
+func TestResetWhenGenerationWrapsAroundMakesEveryValueUndefined(t *testing.T) {
+	// Arrange
+	dict := New()
+	dict.generation = math.MaxUint32 - 1
+	dict.SetValue("WrapEarlierGenerationField", fix.NewRawValue(uint64(1)))
+	dict.Reset()
+	dict.SetValue("WrapLatestGenerationField", fix.NewRawValue(uint64(2)))
+
+	// Act
+	dict.Reset()
+
+	// Assert
+	for _, key := range []string{"WrapEarlierGenerationField", "WrapLatestGenerationField"} {
+		if result := dict.GetValue(key); result != (UndefinedValue{}) {
+			t.Errorf("Expected %s to be undefined after the generation wrapped around, result: %#v", key, result)
+		}
+	}
+}
+
+func TestKeyNotWithinSlotsOfStoreIsResolvedToSlotOfTheDictionary(t *testing.T) {
+	// Arrange
+	slots := &Slots{}
+	slots.Resolve(GlobalKey("WithinStoreField"))
+	dict := NewOf(slots)
+	dict.Reset()
+
+	// Act
+	slot := dict.SlotOf(GlobalKey("NotWithinStoreField"))
+	entry := dict.Entry(slot)
+
+	// Assert
+	if slot != 1 || slots.Len() != 1 {
+		t.Errorf("Expected the key to be resolved to the slot following the slots of the store, without changing them, slot: %d, store slots: %d", slot, slots.Len())
+	}
+	if entry.State() != Undefined {
+		t.Errorf("Expected the entry of a key not within the store to be undefined, result: %d", entry.State())
+	}
+	if result := dict.GetKeyValue(GlobalKey("NotWithinStoreField")); result != (UndefinedValue{}) {
+		t.Errorf("Expected the value of a key not within the store to be undefined, result: %#v", result)
+	}
+}
+
+func TestKeyOfTemplateAndTypeDictionariesIsScoped(t *testing.T) {
+	// Act
+	global := KeyOf("", "Template", "Type", "Field")
+	template := KeyOf(TemplateDictionary, "Template", "Type", "Field")
+	applicationType := KeyOf(TypeDictionary, "Template", "Type", "Field")
+	named := KeyOf("named", "Template", "Type", "Field")
+
+	// Assert
+	expected := []Key{
+		{Dictionary: GlobalDictionary, Name: "Field"},
+		{Dictionary: TemplateDictionary, Scope: "Template", Name: "Field"},
+		{Dictionary: TypeDictionary, Scope: "Type", Name: "Field"},
+		{Dictionary: "named", Name: "Field"},
+	}
+	for index, result := range []Key{global, template, applicationType, named} {
+		if result != expected[index] {
+			t.Errorf("The key was not the key expected, expected: %#v, result: %#v", expected[index], result)
+		}
+	}
+}
+
+func TestSlotOfKeysWithTheSameNameInDifferentDictionariesAreDifferent(t *testing.T) {
+	// Arrange
+	slots := Slots{}
+	global := slots.Resolve(GlobalKey("ScopedKeyField"))
+
+	// Act
+	template := slots.Resolve(KeyOf(TemplateDictionary, "First", AnyType, "ScopedKeyField"))
+	otherTemplate := slots.Resolve(KeyOf(TemplateDictionary, "Second", AnyType, "ScopedKeyField"))
+
+	// Assert
+	if global == template || template == otherTemplate {
+		t.Errorf("Expected keys in different dictionaries to resolve to different slots, global: %d, template: %d, other template: %d", global, template, otherTemplate)
+	}
+	if global != slots.Resolve(KeyOf("", "First", AnyType, "ScopedKeyField")) {
+		t.Errorf("Expected the slot of a key to be the slot of its name in the global dictionary")
+	}
+}
+
+func TestStringOfKeyNamesItsDictionaryScopeAndNamespace(t *testing.T) {
+	// Arrange
+	keys := map[string]Key{
+		"Field":                    GlobalKey("Field"),
+		"{urn:quotes}Field":        {Dictionary: GlobalDictionary, Namespace: "urn:quotes", Name: "Field"},
+		"template[Quote].Field":    KeyOf(TemplateDictionary, "Quote", AnyType, "Field"),
+		"prices.{urn:quotes}Field": {Dictionary: "prices", Namespace: "urn:quotes", Name: "Field"},
+	}
+
+	for expected, key := range keys {
+		// Act
+		result := key.String()
+
+		// Assert
+		if result != expected {
+			t.Errorf("The string of the key was not the string expected, expected: %s, result: %s", expected, result)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package dictionary
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+// Inspection of the entry of a key, holding a copy of its state and value so it is not changed by later writes to the entry
+type Inspection struct {
+	Key   Key
+	State State
+	// Value of an Assigned entry, this is the zero value for an Empty or Undefined entry
+	Value fix.TypedValue
+}
+
+// String of the inspected entry, which is the key followed by its value, null for an Empty entry, or undefined for an Undefined entry
+func (inspection Inspection) String() string {
+	switch inspection.State {
+	case Assigned:
+		return fmt.Sprintf("%s=%v", inspection.Key, inspection.Value.Get())
+	case Empty:
+		return fmt.Sprintf("%s=null", inspection.Key)
+	}
+
+	return fmt.Sprintf("%s=undefined", inspection.Key)
+}
+
+// Write to the entry of a key, with the entry before and after it was written
+type Write struct {
+	Before Inspection
+	After  Inspection
+}
+
+// String of the write, which is the key with its value before and after the write
+func (write Write) String() string {
+	return fmt.Sprintf("%s -> %s", write.Before, write.After)
+}
+
+// Inspect the entry of every key given, sorted by dictionary, scope, namespace and name. Inspecting the dictionary does not change any entry.
+func (dictionary *Dictionary) Inspect(keys []Key) []Inspection {
+	inspections := make([]Inspection, 0, len(keys))
+	for _, key := range keys {
+		inspections = append(inspections, dictionary.inspectKey(key))
+	}
+
+	sort.Slice(inspections, func(i, j int) bool {
+		first, second := inspections[i].Key, inspections[j].Key
+		switch {
+		case first.Dictionary != second.Dictionary:
+			return first.Dictionary < second.Dictionary
+		case first.Scope != second.Scope:
+			return first.Scope < second.Scope
+		case first.Namespace != second.Namespace:
+			return first.Namespace < second.Namespace
+		}
+		return first.Name < second.Name
+	})
+	return inspections
+}
+
+// OnWrite sets the hook called with every write to an entry of the dictionary, after the entry is written, which is nil to remove the hook. Writes are
+// made while a message is decoded or encoded, so the hook should return quickly, and is only intended for debugging as each write is copied.
+func (dictionary *Dictionary) OnWrite(hook func(write Write)) {
+	dictionary.onWrite = hook
+}
+
+// inspectKey inspects the entry of the key in the same way as inspect, a key that has not been resolved to a slot is Undefined
+func (dictionary *Dictionary) inspectKey(key Key) Inspection {
+	slot, exists := dictionary.slotOf(key)
+	if !exists {
+		return Inspection{Key: key}
+	}
+
+	return dictionary.inspect(slot)
+}
+
+// inspect the entry of the slot without normalising an entry of an earlier generation, so the dictionary is not changed
+func (dictionary *Dictionary) inspect(slot Slot) Inspection {
+	inspection := Inspection{Key: dictionary.keyOf(slot)}
+	if int(slot) >= len(dictionary.entries) {
+		return inspection
+	}
+
+	entry := &dictionary.entries[slot]
+	if entry.generation != dictionary.generation {
+		return inspection
+	}
+
+	inspection.State = entry.state
+	if entry.state == Assigned {
+		inspection.Value.Set(&entry.value)
+	}
+	return inspection
+}
+
+// recordWrite to the entry of the slot, passing the entry before and after the write to the OnWrite hook
+func (dictionary *Dictionary) recordWrite(slot Slot, before Inspection) {
+	dictionary.onWrite(Write{Before: before, After: dictionary.inspect(slot)})
+}
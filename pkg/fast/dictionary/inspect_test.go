@@ -0,0 +1,107 @@
+package dictionary
+
+import (
+	"testing"
+
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+func TestInspectListsEveryKeySortedWithItsStateAndValue(t *testing.T) {
+	// Arrange
+	dict := New()
+	assigned := Key{Dictionary: TemplateDictionary, Scope: "InspectTemplate", Name: "InspectAssignedField"}
+	empty := GlobalKey("InspectEmptyField")
+	undefined := GlobalKey("InspectUndefinedField")
+	dict.SetKeyValue(assigned, fix.NewRawValue(uint32(5)))
+	dict.SetKeyValue(empty, fix.NullValue{})
+
+	// Act
+	inspections := dict.Inspect([]Key{assigned, undefined, empty})
+
+	// Assert
+	expected := []string{"InspectEmptyField=null", "InspectUndefinedField=undefined", "template[InspectTemplate].InspectAssignedField=5"}
+	if len(inspections) != len(expected) {
+		t.Fatalf("Expected %d inspections, result: %v", len(expected), inspections)
+	}
+	for index, inspection := range inspections {
+		if inspection.String() != expected[index] {
+			t.Errorf("Expected inspection %d to be %s, result: %s", index, expected[index], inspection)
+		}
+	}
+	if inspections[2].State != Assigned || inspections[2].Value.Get() != uint32(5) {
+		t.Errorf("Expected the assigned entry to hold its value, result: %#v", inspections[2])
+	}
+}
+
+func TestInspectDoesNotChangeEntriesOfResetDictionary(t *testing.T) {
+	// Arrange
+	dict := New()
+	key := GlobalKey("InspectResetField")
+	dict.SetKeyValue(key, fix.NewRawValue("value"))
+	dict.Reset()
+
+	// Act
+	inspections := dict.Inspect([]Key{key})
+
+	// Assert
+	if inspections[0].State != Undefined {
+		t.Errorf("Expected the entry to be undefined once the dictionary is reset, result: %s", inspections[0])
+	}
+	if entry := &dict.entries[dict.SlotOf(key)]; entry.generation == dict.generation {
+		t.Errorf("Expected inspecting the entry not to move it to the generation of the dictionary")
+	}
+}
+
+func TestInspectDoesNotResolveKeysWithoutSlot(t *testing.T) {
+	// Arrange
+	slots := &Slots{}
+	slots.Resolve(GlobalKey("InspectStoreField"))
+	dict := NewOf(slots)
+	key := GlobalKey("InspectUnresolvedField")
+
+	// Act
+	inspections := dict.Inspect([]Key{key})
+
+	// Assert
+	if inspections[0].Key != key || inspections[0].State != Undefined {
+		t.Errorf("Expected a key without a slot to be undefined, result: %s", inspections[0])
+	}
+	if dict.own.Len() != 0 || len(dict.entries) != 1 {
+		t.Errorf("Expected inspecting a key without a slot not to resolve it, own slots: %d, entries: %d", dict.own.Len(), len(dict.entries))
+	}
+}
+
+func TestOnWriteRecordsEntryBeforeAndAfterEveryWrite(t *testing.T) {
+	// Arrange
+	dict := New()
+	key := GlobalKey("InspectWrittenField")
+	slot := dict.SlotOf(key)
+	var writes []Write
+	dict.OnWrite(func(write Write) {
+		writes = append(writes, write)
+	})
+	value := fix.TypedValue{}
+	value.SetUInt64(7)
+
+	// Act
+	dict.SetKeyValue(key, fix.NewRawValue("first"))
+	dict.Set(slot, &value)
+	dict.SetKeyValue(key, fix.NullValue{})
+	dict.OnWrite(nil)
+	dict.SetKeyValue(key, fix.NewRawValue("unrecorded"))
+
+	// Assert
+	expected := []string{
+		"InspectWrittenField=undefined -> InspectWrittenField=first",
+		"InspectWrittenField=first -> InspectWrittenField=7",
+		"InspectWrittenField=7 -> InspectWrittenField=null",
+	}
+	if len(writes) != len(expected) {
+		t.Fatalf("Expected %d writes to be recorded, result: %v", len(expected), writes)
+	}
+	for index, write := range writes {
+		if write.String() != expected[index] {
+			t.Errorf("Expected write %d to be %s, result: %s", index, expected[index], write)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package dictionary
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+// AppendSnapshot appends the entry of each key to the snapshot, in the order given, returning the extended snapshot. The number of keys is written first,
+// then each key followed by the state of its entry, and for an assigned entry the kind and value held by the entry. Restore reads the entries back.
+// Taking a snapshot does not change any entry, in the same way as Inspect.
+func (dictionary *Dictionary) AppendSnapshot(snapshot []byte, keys []Key) []byte {
+	snapshot = appendUvarint(snapshot, uint64(len(keys)))
+	for _, key := range keys {
+		for _, part := range []string{key.Dictionary, key.Scope, key.Namespace, key.Name} {
+			snapshot = appendBytes(snapshot, []byte(part))
+		}
+
+		inspection := dictionary.inspectKey(key)
+		snapshot = append(snapshot, byte(inspection.State))
+		if inspection.State != Assigned {
+			continue
+		}
+
+		value := &inspection.Value
+		snapshot = append(snapshot, byte(value.Kind))
+		switch value.Kind {
+		case fix.UInt32Kind, fix.UInt64Kind:
+			snapshot = appendUvarint(snapshot, value.Unsigned)
+		case fix.Int32Kind, fix.Int64Kind:
+			snapshot = appendVarint(snapshot, value.Signed)
+		case fix.StringKind, fix.ByteVectorKind:
+			snapshot = appendBytes(snapshot, value.Bytes)
+		case fix.DecimalKind:
+			snapshot = appendUint64(snapshot, math.Float64bits(value.Decimal))
+		}
+	}
+
+	return snapshot
+}
+
+// Restore the entries of the keys appended to the snapshot by AppendSnapshot, returning the rest of the snapshot after them. The dictionary is reset first,
+// so only the entries within the snapshot are defined once it is restored. If an error is returned, the dictionary has only been partly restored.
+func (dictionary *Dictionary) Restore(snapshot []byte) ([]byte, error) {
+	dictionary.Reset()
+	reader := snapshotReader{snapshot: snapshot}
+
+	count := reader.uvarint()
+	for index := uint64(0); index < count && reader.err == nil; index++ {
+		key := Key{Dictionary: string(reader.bytes()), Scope: string(reader.bytes()), Namespace: string(reader.bytes()), Name: string(reader.bytes())}
+		state := State(reader.byte())
+		if reader.err != nil {
+			break
+		}
+
+		entry := dictionary.Entry(dictionary.SlotOf(key))
+		switch state {
+		case Undefined:
+		case Empty:
+			entry.state = Empty
+			entry.value.SetNull()
+		case Assigned:
+			reader.value(&entry.value)
+			entry.state = Assigned
+		default:
+			reader.fail(fmt.Errorf("entry of key %s has unknown state %d", key, state))
+		}
+	}
+
+	if reader.err != nil {
+		return nil, fmt.Errorf("unable to restore dictionary from snapshot, reason: %s", reader.err)
+	}
+	return reader.snapshot, nil
+}
+
+func appendUvarint(snapshot []byte, value uint64) []byte {
+	var buffer [binary.MaxVarintLen64]byte
+	return append(snapshot, buffer[:binary.PutUvarint(buffer[:], value)]...)
+}
+
+func appendVarint(snapshot []byte, value int64) []byte {
+	var buffer [binary.MaxVarintLen64]byte
+	return append(snapshot, buffer[:binary.PutVarint(buffer[:], value)]...)
+}
+
+func appendUint64(snapshot []byte, value uint64) []byte {
+	var buffer [8]byte
+	binary.BigEndian.PutUint64(buffer[:], value)
+	return append(snapshot, buffer[:]...)
+}
+
+// appendBytes appends the length of the bytes followed by the bytes
+func appendBytes(snapshot []byte, value []byte) []byte {
+	snapshot = appendUvarint(snapshot, uint64(len(value)))
+	return append(snapshot, value...)
+}
+
+// snapshotReader reads the parts of a snapshot in turn, once a part fails to be read every later read returns the zero value and err is set
+type snapshotReader struct {
+	snapshot []byte
+	err      error
+}
+
+func (reader *snapshotReader) fail(err error) {
+	if reader.err == nil {
+		reader.err = err
+	}
+	reader.snapshot = nil
+}
+
+func (reader *snapshotReader) byte() byte {
+	if len(reader.snapshot) < 1 {
+		reader.fail(fmt.Errorf("snapshot ended before the end of an entry"))
+		return 0
+	}
+
+	value := reader.snapshot[0]
+	reader.snapshot = reader.snapshot[1:]
+	return value
+}
+
+func (reader *snapshotReader) uvarint() uint64 {
+	value, length := binary.Uvarint(reader.snapshot)
+	if length <= 0 {
+		reader.fail(fmt.Errorf("snapshot has an invalid or truncated unsigned integer"))
+		return 0
+	}
+
+	reader.snapshot = reader.snapshot[length:]
+	return value
+}
+
+func (reader *snapshotReader) varint() int64 {
+	value, length := binary.Varint(reader.snapshot)
+	if length <= 0 {
+		reader.fail(fmt.Errorf("snapshot has an invalid or truncated signed integer"))
+		return 0
+	}
+
+	reader.snapshot = reader.snapshot[length:]
+	return value
+}
+
+func (reader *snapshotReader) bytes() []byte {
+	length := reader.uvarint()
+	if length > uint64(len(reader.snapshot)) {
+		reader.fail(fmt.Errorf("snapshot ended before the end of a value %d bytes long", length))
+		return nil
+	}
+
+	value := reader.snapshot[:length]
+	reader.snapshot = reader.snapshot[length:]
+	return value
+}
+
+// value of the kind read first, into the typed value
+func (reader *snapshotReader) value(value *fix.TypedValue) {
+	switch kind := fix.Kind(reader.byte()); kind {
+	case fix.UInt32Kind:
+		value.SetUInt32(uint32(reader.uvarint()))
+	case fix.UInt64Kind:
+		value.SetUInt64(reader.uvarint())
+	case fix.Int32Kind:
+		value.SetInt32(int32(reader.varint()))
+	case fix.Int64Kind:
+		value.SetInt64(reader.varint())
+	case fix.StringKind:
+		value.SetString(reader.bytes())
+	case fix.ByteVectorKind:
+		value.SetByteVector(reader.bytes())
+	case fix.DecimalKind:
+		if len(reader.snapshot) < 8 {
+			reader.fail(fmt.Errorf("snapshot ended before the end of a decimal"))
+			return
+		}
+		value.SetDecimal(math.Float64frombits(binary.BigEndian.Uint64(reader.snapshot)))
+		reader.snapshot = reader.snapshot[8:]
+	default:
+		if reader.err == nil {
+			reader.fail(fmt.Errorf("snapshot has a value of unknown kind %d", kind))
+		}
+	}
+}
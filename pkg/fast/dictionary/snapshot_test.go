@@ -0,0 +1,102 @@
+package dictionary
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+// snapshotValues of every kind a dictionary holds, by key
+var snapshotValues = map[string]fix.Value{
+	"SnapshotUInt32Field":     fix.NewRawValue(uint32(1)),
+	"SnapshotUInt64Field":     fix.NewRawValue(uint64(1 << 40)),
+	"SnapshotInt32Field":      fix.NewRawValue(int32(-2)),
+	"SnapshotInt64Field":      fix.NewRawValue(int64(-1 << 40)),
+	"SnapshotStringField":     fix.NewRawValue("value"),
+	"SnapshotByteVectorField": fix.NewRawValue([]byte{1, 2, 3}),
+	"SnapshotDecimalField":    fix.NewRawValue(12.5),
+	"SnapshotEmptyField":      fix.NullValue{},
+}
+
+func TestRestoreSnapshotRestoresEveryStateAndKindOfValue(t *testing.T) {
+	// Arrange
+	dict := New()
+	keys := []Key{GlobalKey("SnapshotUndefinedField"), {Dictionary: TemplateDictionary, Scope: "Template", Namespace: "urn:snapshot", Name: "SnapshotUndefinedField"}}
+	for name, value := range snapshotValues {
+		dict.SetValue(name, value)
+		keys = append(keys, GlobalKey(name))
+	}
+	snapshot := dict.AppendSnapshot([]byte{}, keys)
+
+	// Act
+	restored := New()
+	restored.SetValue("SnapshotUndefinedField", fix.NewRawValue(uint32(10)))
+	rest, err := restored.Restore(snapshot)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Got an error restoring the snapshot when none was expected: %s", err)
+	}
+	if len(rest) != 0 {
+		t.Errorf("Expected the whole snapshot to be read, %d bytes were left", len(rest))
+	}
+	for name := range snapshotValues {
+		if expected, result := dict.GetValue(name), restored.GetValue(name); !reflect.DeepEqual(expected, result) {
+			t.Errorf("The value of %s was not restored, expected: %#v, result: %#v", name, expected, result)
+		}
+	}
+	if result := restored.GetValue("SnapshotUndefinedField"); result != (UndefinedValue{}) {
+		t.Errorf("Expected the undefined entry to be restored as undefined, result: %#v", result)
+	}
+}
+
+func TestAppendSnapshotDoesNotChangeEntries(t *testing.T) {
+	// Arrange
+	slots := &Slots{}
+	stale := GlobalKey("SnapshotStaleField")
+	slots.Resolve(stale)
+	dict := NewOf(slots)
+	dict.SetKeyValue(stale, fix.NewRawValue(uint32(5)))
+	dict.Reset()
+
+	// Act
+	snapshot := dict.AppendSnapshot([]byte{}, []Key{stale, GlobalKey("SnapshotUnresolvedField")})
+
+	// Assert
+	restored := New()
+	if _, err := restored.Restore(snapshot); err != nil {
+		t.Fatalf("Got an error restoring the snapshot when none was expected: %s", err)
+	}
+	if result := restored.GetKeyValue(stale); result != (UndefinedValue{}) {
+		t.Errorf("Expected the entry of the reset dictionary to be snapshot as undefined, result: %#v", result)
+	}
+	if dict.own.Len() != 0 || len(dict.entries) != 1 {
+		t.Errorf("Expected taking a snapshot not to resolve a key without a slot, own slots: %d, entries: %d", dict.own.Len(), len(dict.entries))
+	}
+	if entry := &dict.entries[0]; entry.generation == dict.generation {
+		t.Errorf("Expected taking a snapshot not to move the entry to the generation of the dictionary")
+	}
+}
+
+func TestRestoreTruncatedSnapshotReturnsError(t *testing.T) {
+	// Arrange
+	dict := New()
+	keys := make([]Key, 0, len(snapshotValues))
+	for name, value := range snapshotValues {
+		dict.SetValue(name, value)
+		keys = append(keys, GlobalKey(name))
+	}
+	snapshot := dict.AppendSnapshot([]byte{}, keys)
+
+	for length := 0; length < len(snapshot); length++ {
+		// Act
+		restored := New()
+		_, err := restored.Restore(snapshot[:length])
+
+		// Assert
+		if err == nil {
+			t.Errorf("Expected an error restoring a snapshot truncated to %d of %d bytes", length, len(snapshot))
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package encoder
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// WriteUInt32 writes the uint32 to the outputSource as a FAST stop bit encoded value.
+// i.e. 100100001000 would become 00010010 10001000
+func WriteUInt32(outputSource *bytes.Buffer, toWrite uint32) {
+	WriteUInt64(outputSource, uint64(toWrite))
+}
+
+// WriteOptionalUInt32 writes the uint32 to the outputSource. As 0 is used to represent nil for optionals, the value written is: value + 1.
+// i.e. 0 would become 10000001
+func WriteOptionalUInt32(outputSource *bytes.Buffer, toWrite uint32) {
+	WriteUInt64(outputSource, uint64(toWrite)+1)
+}
+
+// WriteInt32 writes the int32 to the outputSource as a FAST stop bit encoded value (2's compliment encoded).
+// i.e. -50 would become 11001110 -> 01001110 -> 11001110
+func WriteInt32(outputSource *bytes.Buffer, toWrite int32) {
+	WriteInt64(outputSource, int64(toWrite))
+}
+
+// WriteOptionalInt32 writes the int32 to the outputSource. As 0 is used to represent nil for optionals, the value written is: value + 1 for positive numbers only.
+// i.e. 0 would become 10000001, -1 would become 11111111
+func WriteOptionalInt32(outputSource *bytes.Buffer, toWrite int32) {
+	if toWrite >= 0 {
+		WriteInt64(outputSource, int64(toWrite)+1)
+		return
+	}
+
+	WriteInt64(outputSource, int64(toWrite))
+}
+
+// WriteUInt64 writes the uint64 to the outputSource as a FAST stop bit encoded value.
+// i.e. 100100001000 would become 00010010 10001000
+func WriteUInt64(outputSource *bytes.Buffer, toWrite uint64) {
+	var encoded [10]byte
+	index := len(encoded) - 1
+
+	for {
+		// take the least significant 7 bits, these become the last byte we have not yet populated
+		encoded[index] = byte(toWrite & 127)
+		toWrite = toWrite >> 7
+		if toWrite == 0 {
+			break
+		}
+		index--
+	}
+
+	// 128 = 10000000, mark the final byte with the stop bit
+	encoded[len(encoded)-1] |= 128
+	outputSource.Write(encoded[index:])
+}
+
+// WriteOptionalUInt64 writes the uint64 to the outputSource. As 0 is used to represent nil for optionals, the value written is: value + 1.
+// If the value is the maximum uint64, the value written overflows a uint64 by a single byte.
+func WriteOptionalUInt64(outputSource *bytes.Buffer, toWrite uint64) {
+	if toWrite == math.MaxUint64 {
+		overflowValue := big.NewInt(0).SetUint64(toWrite)
+		WriteBigInt(outputSource, overflowValue.Add(overflowValue, big.NewInt(1)))
+		return
+	}
+
+	WriteUInt64(outputSource, toWrite+1)
+}
+
+// WriteInt64 writes the int64 to the outputSource as a FAST stop bit encoded value (2's compliment encoded).
+// The minimum amount of bytes are written, such that the sign bit (second most significant bit of the first byte) represents the sign of the value.
+// i.e. -50 would become 11001110 -> 01001110 -> 11001110
+func WriteInt64(outputSource *bytes.Buffer, toWrite int64) {
+	var encoded [10]byte
+	index := len(encoded) - 1
+
+	for {
+		// take the least significant 7 bits, these become the last byte we have not yet populated
+		encoded[index] = byte(toWrite & 127)
+		toWrite = toWrite >> 7
+
+		// 64 = 01000000, we can stop once the remaining bits are all sign bits, and the sign bit of this byte matches the sign
+		if toWrite == 0 && encoded[index]&64 == 0 {
+			break
+		}
+		if toWrite == -1 && encoded[index]&64 == 64 {
+			break
+		}
+		index--
+	}
+
+	// 128 = 10000000, mark the final byte with the stop bit
+	encoded[len(encoded)-1] |= 128
+	outputSource.Write(encoded[index:])
+}
+
+// WriteOptionalInt64 writes the int64 to the outputSource. As 0 is used to represent nil for optionals, the value written is: value + 1 for positive numbers only.
+// If the value is the maximum int64, the value written overflows an int64 by a single byte.
+func WriteOptionalInt64(outputSource *bytes.Buffer, toWrite int64) {
+	if toWrite == math.MaxInt64 {
+		overflowValue := big.NewInt(toWrite)
+		WriteBigInt(outputSource, overflowValue.Add(overflowValue, big.NewInt(1)))
+		return
+	}
+
+	if toWrite >= 0 {
+		WriteInt64(outputSource, toWrite+1)
+		return
+	}
+
+	WriteInt64(outputSource, toWrite)
+}
+
+// WriteBigInt writes the big.Int to the outputSource as a FAST stop bit encoded value (2's compliment encoded). This is used for values that may overflow
+// an int64 or uint64 by a single byte (for delta encoding). If the value would not fit in the allowed overflow an err is returned.
+func WriteBigInt(outputSource *bytes.Buffer, toWrite *big.Int) error {
+	var encoded [11]byte
+	index := len(encoded) - 1
+	remaining := big.NewInt(0).Set(toWrite)
+	leastSignificantBits := big.NewInt(127)
+	minusOne := big.NewInt(-1)
+
+	for {
+		if index < 1 {
+			return fmt.Errorf("value %s does not fit within the overflow allowed of an int64 or uint64", toWrite.String())
+		}
+
+		// take the least significant 7 bits, these become the last byte we have not yet populated
+		encoded[index] = byte(big.NewInt(0).And(remaining, leastSignificantBits).Int64())
+		remaining = remaining.Rsh(remaining, 7)
+
+		// 64 = 01000000, we can stop once the remaining bits are all sign bits, and the sign bit of this byte matches the sign
+		if remaining.Sign() == 0 && encoded[index]&64 == 0 {
+			break
+		}
+		if remaining.Cmp(minusOne) == 0 && encoded[index]&64 == 64 {
+			break
+		}
+		index--
+	}
+
+	// 128 = 10000000, mark the final byte with the stop bit
+	encoded[len(encoded)-1] |= 128
+	outputSource.Write(encoded[index:])
+	return nil
+}
+
+// WriteOptionalBigInt writes the big.Int to the outputSource. As 0 is used to represent nil for optionals, the value written is: value + 1 for positive numbers only.
+func WriteOptionalBigInt(outputSource *bytes.Buffer, toWrite *big.Int) error {
+	if toWrite.Sign() >= 0 {
+		return WriteBigInt(outputSource, big.NewInt(0).Add(toWrite, big.NewInt(1)))
+	}
+
+	return WriteBigInt(outputSource, toWrite)
+}
+
+// WriteNull writes the FAST null value (10000000) to the outputSource, this is used by all optional types to represent nil
+func WriteNull(outputSource *bytes.Buffer) {
+	outputSource.WriteByte(128)
+}
+
+// WriteString writes an ASCII encoded string to the outputSource, with the stop bit set on the final character. If the string contains a non ASCII
+// character an err is returned.
+// i.e. "" would become 10000000, "AB" would become 01000001 11000010
+func WriteString(outputSource *bytes.Buffer, toWrite string) error {
+	if len(toWrite) == 0 {
+		outputSource.WriteByte(128)
+		return nil
+	}
+
+	for i := 0; i < len(toWrite); i++ {
+		if toWrite[i] > 127 {
+			return fmt.Errorf("unable to write string %s as ascii, character at position %d is not ascii", toWrite, i)
+		}
+	}
+
+	outputSource.WriteString(toWrite[:len(toWrite)-1])
+	outputSource.WriteByte(toWrite[len(toWrite)-1] | 128)
+	return nil
+}
+
+// WriteOptionalString writes an ASCII encoded string to the outputSource. An empty string is written as 00000000 10000000 in order to distinguish it
+// from nil (10000000).
+func WriteOptionalString(outputSource *bytes.Buffer, toWrite string) error {
+	if len(toWrite) == 0 {
+		outputSource.Write([]byte{0, 128})
+		return nil
+	}
+
+	return WriteString(outputSource, toWrite)
+}
+
+// WriteByteVector writes a uint32 length to the outputSource, followed by the byte vector itself. The vector is not stop bit encoded.
+// i.e. [1, 2] would become 10000010 00000001 00000010
+func WriteByteVector(outputSource *bytes.Buffer, toWrite []byte) {
+	WriteUInt32(outputSource, uint32(len(toWrite)))
+	outputSource.Write(toWrite)
+}
+
+// WriteOptionalByteVector writes the length of the byte vector as an optional uint32, followed by the byte vector itself.
+// i.e. [1] would become 10000010 00000001
+func WriteOptionalByteVector(outputSource *bytes.Buffer, toWrite []byte) {
+	WriteOptionalUInt32(outputSource, uint32(len(toWrite)))
+	outputSource.Write(toWrite)
+}
@@ -0,0 +1,316 @@
+package encoder
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
+	"github.com/Guardian-Development/fastengine/pkg/fast/value"
+)
+
+func TestCanWriteSingleByteUint32(t *testing.T) {
+	// Arrange 10 = (10001010)
+	outputSource := bytes.Buffer{}
+	expectedBytes := []byte{138}
+
+	// Act
+	WriteUInt32(&outputSource, 10)
+
+	// Assert
+	if !reflect.DeepEqual(expectedBytes, outputSource.Bytes()) {
+		t.Errorf("Did not write the expected uint32, expected: %#v, result: %#v", expectedBytes, outputSource.Bytes())
+	}
+}
+
+func TestCanWriteMultipleByteUint32(t *testing.T) {
+	// Arrange 101455882 = (00110000 00110000 00110000 10001010)
+	outputSource := bytes.Buffer{}
+	expectedBytes := []byte{48, 48, 48, 138}
+
+	// Act
+	WriteUInt32(&outputSource, 101455882)
+
+	// Assert
+	if !reflect.DeepEqual(expectedBytes, outputSource.Bytes()) {
+		t.Errorf("Did not write the expected uint32, expected: %#v, result: %#v", expectedBytes, outputSource.Bytes())
+	}
+}
+
+func TestCanWriteOptionalUint32AsValuePlusOne(t *testing.T) {
+	// Arrange 0 = (10000001)
+	outputSource := bytes.Buffer{}
+	expectedBytes := []byte{129}
+
+	// Act
+	WriteOptionalUInt32(&outputSource, 0)
+
+	// Assert
+	if !reflect.DeepEqual(expectedBytes, outputSource.Bytes()) {
+		t.Errorf("Did not write the expected optional uint32, expected: %#v, result: %#v", expectedBytes, outputSource.Bytes())
+	}
+}
+
+func TestCanWriteNegativeInt32(t *testing.T) {
+	// Arrange -50 = (11001110)
+	outputSource := bytes.Buffer{}
+	expectedBytes := []byte{206}
+
+	// Act
+	WriteInt32(&outputSource, -50)
+
+	// Assert
+	if !reflect.DeepEqual(expectedBytes, outputSource.Bytes()) {
+		t.Errorf("Did not write the expected int32, expected: %#v, result: %#v", expectedBytes, outputSource.Bytes())
+	}
+}
+
+func TestCanWritePositiveInt32RequiringSignByte(t *testing.T) {
+	// Arrange 64 = (00000000 11000000), the sign bit of the first byte must not be set for a positive number
+	outputSource := bytes.Buffer{}
+	expectedBytes := []byte{0, 192}
+
+	// Act
+	WriteInt32(&outputSource, 64)
+
+	// Assert
+	if !reflect.DeepEqual(expectedBytes, outputSource.Bytes()) {
+		t.Errorf("Did not write the expected int32, expected: %#v, result: %#v", expectedBytes, outputSource.Bytes())
+	}
+}
+
+func TestCanWriteMinimumInt64(t *testing.T) {
+	// Arrange -9223372036854775808 = (01111111 00000000 00000000 00000000 00000000 00000000 00000000 00000000 00000000 10000000)
+	outputSource := bytes.Buffer{}
+	expectedBytes := []byte{127, 0, 0, 0, 0, 0, 0, 0, 0, 128}
+
+	// Act
+	WriteInt64(&outputSource, math.MinInt64)
+
+	// Assert
+	if !reflect.DeepEqual(expectedBytes, outputSource.Bytes()) {
+		t.Errorf("Did not write the expected int64, expected: %#v, result: %#v", expectedBytes, outputSource.Bytes())
+	}
+}
+
+func TestCanWriteMaximumOptionalInt64WithOverflow(t *testing.T) {
+	// Arrange 9223372036854775807 = (00000001 00000000 00000000 00000000 00000000 00000000 00000000 00000000 00000000 10000000)
+	outputSource := bytes.Buffer{}
+	expectedBytes := []byte{1, 0, 0, 0, 0, 0, 0, 0, 0, 128}
+
+	// Act
+	WriteOptionalInt64(&outputSource, math.MaxInt64)
+
+	// Assert
+	if !reflect.DeepEqual(expectedBytes, outputSource.Bytes()) {
+		t.Errorf("Did not write the expected optional int64, expected: %#v, result: %#v", expectedBytes, outputSource.Bytes())
+	}
+}
+
+func TestCanWriteBigIntWithAllowedOverflowMaxOverflowValue(t *testing.T) {
+	// Arrange 18446744073709551615 = 00000001 01111111 01111111 01111111 01111111 01111111 01111111 01111111 01111111 11111111
+	outputSource := bytes.Buffer{}
+	toWrite, _ := big.NewInt(0).SetString("18446744073709551615", 10)
+	expectedBytes := []byte{1, 127, 127, 127, 127, 127, 127, 127, 127, 255}
+
+	// Act
+	err := WriteBigInt(&outputSource, toWrite)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error writing big int when none was expected: %s", err)
+	}
+	if !reflect.DeepEqual(expectedBytes, outputSource.Bytes()) {
+		t.Errorf("Did not write the expected big int, expected: %#v, result: %#v", expectedBytes, outputSource.Bytes())
+	}
+}
+
+func TestWriteBigIntReturnsErrorIfValueOverflowsAllowedOverflow(t *testing.T) {
+	// Arrange 2^70 cannot be represented in 10 bytes
+	outputSource := bytes.Buffer{}
+	toWrite := big.NewInt(0).Lsh(big.NewInt(1), 70)
+
+	// Act
+	err := WriteBigInt(&outputSource, toWrite)
+
+	// Assert
+	if err == nil {
+		t.Errorf("Expected error writing big int that does not fit in allowed overflow, but got none")
+	}
+}
+
+func TestCanWriteString(t *testing.T) {
+	// Arrange TEST1 = 01010100 01000101 01010011 01010100 10110001
+	outputSource := bytes.Buffer{}
+	expectedBytes := []byte{84, 69, 83, 84, 177}
+
+	// Act
+	err := WriteString(&outputSource, "TEST1")
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error writing string when none was expected: %s", err)
+	}
+	if !reflect.DeepEqual(expectedBytes, outputSource.Bytes()) {
+		t.Errorf("Did not write the expected string, expected: %#v, result: %#v", expectedBytes, outputSource.Bytes())
+	}
+}
+
+func TestWriteStringReturnsErrorForNonAsciiString(t *testing.T) {
+	// Arrange
+	outputSource := bytes.Buffer{}
+
+	// Act
+	err := WriteString(&outputSource, "Hello: ϔ")
+
+	// Assert
+	if err == nil {
+		t.Errorf("Expected error writing non ascii string, but got none")
+	}
+}
+
+func TestCanWriteOptionalEmptyString(t *testing.T) {
+	// Arrange "" = 00000000 10000000
+	outputSource := bytes.Buffer{}
+	expectedBytes := []byte{0, 128}
+
+	// Act
+	err := WriteOptionalString(&outputSource, "")
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error writing optional string when none was expected: %s", err)
+	}
+	if !reflect.DeepEqual(expectedBytes, outputSource.Bytes()) {
+		t.Errorf("Did not write the expected optional string, expected: %#v, result: %#v", expectedBytes, outputSource.Bytes())
+	}
+}
+
+func TestCanWriteByteVector(t *testing.T) {
+	// Arrange [1, 2] = 10000010 00000001 00000010
+	outputSource := bytes.Buffer{}
+	expectedBytes := []byte{130, 1, 2}
+
+	// Act
+	WriteByteVector(&outputSource, []byte{1, 2})
+
+	// Assert
+	if !reflect.DeepEqual(expectedBytes, outputSource.Bytes()) {
+		t.Errorf("Did not write the expected byte vector, expected: %#v, result: %#v", expectedBytes, outputSource.Bytes())
+	}
+}
+
+func TestWrittenIntegersCanBeReadByDecoder(t *testing.T) {
+	cases := []struct {
+		encode     Encoder
+		decode     decoder.Decoder
+		toWrite    value.Value
+		isOptional bool
+	}{
+		{UInt32Encoder{}, decoder.UInt32Decoder{}, value.UInt32Value{Value: math.MaxUint32}, false},
+		{UInt32Encoder{}, decoder.UInt32Decoder{}, value.UInt32Value{Value: math.MaxUint32}, true},
+		{UInt32Encoder{}, decoder.UInt32Decoder{}, value.NullValue{}, true},
+		{Int32Encoder{}, decoder.Int32Decoder{}, value.Int32Value{Value: math.MinInt32}, false},
+		{Int32Encoder{}, decoder.Int32Decoder{}, value.Int32Value{Value: math.MaxInt32}, true},
+		{Int32Encoder{}, decoder.Int32Decoder{}, value.Int32Value{Value: -1}, true},
+		{UInt64Encoder{}, decoder.UInt64Decoder{}, value.UInt64Value{Value: math.MaxUint64}, false},
+		{UInt64Encoder{}, decoder.UInt64Decoder{}, value.UInt64Value{Value: math.MaxUint64}, true},
+		{Int64Encoder{}, decoder.Int64Decoder{}, value.Int64Value{Value: math.MaxInt64}, false},
+		{Int64Encoder{}, decoder.Int64Decoder{}, value.Int64Value{Value: math.MinInt64}, true},
+		{Int64Encoder{}, decoder.Int64Decoder{}, value.NullValue{}, true},
+	}
+
+	for _, testCase := range cases {
+		// Arrange
+		outputSource := bytes.Buffer{}
+		var err error
+
+		// Act
+		if testCase.isOptional {
+			err = testCase.encode.WriteOptionalValue(&outputSource, testCase.toWrite)
+		} else {
+			err = testCase.encode.WriteValue(&outputSource, testCase.toWrite)
+		}
+		if err != nil {
+			t.Errorf("Got an error writing %#v when none was expected: %s", testCase.toWrite, err)
+		}
+
+		var result value.Value
+		if testCase.isOptional {
+			result, err = testCase.decode.ReadOptionalValue(&outputSource)
+		} else {
+			result, err = testCase.decode.ReadValue(&outputSource)
+		}
+
+		// Assert
+		if err != nil {
+			t.Errorf("Got an error reading %#v when none was expected: %s", testCase.toWrite, err)
+		}
+		if !reflect.DeepEqual(testCase.toWrite, result) {
+			t.Errorf("Did not read the written value, expected: %#v, result: %#v", testCase.toWrite, result)
+		}
+	}
+}
+
+func TestWrittenDeltasCanBeReadByDecoder(t *testing.T) {
+	cases := []struct {
+		encode     Encoder
+		decode     decoder.Decoder
+		toWrite    value.Value
+		isOptional bool
+	}{
+		{AsciiStringDeltaEncoder{}, decoder.AsciiStringDeltaDecoder{}, value.StringValue{Value: "ABC", ItemsToRemove: 2}, false},
+		{AsciiStringDeltaEncoder{}, decoder.AsciiStringDeltaDecoder{}, value.StringValue{Value: "ABC", ItemsToRemove: -3}, true},
+		{AsciiStringDeltaEncoder{}, decoder.AsciiStringDeltaDecoder{}, value.NullValue{}, true},
+		{ByteVectorDeltaEncoder{}, decoder.ByteVectorDeltaDecoder{}, value.ByteVector{Value: []byte{1, 2}, ItemsToRemove: 0}, false},
+		{ByteVectorDeltaEncoder{}, decoder.ByteVectorDeltaDecoder{}, value.ByteVector{Value: []byte{1, 2}, ItemsToRemove: -1}, true},
+		{BigIntEncoder{}, decoder.BitIntDecoder{}, value.BigInt{Value: big.NewInt(-10)}, false},
+		{BigIntEncoder{}, decoder.BitIntDecoder{}, value.BigInt{Value: big.NewInt(10)}, true},
+	}
+
+	for _, testCase := range cases {
+		// Arrange
+		outputSource := bytes.Buffer{}
+		var err error
+
+		// Act
+		if testCase.isOptional {
+			err = testCase.encode.WriteOptionalValue(&outputSource, testCase.toWrite)
+		} else {
+			err = testCase.encode.WriteValue(&outputSource, testCase.toWrite)
+		}
+		if err != nil {
+			t.Errorf("Got an error writing %#v when none was expected: %s", testCase.toWrite, err)
+		}
+
+		var result value.Value
+		if testCase.isOptional {
+			result, err = testCase.decode.ReadOptionalValue(&outputSource)
+		} else {
+			result, err = testCase.decode.ReadValue(&outputSource)
+		}
+
+		// Assert
+		if err != nil {
+			t.Errorf("Got an error reading %#v when none was expected: %s", testCase.toWrite, err)
+		}
+		if !reflect.DeepEqual(testCase.toWrite, result) {
+			t.Errorf("Did not read the written value, expected: %#v, result: %#v", testCase.toWrite, result)
+		}
+	}
+}
+
+func TestWriteValueReturnsErrorForMismatchedType(t *testing.T) {
+	// Arrange
+	outputSource := bytes.Buffer{}
+
+	// Act
+	err := UInt32Encoder{}.WriteValue(&outputSource, value.StringValue{Value: "1"})
+
+	// Assert
+	if err == nil {
+		t.Errorf("Expected error writing a string as a uint32, but got none")
+	}
+}
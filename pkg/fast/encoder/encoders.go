@@ -0,0 +1,273 @@
+package encoder
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/value"
+)
+
+// Encoder is used to couple the writing of required and optional values of the same type
+type Encoder interface {
+	WriteValue(outputSource *bytes.Buffer, toWrite value.Value) error
+	WriteOptionalValue(outputSource *bytes.Buffer, toWrite value.Value) error
+}
+
+// Int32Encoder performs a write/optional write of a FAST encoded int32
+type Int32Encoder struct {
+}
+
+// WriteValue fast encoded int32
+func (Int32Encoder) WriteValue(outputSource *bytes.Buffer, toWrite value.Value) error {
+	switch t := toWrite.(type) {
+	case value.Int32Value:
+		WriteInt32(outputSource, t.Value)
+		return nil
+	}
+
+	return fmt.Errorf("unable to write %#v as an int32", toWrite)
+}
+
+// WriteOptionalValue fast encoded optional int32
+func (Int32Encoder) WriteOptionalValue(outputSource *bytes.Buffer, toWrite value.Value) error {
+	switch t := toWrite.(type) {
+	case value.NullValue:
+		WriteNull(outputSource)
+		return nil
+	case value.Int32Value:
+		WriteOptionalInt32(outputSource, t.Value)
+		return nil
+	}
+
+	return fmt.Errorf("unable to write %#v as an optional int32", toWrite)
+}
+
+// UInt32Encoder performs a write/optional write of a FAST encoded uint32
+type UInt32Encoder struct {
+}
+
+// WriteValue fast encoded uint32
+func (UInt32Encoder) WriteValue(outputSource *bytes.Buffer, toWrite value.Value) error {
+	switch t := toWrite.(type) {
+	case value.UInt32Value:
+		WriteUInt32(outputSource, t.Value)
+		return nil
+	}
+
+	return fmt.Errorf("unable to write %#v as a uint32", toWrite)
+}
+
+// WriteOptionalValue fast encoded optional uint32
+func (UInt32Encoder) WriteOptionalValue(outputSource *bytes.Buffer, toWrite value.Value) error {
+	switch t := toWrite.(type) {
+	case value.NullValue:
+		WriteNull(outputSource)
+		return nil
+	case value.UInt32Value:
+		WriteOptionalUInt32(outputSource, t.Value)
+		return nil
+	}
+
+	return fmt.Errorf("unable to write %#v as an optional uint32", toWrite)
+}
+
+// Int64Encoder performs a write/optional write of a FAST encoded int64
+type Int64Encoder struct {
+}
+
+// WriteValue fast encoded int64
+func (Int64Encoder) WriteValue(outputSource *bytes.Buffer, toWrite value.Value) error {
+	switch t := toWrite.(type) {
+	case value.Int64Value:
+		WriteInt64(outputSource, t.Value)
+		return nil
+	}
+
+	return fmt.Errorf("unable to write %#v as an int64", toWrite)
+}
+
+// WriteOptionalValue fast encoded optional int64
+func (Int64Encoder) WriteOptionalValue(outputSource *bytes.Buffer, toWrite value.Value) error {
+	switch t := toWrite.(type) {
+	case value.NullValue:
+		WriteNull(outputSource)
+		return nil
+	case value.Int64Value:
+		WriteOptionalInt64(outputSource, t.Value)
+		return nil
+	}
+
+	return fmt.Errorf("unable to write %#v as an optional int64", toWrite)
+}
+
+// UInt64Encoder performs a write/optional write of a FAST encoded uint64
+type UInt64Encoder struct {
+}
+
+// WriteValue fast encoded uint64
+func (UInt64Encoder) WriteValue(outputSource *bytes.Buffer, toWrite value.Value) error {
+	switch t := toWrite.(type) {
+	case value.UInt64Value:
+		WriteUInt64(outputSource, t.Value)
+		return nil
+	}
+
+	return fmt.Errorf("unable to write %#v as a uint64", toWrite)
+}
+
+// WriteOptionalValue fast encoded optional uint64
+func (UInt64Encoder) WriteOptionalValue(outputSource *bytes.Buffer, toWrite value.Value) error {
+	switch t := toWrite.(type) {
+	case value.NullValue:
+		WriteNull(outputSource)
+		return nil
+	case value.UInt64Value:
+		WriteOptionalUInt64(outputSource, t.Value)
+		return nil
+	}
+
+	return fmt.Errorf("unable to write %#v as an optional uint64", toWrite)
+}
+
+// BigIntEncoder performs a write/optional write of a FAST encoded int64 with allowed overflow of a single byte
+type BigIntEncoder struct {
+}
+
+// WriteValue fast encoded int64 with allowed overflow
+func (BigIntEncoder) WriteValue(outputSource *bytes.Buffer, toWrite value.Value) error {
+	switch t := toWrite.(type) {
+	case value.BigInt:
+		return WriteBigInt(outputSource, t.Value)
+	}
+
+	return fmt.Errorf("unable to write %#v as an int64 with allowed overflow", toWrite)
+}
+
+// WriteOptionalValue fast encoded optional int64 with allowed overflow
+func (BigIntEncoder) WriteOptionalValue(outputSource *bytes.Buffer, toWrite value.Value) error {
+	switch t := toWrite.(type) {
+	case value.NullValue:
+		WriteNull(outputSource)
+		return nil
+	case value.BigInt:
+		return WriteOptionalBigInt(outputSource, t.Value)
+	}
+
+	return fmt.Errorf("unable to write %#v as an optional int64 with allowed overflow", toWrite)
+}
+
+// AsciiStringEncoder performs a write/optional write of a FAST encoded string
+type AsciiStringEncoder struct {
+}
+
+// WriteValue fast encoded string
+func (AsciiStringEncoder) WriteValue(outputSource *bytes.Buffer, toWrite value.Value) error {
+	switch t := toWrite.(type) {
+	case value.StringValue:
+		return WriteString(outputSource, t.Value)
+	}
+
+	return fmt.Errorf("unable to write %#v as a string", toWrite)
+}
+
+// WriteOptionalValue fast encoded optional string
+func (AsciiStringEncoder) WriteOptionalValue(outputSource *bytes.Buffer, toWrite value.Value) error {
+	switch t := toWrite.(type) {
+	case value.NullValue:
+		WriteNull(outputSource)
+		return nil
+	case value.StringValue:
+		return WriteOptionalString(outputSource, t.Value)
+	}
+
+	return fmt.Errorf("unable to write %#v as an optional string", toWrite)
+}
+
+// AsciiStringDeltaEncoder performs a write/optional write of a FAST encoded string delta
+type AsciiStringDeltaEncoder struct {
+}
+
+// WriteValue fast encoded string delta
+func (AsciiStringDeltaEncoder) WriteValue(outputSource *bytes.Buffer, toWrite value.Value) error {
+	switch t := toWrite.(type) {
+	case value.StringValue:
+		WriteInt32(outputSource, t.ItemsToRemove)
+		return WriteString(outputSource, t.Value)
+	}
+
+	return fmt.Errorf("unable to write %#v as a string delta", toWrite)
+}
+
+// WriteOptionalValue fast encoded string delta, if no delta is present the subtraction length is written as null
+func (AsciiStringDeltaEncoder) WriteOptionalValue(outputSource *bytes.Buffer, toWrite value.Value) error {
+	switch t := toWrite.(type) {
+	case value.NullValue:
+		WriteNull(outputSource)
+		return nil
+	case value.StringValue:
+		WriteOptionalInt32(outputSource, t.ItemsToRemove)
+		return WriteString(outputSource, t.Value)
+	}
+
+	return fmt.Errorf("unable to write %#v as an optional string delta", toWrite)
+}
+
+// ByteVectorEncoder performs a write/optional write of a FAST encoded byte vector
+type ByteVectorEncoder struct {
+}
+
+// WriteValue fast encoded byte vector
+func (ByteVectorEncoder) WriteValue(outputSource *bytes.Buffer, toWrite value.Value) error {
+	switch t := toWrite.(type) {
+	case value.ByteVector:
+		WriteByteVector(outputSource, t.Value)
+		return nil
+	}
+
+	return fmt.Errorf("unable to write %#v as a byte vector", toWrite)
+}
+
+// WriteOptionalValue fast encoded optional byte vector
+func (ByteVectorEncoder) WriteOptionalValue(outputSource *bytes.Buffer, toWrite value.Value) error {
+	switch t := toWrite.(type) {
+	case value.NullValue:
+		WriteNull(outputSource)
+		return nil
+	case value.ByteVector:
+		WriteOptionalByteVector(outputSource, t.Value)
+		return nil
+	}
+
+	return fmt.Errorf("unable to write %#v as an optional byte vector", toWrite)
+}
+
+// ByteVectorDeltaEncoder performs a write/optional write of a FAST encoded byte vector delta
+type ByteVectorDeltaEncoder struct {
+}
+
+// WriteValue fast encoded byte vector delta
+func (ByteVectorDeltaEncoder) WriteValue(outputSource *bytes.Buffer, toWrite value.Value) error {
+	switch t := toWrite.(type) {
+	case value.ByteVector:
+		WriteInt32(outputSource, t.ItemsToRemove)
+		WriteByteVector(outputSource, t.Value)
+		return nil
+	}
+
+	return fmt.Errorf("unable to write %#v as a byte vector delta", toWrite)
+}
+
+// WriteOptionalValue fast encoded byte vector delta, if no delta is present the subtraction length is written as null
+func (ByteVectorDeltaEncoder) WriteOptionalValue(outputSource *bytes.Buffer, toWrite value.Value) error {
+	switch t := toWrite.(type) {
+	case value.NullValue:
+		WriteNull(outputSource)
+		return nil
+	case value.ByteVector:
+		WriteOptionalInt32(outputSource, t.ItemsToRemove)
+		WriteByteVector(outputSource, t.Value)
+		return nil
+	}
+
+	return fmt.Errorf("unable to write %#v as an optional byte vector delta", toWrite)
+}
@@ -13,3 +13,5 @@ const D9 = "[ERR D9] decoder cannot find a template associated with a template i
 const R1 = "[ERR R1] decimal must be represented by an exponent in the range [-63 ... 63] and the mantissa must fit in an int64"
 const R4 = "[ERR R4] value of an integer type cannot be represented in the target integer type in a conversion"
 const R6 = "[ERR R6] read integer does not fit into target type (overlong encoding)"
+const R7 = "[ERR R7] presence map is overlong, the last byte of the presence map has no bits set"
+const R8 = "[ERR R8] presence map is too long, it has bits set beyond the bits read by its template or repeating group"
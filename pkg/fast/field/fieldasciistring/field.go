@@ -6,6 +6,7 @@ import (
 
 	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
 	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
+	"github.com/Guardian-Development/fastengine/pkg/fast/encoder"
 	"github.com/Guardian-Development/fastengine/pkg/fast/field/properties"
 	"github.com/Guardian-Development/fastengine/pkg/fast/operation"
 	"github.com/Guardian-Development/fastengine/pkg/fast/presencemap"
@@ -20,11 +21,12 @@ type FieldAsciiString struct {
 	Operation    operation.Operation
 
 	decode decoder.Decoder
+	encode encoder.Encoder
 }
 
 // Deserialise a <string/> from the input source
-func (field FieldAsciiString) Deserialise(inputSource *bytes.Buffer, pMap *presencemap.PresenceMap, dictionary *dictionary.Dictionary) (fix.Value, error) {
-	previousValue := dictionary.GetValue(field.FieldDetails.Name)
+func (field FieldAsciiString) Deserialise(inputSource decoder.Reader, pMap *presencemap.PresenceMap, dictionary *dictionary.Dictionary) (fix.Value, error) {
+	previousValue := dictionary.GetKeyValue(field.FieldDetails.Key)
 
 	if field.Operation.ShouldReadValue(pMap) {
 		var readValue value.Value
@@ -47,7 +49,7 @@ func (field FieldAsciiString) Deserialise(inputSource *bytes.Buffer, pMap *prese
 			return nil, fmt.Errorf("[FieldAsciiString][%#v][%#v] failed to apply operation with readValue %#v, previousValue: %#v, reason: %s", field.FieldDetails, field.Operation, readValue, previousValue, err)
 		}
 
-		dictionary.SetValue(field.FieldDetails.Name, transformedValue)
+		dictionary.SetKeyValue(field.FieldDetails.Key, transformedValue)
 		return transformedValue, nil
 	}
 
@@ -57,15 +59,57 @@ func (field FieldAsciiString) Deserialise(inputSource *bytes.Buffer, pMap *prese
 		return nil, fmt.Errorf("[FieldAsciiString][%#v][%#v] failed to get value for field when not encoded in message, reason: %s", field.FieldDetails, field.Operation, err)
 	}
 
-	dictionary.SetValue(field.FieldDetails.Name, transformedValue)
+	dictionary.SetKeyValue(field.FieldDetails.Key, transformedValue)
 	return transformedValue, nil
 }
 
+// Serialise a <string/> to the output source
+func (field FieldAsciiString) Serialise(outputSource *bytes.Buffer, pMap *presencemap.PresenceMap, dictionary *dictionary.Dictionary, fixValue fix.Value) error {
+	previousValue := dictionary.GetKeyValue(field.FieldDetails.Key)
+	shouldWrite, err := field.Operation.ShouldWriteValue(pMap, field.FieldDetails.Required, fixValue, previousValue)
+	if err != nil {
+		field.FieldDetails.Logger.Printf("[FieldAsciiString][%#v][%#v] failed to evaluate whether to encode value %#v, previousValue: %#v, reason: %s", field.FieldDetails, field.Operation, fixValue, previousValue, err)
+		return fmt.Errorf("[FieldAsciiString][%#v][%#v] failed to evaluate whether to encode value %#v, previousValue: %#v, reason: %s", field.FieldDetails, field.Operation, fixValue, previousValue, err)
+	}
+
+	if shouldWrite {
+		writeValue, err := field.Operation.GetEncodedValue(fixValue, previousValue)
+		if err != nil {
+			field.FieldDetails.Logger.Printf("[FieldAsciiString][%#v][%#v] failed to apply operation with value %#v, previousValue: %#v, reason: %s", field.FieldDetails, field.Operation, fixValue, previousValue, err)
+			return fmt.Errorf("[FieldAsciiString][%#v][%#v] failed to apply operation with value %#v, previousValue: %#v, reason: %s", field.FieldDetails, field.Operation, fixValue, previousValue, err)
+		}
+
+		if field.FieldDetails.Required {
+			err = field.encode.WriteValue(outputSource, writeValue)
+		} else {
+			err = field.encode.WriteOptionalValue(outputSource, writeValue)
+		}
+
+		if err != nil {
+			field.FieldDetails.Logger.Printf("[FieldAsciiString][%#v][%#v] failed to encode value to byte buffer, reason: %s", field.FieldDetails, field.Operation, err)
+			return fmt.Errorf("[FieldAsciiString][%#v][%#v] failed to encode value to byte buffer, reason: %s", field.FieldDetails, field.Operation, err)
+		}
+	}
+
+	dictionary.SetKeyValue(field.FieldDetails.Key, fixValue)
+	return nil
+}
+
 // GetTagId for this field
 func (field FieldAsciiString) GetTagId() uint64 {
 	return field.FieldDetails.ID
 }
 
+// GetName of this field, as given in the template
+func (field FieldAsciiString) GetName() string {
+	return field.FieldDetails.Name
+}
+
+// Keys of the dictionary entry holding the previous value of this field
+func (field FieldAsciiString) Keys() []dictionary.Key {
+	return []dictionary.Key{field.FieldDetails.Key}
+}
+
 // RequiresPmap returns whether the underlying operation for this field requires a pmap bit being set
 func (field FieldAsciiString) RequiresPmap() bool {
 	return field.Operation.RequiresPmap(field.FieldDetails.Required)
@@ -76,6 +120,7 @@ func New(properties properties.Properties) FieldAsciiString {
 	field := FieldAsciiString{
 		FieldDetails: properties,
 		decode:       decoder.AsciiStringDecoder{},
+		encode:       encoder.AsciiStringEncoder{},
 		Operation:    operation.None{},
 	}
 
@@ -87,6 +132,7 @@ func NewConstantOperation(properties properties.Properties, constantValue string
 	field := FieldAsciiString{
 		FieldDetails: properties,
 		decode:       decoder.AsciiStringDecoder{},
+		encode:       encoder.AsciiStringEncoder{},
 		Operation: operation.Constant{
 			ConstantValue: fix.NewRawValue(constantValue),
 		},
@@ -100,6 +146,7 @@ func NewDefaultOperation(properties properties.Properties) FieldAsciiString {
 	field := FieldAsciiString{
 		FieldDetails: properties,
 		decode:       decoder.AsciiStringDecoder{},
+		encode:       encoder.AsciiStringEncoder{},
 		Operation: operation.Default{
 			DefaultValue: fix.NullValue{},
 		},
@@ -113,6 +160,7 @@ func NewDefaultOperationWithValue(properties properties.Properties, defaultValue
 	field := FieldAsciiString{
 		FieldDetails: properties,
 		decode:       decoder.AsciiStringDecoder{},
+		encode:       encoder.AsciiStringEncoder{},
 		Operation: operation.Default{
 			DefaultValue: fix.NewRawValue(defaultValue),
 		},
@@ -126,6 +174,7 @@ func NewCopyOperation(properties properties.Properties) FieldAsciiString {
 	field := FieldAsciiString{
 		FieldDetails: properties,
 		decode:       decoder.AsciiStringDecoder{},
+		encode:       encoder.AsciiStringEncoder{},
 		Operation: operation.Copy{
 			InitialValue: fix.NullValue{},
 		},
@@ -139,6 +188,7 @@ func NewCopyOperationWithInitialValue(properties properties.Properties, initialV
 	field := FieldAsciiString{
 		FieldDetails: properties,
 		decode:       decoder.AsciiStringDecoder{},
+		encode:       encoder.AsciiStringEncoder{},
 		Operation: operation.Copy{
 			InitialValue: fix.NewRawValue(initialValue),
 		},
@@ -152,6 +202,7 @@ func NewTailOperation(properties properties.Properties) FieldAsciiString {
 	field := FieldAsciiString{
 		FieldDetails: properties,
 		decode:       decoder.AsciiStringDecoder{},
+		encode:       encoder.AsciiStringEncoder{},
 		Operation: operation.Tail{
 			InitialValue: fix.NullValue{},
 			BaseValue:    fix.NewRawValue(""),
@@ -165,6 +216,7 @@ func NewTailOperationWithInitialValue(properties properties.Properties, initialV
 	field := FieldAsciiString{
 		FieldDetails: properties,
 		decode:       decoder.AsciiStringDecoder{},
+		encode:       encoder.AsciiStringEncoder{},
 		Operation: operation.Tail{
 			InitialValue: fix.NewRawValue(initialValue),
 			BaseValue:    fix.NewRawValue(""),
@@ -178,6 +230,7 @@ func NewDeltaOperation(properties properties.Properties) FieldAsciiString {
 	field := FieldAsciiString{
 		FieldDetails: properties,
 		decode:       decoder.AsciiStringDeltaDecoder{},
+		encode:       encoder.AsciiStringDeltaEncoder{},
 		Operation: operation.Delta{
 			InitialValue: fix.NullValue{},
 			BaseValue:    fix.NewRawValue(""),
@@ -192,6 +245,7 @@ func NewDeltaOperationWithInitialValue(properties properties.Properties, initial
 	field := FieldAsciiString{
 		FieldDetails: properties,
 		decode:       decoder.AsciiStringDeltaDecoder{},
+		encode:       encoder.AsciiStringDeltaEncoder{},
 		Operation: operation.Delta{
 			InitialValue: fix.NewRawValue(initialValue),
 			BaseValue:    fix.NewRawValue(""),
@@ -0,0 +1,122 @@
+package fieldasciistring
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/properties"
+	"github.com/Guardian-Development/fastengine/pkg/fast/presencemap"
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+//<string />
+func TestCanSeraliseRequiredAsciiString(t *testing.T) {
+	// Arrange TEST1 = 01010100 01000101 01010011 01010100 10110001
+	outputSource := bytes.Buffer{}
+	pmap := presencemap.PresenceMap{}
+	dict := dictionary.New()
+	expectedBytes := []byte{84, 69, 83, 84, 177}
+	unitUnderTest := New(properties.New(1, "AsciiStringField", true, testLog))
+
+	// Act
+	err := unitUnderTest.Serialise(&outputSource, &pmap, &dict, fix.NewRawValue("TEST1"))
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+
+	// Assert
+	if !reflect.DeepEqual(expectedBytes, outputSource.Bytes()) {
+		t.Errorf("Expected bytes and serialised bytes were not equal, expected: %#v, actual: %#v", expectedBytes, outputSource.Bytes())
+	}
+}
+
+//<string>
+//	<delta />
+//</string>
+func TestCanSeraliseRequiredAsciiStringDeltaOperatorAppendsToPreviousValue(t *testing.T) {
+	// Arrange remove(1) = 10000001 TS = 01010100 11010011
+	outputSource := bytes.Buffer{}
+	pmap := presencemap.PresenceMap{}
+	dict := dictionary.New()
+	dict.SetValue("AsciiStringField", fix.NewRawValue("TEST1"))
+	expectedBytes := []byte{129, 84, 211}
+	unitUnderTest := NewDeltaOperation(properties.New(1, "AsciiStringField", true, testLog))
+
+	// Act
+	err := unitUnderTest.Serialise(&outputSource, &pmap, &dict, fix.NewRawValue("TESTTS"))
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+
+	// Assert
+	if !reflect.DeepEqual(expectedBytes, outputSource.Bytes()) {
+		t.Errorf("Expected bytes and serialised bytes were not equal, expected: %#v, actual: %#v", expectedBytes, outputSource.Bytes())
+	}
+}
+
+//<string>
+//	<tail />
+//</string>
+func TestCanSeraliseRequiredAsciiStringTailOperatorReplacesEndOfPreviousValue(t *testing.T) {
+	// Arrange pmap = 11000000 2 = 10110010
+	outputSource := bytes.Buffer{}
+	pmap := presencemap.PresenceMap{}
+	dict := dictionary.New()
+	dict.SetValue("AsciiStringField", fix.NewRawValue("TEST1"))
+	expectedBytes := []byte{178}
+	expectedPmap := []byte{192}
+	unitUnderTest := NewTailOperation(properties.New(1, "AsciiStringField", true, testLog))
+
+	// Act
+	err := unitUnderTest.Serialise(&outputSource, &pmap, &dict, fix.NewRawValue("TEST2"))
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+
+	// Assert
+	if !reflect.DeepEqual(expectedBytes, outputSource.Bytes()) {
+		t.Errorf("Expected bytes and serialised bytes were not equal, expected: %#v, actual: %#v", expectedBytes, outputSource.Bytes())
+	}
+	if !reflect.DeepEqual(expectedPmap, pmap.Bytes()) {
+		t.Errorf("Expected pmap and serialised pmap were not equal, expected: %#v, actual: %#v", expectedPmap, pmap.Bytes())
+	}
+}
+
+func TestSeralisedAsciiStringOperatorsCanBeDeserialised(t *testing.T) {
+	values := []fix.Value{fix.NewRawValue("TEST1"), fix.NewRawValue("TEST1"), fix.NewRawValue("TEST2"), fix.NullValue{}, fix.NewRawValue(""), fix.NewRawValue("ATEST2"), fix.NewRawValue("ATEST2XY")}
+	cases := []FieldAsciiString{
+		New(properties.New(1, "AsciiStringField", false, testLog)),
+		NewDefaultOperationWithValue(properties.New(1, "AsciiStringField", false, testLog), "TEST1"),
+		NewCopyOperationWithInitialValue(properties.New(1, "AsciiStringField", false, testLog), "TEST1"),
+		NewDeltaOperationWithInitialValue(properties.New(1, "AsciiStringField", false, testLog), "TEST1"),
+	}
+
+	for _, unitUnderTest := range cases {
+		// Arrange
+		outputSource := bytes.Buffer{}
+		writePmap := presencemap.PresenceMap{}
+		writeDict := dictionary.New()
+		for _, toWrite := range values {
+			if err := unitUnderTest.Serialise(&outputSource, &writePmap, &writeDict, toWrite); err != nil {
+				t.Errorf("Got an error serialising %#v when none was expected: %s", toWrite, err)
+			}
+		}
+
+		// Act
+		readPmap, _ := presencemap.New(bytes.NewBuffer(writePmap.Bytes()))
+		readDict := dictionary.New()
+		for _, expected := range values {
+			result, err := unitUnderTest.Deserialise(&outputSource, &readPmap, &readDict)
+
+			// Assert
+			if err != nil {
+				t.Errorf("Got an error deserialising %#v when none was expected: %s", expected, err)
+			}
+			if !reflect.DeepEqual(expected.Get(), result.Get()) {
+				t.Errorf("Expected value and deserialised value were not equal, operation: %#v, expected: %v, actual: %v", unitUnderTest.Operation, expected, result)
+			}
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
 	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
+	"github.com/Guardian-Development/fastengine/pkg/fast/encoder"
 	"github.com/Guardian-Development/fastengine/pkg/fast/field/properties"
 	"github.com/Guardian-Development/fastengine/pkg/fast/operation"
 	"github.com/Guardian-Development/fastengine/pkg/fast/presencemap"
@@ -19,11 +20,12 @@ type FieldByteVector struct {
 	Operation    operation.Operation
 
 	decode decoder.Decoder
+	encode encoder.Encoder
 }
 
 // Deserialise a <byteVector/> from the input source
-func (field FieldByteVector) Deserialise(inputSource *bytes.Buffer, pMap *presencemap.PresenceMap, dictionary *dictionary.Dictionary) (fix.Value, error) {
-	previousValue := dictionary.GetValue(field.FieldDetails.Name)
+func (field FieldByteVector) Deserialise(inputSource decoder.Reader, pMap *presencemap.PresenceMap, dictionary *dictionary.Dictionary) (fix.Value, error) {
+	previousValue := dictionary.GetKeyValue(field.FieldDetails.Key)
 	if field.Operation.ShouldReadValue(pMap) {
 		var readValue value.Value
 		var err error
@@ -45,7 +47,7 @@ func (field FieldByteVector) Deserialise(inputSource *bytes.Buffer, pMap *presen
 			return nil, fmt.Errorf("[FieldByteVector][%#v][%#v] failed to apply operation with readValue %#v, previousValue: %#v, reason: %s", field.FieldDetails, field.Operation, readValue, previousValue, err)
 		}
 
-		dictionary.SetValue(field.FieldDetails.Name, transformedValue)
+		dictionary.SetKeyValue(field.FieldDetails.Key, transformedValue)
 		return transformedValue, nil
 	}
 
@@ -55,15 +57,57 @@ func (field FieldByteVector) Deserialise(inputSource *bytes.Buffer, pMap *presen
 		return nil, fmt.Errorf("[FieldByteVector][%#v][%#v] failed to get value for field when not encoded in message, reason: %s", field.FieldDetails, field.Operation, err)
 	}
 
-	dictionary.SetValue(field.FieldDetails.Name, transformedValue)
+	dictionary.SetKeyValue(field.FieldDetails.Key, transformedValue)
 	return transformedValue, nil
 }
 
+// Serialise a <byteVector/> to the output source
+func (field FieldByteVector) Serialise(outputSource *bytes.Buffer, pMap *presencemap.PresenceMap, dictionary *dictionary.Dictionary, fixValue fix.Value) error {
+	previousValue := dictionary.GetKeyValue(field.FieldDetails.Key)
+	shouldWrite, err := field.Operation.ShouldWriteValue(pMap, field.FieldDetails.Required, fixValue, previousValue)
+	if err != nil {
+		field.FieldDetails.Logger.Printf("[FieldByteVector][%#v][%#v] failed to evaluate whether to encode value %#v, previousValue: %#v, reason: %s", field.FieldDetails, field.Operation, fixValue, previousValue, err)
+		return fmt.Errorf("[FieldByteVector][%#v][%#v] failed to evaluate whether to encode value %#v, previousValue: %#v, reason: %s", field.FieldDetails, field.Operation, fixValue, previousValue, err)
+	}
+
+	if shouldWrite {
+		writeValue, err := field.Operation.GetEncodedValue(fixValue, previousValue)
+		if err != nil {
+			field.FieldDetails.Logger.Printf("[FieldByteVector][%#v][%#v] failed to apply operation with value %#v, previousValue: %#v, reason: %s", field.FieldDetails, field.Operation, fixValue, previousValue, err)
+			return fmt.Errorf("[FieldByteVector][%#v][%#v] failed to apply operation with value %#v, previousValue: %#v, reason: %s", field.FieldDetails, field.Operation, fixValue, previousValue, err)
+		}
+
+		if field.FieldDetails.Required {
+			err = field.encode.WriteValue(outputSource, writeValue)
+		} else {
+			err = field.encode.WriteOptionalValue(outputSource, writeValue)
+		}
+
+		if err != nil {
+			field.FieldDetails.Logger.Printf("[FieldByteVector][%#v][%#v] failed to encode value to byte buffer, reason: %s", field.FieldDetails, field.Operation, err)
+			return fmt.Errorf("[FieldByteVector][%#v][%#v] failed to encode value to byte buffer, reason: %s", field.FieldDetails, field.Operation, err)
+		}
+	}
+
+	dictionary.SetKeyValue(field.FieldDetails.Key, fixValue)
+	return nil
+}
+
 // GetTagId for this field
 func (field FieldByteVector) GetTagId() uint64 {
 	return field.FieldDetails.ID
 }
 
+// GetName of this field, as given in the template
+func (field FieldByteVector) GetName() string {
+	return field.FieldDetails.Name
+}
+
+// Keys of the dictionary entry holding the previous value of this field
+func (field FieldByteVector) Keys() []dictionary.Key {
+	return []dictionary.Key{field.FieldDetails.Key}
+}
+
 // RequiresPmap returns whether the underlying operation for this field requires a pmap bit being set
 func (field FieldByteVector) RequiresPmap() bool {
 	return field.Operation.RequiresPmap(field.FieldDetails.Required)
@@ -74,6 +118,7 @@ func New(properties properties.Properties) FieldByteVector {
 	field := FieldByteVector{
 		FieldDetails: properties,
 		decode:       decoder.ByteVectorDecoder{},
+		encode:       encoder.ByteVectorEncoder{},
 		Operation:    operation.None{},
 	}
 
@@ -85,6 +130,7 @@ func NewConstantOperation(properties properties.Properties, constantValue []byte
 	field := FieldByteVector{
 		FieldDetails: properties,
 		decode:       decoder.ByteVectorDecoder{},
+		encode:       encoder.ByteVectorEncoder{},
 		Operation: operation.Constant{
 			ConstantValue: fix.NewRawValue(constantValue),
 		},
@@ -98,6 +144,7 @@ func NewDefaultOperation(properties properties.Properties) FieldByteVector {
 	field := FieldByteVector{
 		FieldDetails: properties,
 		decode:       decoder.ByteVectorDecoder{},
+		encode:       encoder.ByteVectorEncoder{},
 		Operation: operation.Default{
 			DefaultValue: fix.NullValue{},
 		},
@@ -111,6 +158,7 @@ func NewDefaultOperationWithValue(properties properties.Properties, defaultValue
 	field := FieldByteVector{
 		FieldDetails: properties,
 		decode:       decoder.ByteVectorDecoder{},
+		encode:       encoder.ByteVectorEncoder{},
 		Operation: operation.Default{
 			DefaultValue: fix.NewRawValue(defaultValue),
 		},
@@ -124,6 +172,7 @@ func NewCopyOperation(properties properties.Properties) FieldByteVector {
 	field := FieldByteVector{
 		FieldDetails: properties,
 		decode:       decoder.ByteVectorDecoder{},
+		encode:       encoder.ByteVectorEncoder{},
 		Operation: operation.Copy{
 			InitialValue: fix.NullValue{},
 		},
@@ -137,6 +186,7 @@ func NewCopyOperationWithInitialValue(properties properties.Properties, initialV
 	field := FieldByteVector{
 		FieldDetails: properties,
 		decode:       decoder.ByteVectorDecoder{},
+		encode:       encoder.ByteVectorEncoder{},
 		Operation: operation.Copy{
 			InitialValue: fix.NewRawValue(initialValue),
 		},
@@ -150,6 +200,7 @@ func NewTailOperation(properties properties.Properties) FieldByteVector {
 	field := FieldByteVector{
 		FieldDetails: properties,
 		decode:       decoder.ByteVectorDecoder{},
+		encode:       encoder.ByteVectorEncoder{},
 		Operation: operation.Tail{
 			InitialValue: fix.NullValue{},
 			BaseValue:    fix.NewRawValue([]byte{}),
@@ -163,6 +214,7 @@ func NewTailOperationWithInitialValue(properties properties.Properties, initialV
 	field := FieldByteVector{
 		FieldDetails: properties,
 		decode:       decoder.ByteVectorDecoder{},
+		encode:       encoder.ByteVectorEncoder{},
 		Operation: operation.Tail{
 			InitialValue: fix.NewRawValue(initialValue),
 			BaseValue:    fix.NewRawValue([]byte{}),
@@ -176,6 +228,7 @@ func NewDeltaOperation(properties properties.Properties) FieldByteVector {
 	field := FieldByteVector{
 		FieldDetails: properties,
 		decode:       decoder.ByteVectorDeltaDecoder{},
+		encode:       encoder.ByteVectorDeltaEncoder{},
 		Operation: operation.Delta{
 			InitialValue: fix.NullValue{},
 			BaseValue:    fix.NewRawValue([]byte{}),
@@ -190,6 +243,7 @@ func NewDeltaOperationWithInitialValue(properties properties.Properties, initial
 	field := FieldByteVector{
 		FieldDetails: properties,
 		decode:       decoder.ByteVectorDeltaDecoder{},
+		encode:       encoder.ByteVectorDeltaEncoder{},
 		Operation: operation.Delta{
 			InitialValue: fix.NewRawValue(initialValue),
 			BaseValue:    fix.NewRawValue([]byte{}),
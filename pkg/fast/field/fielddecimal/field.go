@@ -3,6 +3,7 @@ package fielddecimal
 import (
 	"bytes"
 	"fmt"
+	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
 	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
 	"github.com/Guardian-Development/fastengine/pkg/fast/errors"
 	"github.com/Guardian-Development/fastengine/pkg/fast/field/fieldint32"
@@ -22,7 +23,7 @@ type FieldDecimal struct {
 }
 
 // Deserialise a <decimal/> from the input source
-func (field FieldDecimal) Deserialise(inputSource *bytes.Buffer, pMap *presencemap.PresenceMap, dict *dictionary.Dictionary) (fix.Value, error) {
+func (field FieldDecimal) Deserialise(inputSource decoder.Reader, pMap *presencemap.PresenceMap, dict *dictionary.Dictionary) (fix.Value, error) {
 	exponentValue, err := field.ExponentField.Deserialise(inputSource, pMap, dict)
 	if err != nil {
 		field.FieldDetails.Logger.Printf("[FieldDecimal][%#v] failed to read exponent value, reason: %s", field.FieldDetails, err)
@@ -44,23 +45,117 @@ func (field FieldDecimal) Deserialise(inputSource *bytes.Buffer, pMap *presencem
 
 		decimalValue := math.Pow(10, float64(exponentValue.Get().(int32))) * float64(mantissaValue.Get().(int64))
 		fixValue := fix.NewRawValue(decimalValue)
-		dict.SetValue(field.FieldDetails.Name, fixValue)
+		dict.SetKeyValue(field.FieldDetails.Key, fixValue)
 		return fixValue, nil
 	}
 
 	return nil, fmt.Errorf("[FieldDecimal][%#v] exponent value of decimal was not expected type: %#v", field.FieldDetails, exponentValue)
 }
 
+// Serialise a <decimal/> to the output source. The decimal is split into an exponent and mantissa, preferring the exponent the operation on the
+// exponent would produce if it were not encoded, so that the exponent is not written to the stream where possible.
+func (field FieldDecimal) Serialise(outputSource *bytes.Buffer, pMap *presencemap.PresenceMap, dict *dictionary.Dictionary, fixValue fix.Value) error {
+	switch fixValue.(type) {
+	case fix.NullValue:
+		err := field.ExponentField.Serialise(outputSource, pMap, dict, fixValue)
+		if err != nil {
+			field.FieldDetails.Logger.Printf("[FieldDecimal][%#v] failed to write null exponent value, reason: %s", field.FieldDetails, err)
+			return fmt.Errorf("[FieldDecimal][%#v] failed to write null exponent value, reason: %s", field.FieldDetails, err)
+		}
+		return nil
+	}
+
+	decimalValue, ok := fixValue.Get().(float64)
+	if !ok {
+		return fmt.Errorf("[FieldDecimal][%#v] value to encode was not a float64: %#v", field.FieldDetails, fixValue.Get())
+	}
+
+	exponentValue, mantissaValue, err := field.toExponentAndMantissa(decimalValue, dict)
+	if err != nil {
+		field.FieldDetails.Logger.Printf("[FieldDecimal][%#v] failed to split value %v into exponent and mantissa, reason: %s", field.FieldDetails, decimalValue, err)
+		return fmt.Errorf("[FieldDecimal][%#v] failed to split value %v into exponent and mantissa, reason: %s", field.FieldDetails, decimalValue, err)
+	}
+
+	err = field.ExponentField.Serialise(outputSource, pMap, dict, fix.NewRawValue(exponentValue))
+	if err != nil {
+		field.FieldDetails.Logger.Printf("[FieldDecimal][%#v] failed to write exponent value, reason: %s", field.FieldDetails, err)
+		return fmt.Errorf("[FieldDecimal][%#v] failed to write exponent value, reason: %s", field.FieldDetails, err)
+	}
+	err = field.MantissaField.Serialise(outputSource, pMap, dict, fix.NewRawValue(mantissaValue))
+	if err != nil {
+		field.FieldDetails.Logger.Printf("[FieldDecimal][%#v] failed to write mantissa value after successful write of exponent, reason: %s", field.FieldDetails, err)
+		return fmt.Errorf("[FieldDecimal][%#v] failed to write mantissa value after successful write of exponent, reason: %s", field.FieldDetails, err)
+	}
+
+	dict.SetKeyValue(field.FieldDetails.Key, fixValue)
+	return nil
+}
+
+// toExponentAndMantissa finds an exponent and mantissa that decode back to exactly the decimal value. The exponent the exponent operation would
+// produce if not encoded is tried first, followed by every other exponent in the range [-63 ... 63], starting at 0 and moving towards more precision.
+func (field FieldDecimal) toExponentAndMantissa(decimalValue float64, dict *dictionary.Dictionary) (int32, int64, error) {
+	exponentsToTry := make([]int32, 0, 128)
+	notEncodedExponent, err := field.ExponentField.Operation.GetNotEncodedValue(&presencemap.PresenceMap{}, true, dict.GetKeyValue(field.ExponentField.FieldDetails.Key))
+	if err == nil {
+		if exponent, ok := notEncodedExponent.Get().(int32); ok {
+			exponentsToTry = append(exponentsToTry, exponent)
+		}
+	}
+	for exponent := int32(0); exponent >= -63; exponent-- {
+		exponentsToTry = append(exponentsToTry, exponent)
+	}
+	for exponent := int32(1); exponent <= 63; exponent++ {
+		exponentsToTry = append(exponentsToTry, exponent)
+	}
+
+	for _, exponent := range exponentsToTry {
+		mantissa := math.Round(decimalValue / math.Pow(10, float64(exponent)))
+		if math.Abs(mantissa) >= math.MaxInt64 {
+			continue
+		}
+
+		// the mantissa must decode back to exactly the same value
+		if math.Pow(10, float64(exponent))*float64(int64(mantissa)) == decimalValue {
+			return exponent, int64(mantissa), nil
+		}
+	}
+
+	return 0, 0, fmt.Errorf("%s: %v", errors.R1, decimalValue)
+}
+
 // GetTagId for this field
 func (field FieldDecimal) GetTagId() uint64 {
 	return field.FieldDetails.ID
 }
 
+// GetName of this field, as given in the template
+func (field FieldDecimal) GetName() string {
+	return field.FieldDetails.Name
+}
+
+// Keys of the dictionary entries holding the previous values of this field, which are the entry of the decimal and the entries of its exponent and mantissa
+func (field FieldDecimal) Keys() []dictionary.Key {
+	return []dictionary.Key{field.FieldDetails.Key, field.ExponentField.FieldDetails.Key, field.MantissaField.FieldDetails.Key}
+}
+
 // RequiresPmap returns whether either the exponent or mantissa require a pmap bit being set
 func (field FieldDecimal) RequiresPmap() bool {
 	return field.ExponentField.RequiresPmap() || field.MantissaField.RequiresPmap()
 }
 
+// PresenceMapUnits names the exponent and mantissa if they require a pmap bit, the mantissa does not read its bit when the exponent is null
+func (field FieldDecimal) PresenceMapUnits() []string {
+	units := make([]string, 0, 2)
+	if field.ExponentField.RequiresPmap() {
+		units = append(units, field.ExponentField.FieldDetails.Name)
+	}
+	if field.MantissaField.RequiresPmap() {
+		units = append(units, field.MantissaField.FieldDetails.Name)
+	}
+
+	return units
+}
+
 // New <decimal/> field with the given properties, exponent and mantissa
 func New(properties properties.Properties, exponent fieldint32.FieldInt32, mantissa fieldint64.FieldInt64) FieldDecimal {
 	field := FieldDecimal{
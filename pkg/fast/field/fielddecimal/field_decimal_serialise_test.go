@@ -0,0 +1,130 @@
+package fielddecimal
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/fieldint32"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/fieldint64"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/properties"
+	"github.com/Guardian-Development/fastengine/pkg/fast/presencemap"
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+//<decimal>
+//	<exponent />
+//	<mantissa />
+//</decimal>
+func TestCanSeraliseRequiredDecimal(t *testing.T) {
+	// Arrange exp(-1) = 11111111 man(15) = 10001111
+	outputSource := bytes.Buffer{}
+	pmap := presencemap.PresenceMap{}
+	dict := dictionary.New()
+	expectedBytes := []byte{255, 143}
+	unitUnderTest := New(properties.New(1, "DecimalField", true, testLog),
+		fieldint32.New(properties.New(1, "DecimalFieldExponent", true, testLog)),
+		fieldint64.New(properties.New(1, "DecimalFieldMantissa", true, testLog)))
+
+	// Act
+	err := unitUnderTest.Serialise(&outputSource, &pmap, &dict, fix.NewRawValue(float64(1.5)))
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+
+	// Assert
+	if !reflect.DeepEqual(expectedBytes, outputSource.Bytes()) {
+		t.Errorf("Expected bytes and serialised bytes were not equal, expected: %#v, actual: %#v", expectedBytes, outputSource.Bytes())
+	}
+}
+
+//<decimal presence="optional">
+//	<exponent />
+//	<mantissa />
+//</decimal>
+func TestCanSeraliseOptionalDecimalNullAsNullExponent(t *testing.T) {
+	// Arrange exp = 10000000
+	outputSource := bytes.Buffer{}
+	pmap := presencemap.PresenceMap{}
+	dict := dictionary.New()
+	expectedBytes := []byte{128}
+	unitUnderTest := New(properties.New(1, "DecimalField", false, testLog),
+		fieldint32.New(properties.New(1, "DecimalFieldExponent", false, testLog)),
+		fieldint64.New(properties.New(1, "DecimalFieldMantissa", true, testLog)))
+
+	// Act
+	err := unitUnderTest.Serialise(&outputSource, &pmap, &dict, fix.NullValue{})
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+
+	// Assert
+	if !reflect.DeepEqual(expectedBytes, outputSource.Bytes()) {
+		t.Errorf("Expected bytes and serialised bytes were not equal, expected: %#v, actual: %#v", expectedBytes, outputSource.Bytes())
+	}
+}
+
+//<decimal>
+//	<exponent />
+//	<mantissa />
+//</decimal>
+func TestSeraliseRequiredDecimalReturnsErrorForNonDecimalValue(t *testing.T) {
+	// Arrange
+	outputSource := bytes.Buffer{}
+	pmap := presencemap.PresenceMap{}
+	dict := dictionary.New()
+	unitUnderTest := New(properties.New(1, "DecimalField", true, testLog),
+		fieldint32.New(properties.New(1, "DecimalFieldExponent", true, testLog)),
+		fieldint64.New(properties.New(1, "DecimalFieldMantissa", true, testLog)))
+
+	// Act
+	err := unitUnderTest.Serialise(&outputSource, &pmap, &dict, fix.NewRawValue("1.5"))
+
+	// Assert
+	if err == nil {
+		t.Errorf("Expected error serialising a string as a decimal, but got none")
+	}
+}
+
+func TestSeralisedDecimalOperatorsCanBeDeserialised(t *testing.T) {
+	values := []fix.Value{fix.NewRawValue(float64(1.5)), fix.NewRawValue(float64(1.5)), fix.NewRawValue(float64(100)), fix.NullValue{}, fix.NewRawValue(float64(-0.0025)), fix.NewRawValue(float64(0))}
+	cases := []FieldDecimal{
+		New(properties.New(1, "DecimalField", false, testLog),
+			fieldint32.New(properties.New(1, "DecimalFieldExponent", false, testLog)),
+			fieldint64.New(properties.New(1, "DecimalFieldMantissa", true, testLog))),
+		New(properties.New(1, "DecimalField", false, testLog),
+			fieldint32.NewCopyOperation(properties.New(1, "DecimalFieldExponent", false, testLog)),
+			fieldint64.NewDeltaOperation(properties.New(1, "DecimalFieldMantissa", true, testLog))),
+		New(properties.New(1, "DecimalField", false, testLog),
+			fieldint32.NewDefaultOperationWithValue(properties.New(1, "DecimalFieldExponent", false, testLog), -1),
+			fieldint64.NewCopyOperation(properties.New(1, "DecimalFieldMantissa", true, testLog))),
+	}
+
+	for _, unitUnderTest := range cases {
+		// Arrange
+		outputSource := bytes.Buffer{}
+		writePmap := presencemap.PresenceMap{}
+		writeDict := dictionary.New()
+		for _, toWrite := range values {
+			if err := unitUnderTest.Serialise(&outputSource, &writePmap, &writeDict, toWrite); err != nil {
+				t.Errorf("Got an error serialising %#v when none was expected: %s", toWrite, err)
+			}
+		}
+
+		// Act
+		readPmap, _ := presencemap.New(bytes.NewBuffer(writePmap.Bytes()))
+		readDict := dictionary.New()
+		for _, expected := range values {
+			result, err := unitUnderTest.Deserialise(&outputSource, &readPmap, &readDict)
+
+			// Assert
+			if err != nil {
+				t.Errorf("Got an error deserialising %#v when none was expected: %s", expected, err)
+			}
+			if !reflect.DeepEqual(expected.Get(), result.Get()) {
+				t.Errorf("Expected value and deserialised value were not equal, expected: %v, actual: %v", expected, result)
+			}
+		}
+	}
+}
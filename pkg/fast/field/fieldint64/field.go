@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
 	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
+	"github.com/Guardian-Development/fastengine/pkg/fast/encoder"
 	"github.com/Guardian-Development/fastengine/pkg/fast/field/properties"
 	"github.com/Guardian-Development/fastengine/pkg/fast/operation"
 	"github.com/Guardian-Development/fastengine/pkg/fast/presencemap"
@@ -19,11 +20,12 @@ type FieldInt64 struct {
 	Operation    operation.Operation
 
 	decode decoder.Decoder
+	encode encoder.Encoder
 }
 
 // Deserialise an <int64/> from the input source
-func (field FieldInt64) Deserialise(inputSource *bytes.Buffer, pMap *presencemap.PresenceMap, dictionary *dictionary.Dictionary) (fix.Value, error) {
-	previousValue := dictionary.GetValue(field.FieldDetails.Name)
+func (field FieldInt64) Deserialise(inputSource decoder.Reader, pMap *presencemap.PresenceMap, dictionary *dictionary.Dictionary) (fix.Value, error) {
+	previousValue := dictionary.GetKeyValue(field.FieldDetails.Key)
 	if field.Operation.ShouldReadValue(pMap) {
 		var readValue value.Value
 		var err error
@@ -45,7 +47,7 @@ func (field FieldInt64) Deserialise(inputSource *bytes.Buffer, pMap *presencemap
 			return nil, fmt.Errorf("[FieldInt64][%#v][%#v] failed to apply operation with readValue %#v, previousValue: %#v, reason: %s", field.FieldDetails, field.Operation, readValue, previousValue, err)
 		}
 
-		dictionary.SetValue(field.FieldDetails.Name, transformedValue)
+		dictionary.SetKeyValue(field.FieldDetails.Key, transformedValue)
 		return transformedValue, nil
 	}
 
@@ -55,15 +57,57 @@ func (field FieldInt64) Deserialise(inputSource *bytes.Buffer, pMap *presencemap
 		return nil, fmt.Errorf("[FieldInt64][%#v][%#v] failed to get value for field when not encoded in message, reason: %s", field.FieldDetails, field.Operation, err)
 	}
 
-	dictionary.SetValue(field.FieldDetails.Name, transformedValue)
+	dictionary.SetKeyValue(field.FieldDetails.Key, transformedValue)
 	return transformedValue, nil
 }
 
+// Serialise a <int64/> to the output source
+func (field FieldInt64) Serialise(outputSource *bytes.Buffer, pMap *presencemap.PresenceMap, dictionary *dictionary.Dictionary, fixValue fix.Value) error {
+	previousValue := dictionary.GetKeyValue(field.FieldDetails.Key)
+	shouldWrite, err := field.Operation.ShouldWriteValue(pMap, field.FieldDetails.Required, fixValue, previousValue)
+	if err != nil {
+		field.FieldDetails.Logger.Printf("[FieldInt64][%#v][%#v] failed to evaluate whether to encode value %#v, previousValue: %#v, reason: %s", field.FieldDetails, field.Operation, fixValue, previousValue, err)
+		return fmt.Errorf("[FieldInt64][%#v][%#v] failed to evaluate whether to encode value %#v, previousValue: %#v, reason: %s", field.FieldDetails, field.Operation, fixValue, previousValue, err)
+	}
+
+	if shouldWrite {
+		writeValue, err := field.Operation.GetEncodedValue(fixValue, previousValue)
+		if err != nil {
+			field.FieldDetails.Logger.Printf("[FieldInt64][%#v][%#v] failed to apply operation with value %#v, previousValue: %#v, reason: %s", field.FieldDetails, field.Operation, fixValue, previousValue, err)
+			return fmt.Errorf("[FieldInt64][%#v][%#v] failed to apply operation with value %#v, previousValue: %#v, reason: %s", field.FieldDetails, field.Operation, fixValue, previousValue, err)
+		}
+
+		if field.FieldDetails.Required {
+			err = field.encode.WriteValue(outputSource, writeValue)
+		} else {
+			err = field.encode.WriteOptionalValue(outputSource, writeValue)
+		}
+
+		if err != nil {
+			field.FieldDetails.Logger.Printf("[FieldInt64][%#v][%#v] failed to encode value to byte buffer, reason: %s", field.FieldDetails, field.Operation, err)
+			return fmt.Errorf("[FieldInt64][%#v][%#v] failed to encode value to byte buffer, reason: %s", field.FieldDetails, field.Operation, err)
+		}
+	}
+
+	dictionary.SetKeyValue(field.FieldDetails.Key, fixValue)
+	return nil
+}
+
 // GetTagId for this field
 func (field FieldInt64) GetTagId() uint64 {
 	return field.FieldDetails.ID
 }
 
+// GetName of this field, as given in the template
+func (field FieldInt64) GetName() string {
+	return field.FieldDetails.Name
+}
+
+// Keys of the dictionary entry holding the previous value of this field
+func (field FieldInt64) Keys() []dictionary.Key {
+	return []dictionary.Key{field.FieldDetails.Key}
+}
+
 // RequiresPmap returns whether the underlying operation for this field requires a pmap bit being set
 func (field FieldInt64) RequiresPmap() bool {
 	return field.Operation.RequiresPmap(field.FieldDetails.Required)
@@ -74,6 +118,7 @@ func New(properties properties.Properties) FieldInt64 {
 	field := FieldInt64{
 		FieldDetails: properties,
 		decode:       decoder.Int64Decoder{},
+		encode:       encoder.Int64Encoder{},
 		Operation:    operation.None{},
 	}
 
@@ -85,6 +130,7 @@ func NewConstantOperation(properties properties.Properties, constantValue int64)
 	field := FieldInt64{
 		FieldDetails: properties,
 		decode:       decoder.Int64Decoder{},
+		encode:       encoder.Int64Encoder{},
 		Operation: operation.Constant{
 			ConstantValue: fix.NewRawValue(constantValue),
 		},
@@ -98,6 +144,7 @@ func NewDefaultOperation(properties properties.Properties) FieldInt64 {
 	field := FieldInt64{
 		FieldDetails: properties,
 		decode:       decoder.Int64Decoder{},
+		encode:       encoder.Int64Encoder{},
 		Operation: operation.Default{
 			DefaultValue: fix.NullValue{},
 		},
@@ -111,6 +158,7 @@ func NewDefaultOperationWithValue(properties properties.Properties, defaultValue
 	field := FieldInt64{
 		FieldDetails: properties,
 		decode:       decoder.Int64Decoder{},
+		encode:       encoder.Int64Encoder{},
 		Operation: operation.Default{
 			DefaultValue: fix.NewRawValue(defaultValue),
 		},
@@ -124,6 +172,7 @@ func NewCopyOperation(properties properties.Properties) FieldInt64 {
 	field := FieldInt64{
 		FieldDetails: properties,
 		decode:       decoder.Int64Decoder{},
+		encode:       encoder.Int64Encoder{},
 		Operation: operation.Copy{
 			InitialValue: fix.NullValue{},
 		},
@@ -137,6 +186,7 @@ func NewCopyOperationWithInitialValue(properties properties.Properties, initialV
 	field := FieldInt64{
 		FieldDetails: properties,
 		decode:       decoder.Int64Decoder{},
+		encode:       encoder.Int64Encoder{},
 		Operation: operation.Copy{
 			InitialValue: fix.NewRawValue(initialValue),
 		},
@@ -150,6 +200,7 @@ func NewIncrementOperation(properties properties.Properties) FieldInt64 {
 	field := FieldInt64{
 		FieldDetails: properties,
 		decode:       decoder.Int64Decoder{},
+		encode:       encoder.Int64Encoder{},
 		Operation: operation.Increment{
 			InitialValue: fix.NullValue{},
 		},
@@ -163,6 +214,7 @@ func NewIncrementOperationWithInitialValue(properties properties.Properties, ini
 	field := FieldInt64{
 		FieldDetails: properties,
 		decode:       decoder.Int64Decoder{},
+		encode:       encoder.Int64Encoder{},
 		Operation: operation.Increment{
 			InitialValue: fix.NewRawValue(initialValue),
 		},
@@ -176,6 +228,7 @@ func NewDeltaOperation(properties properties.Properties) FieldInt64 {
 	field := FieldInt64{
 		FieldDetails: properties,
 		decode:       decoder.BitIntDecoder{},
+		encode:       encoder.BigIntEncoder{},
 		Operation: operation.Delta{
 			InitialValue: fix.NullValue{},
 			BaseValue:    fix.NewRawValue(int64(0)),
@@ -190,6 +243,7 @@ func NewDeltaOperationWithInitialValue(properties properties.Properties, initial
 	field := FieldInt64{
 		FieldDetails: properties,
 		decode:       decoder.BitIntDecoder{},
+		encode:       encoder.BigIntEncoder{},
 		Operation: operation.Delta{
 			InitialValue: fix.NewRawValue(initialValue),
 			BaseValue:    fix.NewRawValue(int64(0)),
@@ -3,6 +3,7 @@ package fieldsequence
 import (
 	"bytes"
 	"fmt"
+	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
 	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
 	"github.com/Guardian-Development/fastengine/pkg/fast/field/fielduint32"
 	"github.com/Guardian-Development/fastengine/pkg/fast/field/properties"
@@ -17,14 +18,15 @@ type FieldSequence struct {
 	FieldDetails   properties.Properties
 	LengthField    fielduint32.FieldUInt32
 	SequenceFields []store.Unit
+	// GroupLayout of the pmap of each repeating group, computed from the sequence fields by New
+	GroupLayout presencemap.Layout
 }
 
 // Deserialise an <sequence/> from the input source
-func (field FieldSequence) Deserialise(inputSource *bytes.Buffer, pMap *presencemap.PresenceMap, previousValues *dictionary.Dictionary) (fix.Value, error) {
-	numberOfElements, err := field.LengthField.Deserialise(inputSource, pMap, previousValues)
+func (field FieldSequence) Deserialise(inputSource decoder.Reader, pMap *presencemap.PresenceMap, previousValues *dictionary.Dictionary) (fix.Value, error) {
+	numberOfElements, err := field.deserialiseLength(inputSource, pMap, previousValues)
 	if err != nil {
-		field.FieldDetails.Logger.Printf("[FieldSequence][%#v] failed to decode number of elements in sequence from byte buffer, reason: %s", field.FieldDetails, err)
-		return nil, fmt.Errorf("[FieldSequence][%#v] failed to decode number of elements in sequence from byte buffer, reason: %s", field.FieldDetails, err)
+		return nil, err
 	}
 
 	switch t := numberOfElements.(type) {
@@ -39,6 +41,9 @@ func (field FieldSequence) Deserialise(inputSource *bytes.Buffer, pMap *presence
 		sequencePmap := presencemap.PresenceMap{}
 		if field.subFieldsRequirePmap() {
 			sequencePmap, err = presencemap.New(inputSource)
+			if err == nil {
+				err = sequencePmap.Check(inputSource, field.GroupLayout.Bits())
+			}
 			if err != nil {
 				field.FieldDetails.Logger.Printf("[FieldSequence][%#v] failed to decode pmap for repeating group [%d] in sequence, reason: %s", field.FieldDetails, repeatingGroup, err)
 				field.FieldDetails.Logger.Printf("[FieldSequence][%#v] sequence currently decoded before failure %d=%s", field.FieldDetails, field.FieldDetails.ID, sequenceValue.String())
@@ -61,6 +66,118 @@ func (field FieldSequence) Deserialise(inputSource *bytes.Buffer, pMap *presence
 	return sequenceValue, nil
 }
 
+// Visit a <sequence/> from the input source, passing the length of the sequence, the start of each repeating group, and each value within it to the visitor
+func (field FieldSequence) Visit(inputSource decoder.Reader, pMap *presencemap.PresenceMap, previousValues *dictionary.Dictionary, visitor store.Visitor) error {
+	numberOfElements, err := field.deserialiseLength(inputSource, pMap, previousValues)
+	if err != nil {
+		return err
+	}
+
+	switch t := numberOfElements.(type) {
+	case fix.NullValue:
+		visitor.OnField(field.FieldDetails.ID, field.FieldDetails.Name, t)
+		return nil
+	}
+
+	numberOfRepeatingGroups := numberOfElements.Get().(uint32)
+	visitor.OnSequenceStart(field.FieldDetails.ID, numberOfRepeatingGroups)
+
+	for repeatingGroup := uint32(0); repeatingGroup < numberOfRepeatingGroups; repeatingGroup++ {
+		sequencePmap := presencemap.PresenceMap{}
+		if field.subFieldsRequirePmap() {
+			sequencePmap, err = presencemap.New(inputSource)
+			if err == nil {
+				err = sequencePmap.Check(inputSource, field.GroupLayout.Bits())
+			}
+			if err != nil {
+				field.FieldDetails.Logger.Printf("[FieldSequence][%#v] failed to decode pmap for repeating group [%d] in sequence, reason: %s", field.FieldDetails, repeatingGroup, err)
+				return fmt.Errorf("[FieldSequence][%#v] failed to decode pmap for repeating group [%d] in sequence, reason: %s", field.FieldDetails, repeatingGroup, err)
+			}
+		}
+
+		visitor.OnGroupStart(repeatingGroup)
+		for _, element := range field.SequenceFields {
+			if err := store.Visit(element, inputSource, &sequencePmap, previousValues, visitor); err != nil {
+				field.FieldDetails.Logger.Printf("[FieldSequence][%#v] failed to decode element for repeating group [%d] in sequence, reason: %s", field.FieldDetails, repeatingGroup, err)
+				return fmt.Errorf("[FieldSequence][%#v] failed to decode element for repeating group [%d] in sequence, reason: %s", field.FieldDetails, repeatingGroup, err)
+			}
+		}
+	}
+
+	visitor.OnSequenceEnd()
+	return nil
+}
+
+// deserialiseLength of the sequence, checking it is within the maximum sequence length of the input source before any repeating groups are read
+func (field FieldSequence) deserialiseLength(inputSource decoder.Reader, pMap *presencemap.PresenceMap, previousValues *dictionary.Dictionary) (fix.Value, error) {
+	numberOfElements, err := field.LengthField.Deserialise(inputSource, pMap, previousValues)
+	if err != nil {
+		field.FieldDetails.Logger.Printf("[FieldSequence][%#v] failed to decode number of elements in sequence from byte buffer, reason: %s", field.FieldDetails, err)
+		return nil, fmt.Errorf("[FieldSequence][%#v] failed to decode number of elements in sequence from byte buffer, reason: %s", field.FieldDetails, err)
+	}
+
+	switch numberOfElements.(type) {
+	case fix.NullValue:
+		return numberOfElements, nil
+	}
+
+	numberOfRepeatingGroups := numberOfElements.Get().(uint32)
+	if maxLength := decoder.SettingsOf(inputSource).MaxSequenceLength; maxLength > 0 && numberOfRepeatingGroups > maxLength {
+		field.FieldDetails.Logger.Printf("[FieldSequence][%#v] sequence of %d repeating groups exceeds the maximum sequence length of %d", field.FieldDetails, numberOfRepeatingGroups, maxLength)
+		return nil, fmt.Errorf("[FieldSequence][%#v] sequence of %d repeating groups exceeds the maximum sequence length of %d", field.FieldDetails, numberOfRepeatingGroups, maxLength)
+	}
+
+	return numberOfElements, nil
+}
+
+// Serialise a <sequence/> to the output source. Each repeating group is written with its own pmap, if any of the sequence fields require one.
+func (field FieldSequence) Serialise(outputSource *bytes.Buffer, pMap *presencemap.PresenceMap, previousValues *dictionary.Dictionary, fixValue fix.Value) error {
+	var numberOfElements fix.Value
+	var repeatingGroups []fix.Message
+
+	switch t := fixValue.(type) {
+	case fix.NullValue:
+		numberOfElements = t
+	case fix.SequenceValue:
+		repeatingGroups = t.Values
+		numberOfElements = fix.NewRawValue(uint32(len(repeatingGroups)))
+	default:
+		field.FieldDetails.Logger.Printf("[FieldSequence][%#v] value to encode was not a sequence: %#v", field.FieldDetails, fixValue)
+		return fmt.Errorf("[FieldSequence][%#v] value to encode was not a sequence: %#v", field.FieldDetails, fixValue)
+	}
+
+	err := field.LengthField.Serialise(outputSource, pMap, previousValues, numberOfElements)
+	if err != nil {
+		field.FieldDetails.Logger.Printf("[FieldSequence][%#v] failed to encode number of elements in sequence to byte buffer, reason: %s", field.FieldDetails, err)
+		return fmt.Errorf("[FieldSequence][%#v] failed to encode number of elements in sequence to byte buffer, reason: %s", field.FieldDetails, err)
+	}
+
+	for repeatingGroup, groupValues := range repeatingGroups {
+		sequencePmap := presencemap.PresenceMap{}
+		sequenceBody := bytes.Buffer{}
+
+		for _, element := range field.SequenceFields {
+			value, exists := groupValues.Tags[element.GetTagId()]
+			if !exists {
+				value = fix.NullValue{}
+			}
+
+			err := element.Serialise(&sequenceBody, &sequencePmap, previousValues, value)
+			if err != nil {
+				field.FieldDetails.Logger.Printf("[FieldSequence][%#v] failed to encode element for repeating group [%d] in sequence, reason: %s", field.FieldDetails, repeatingGroup, err)
+				return fmt.Errorf("[FieldSequence][%#v] failed to encode element for repeating group [%d] in sequence, reason: %s", field.FieldDetails, repeatingGroup, err)
+			}
+		}
+
+		if field.subFieldsRequirePmap() {
+			outputSource.Write(sequencePmap.Bytes())
+		}
+		outputSource.Write(sequenceBody.Bytes())
+	}
+
+	return nil
+}
+
 func (field FieldSequence) subFieldsRequirePmap() bool {
 	for _, element := range field.SequenceFields {
 		if element.RequiresPmap() {
@@ -76,6 +193,21 @@ func (field FieldSequence) GetTagId() uint64 {
 	return field.FieldDetails.ID
 }
 
+// GetName of this field, as given in the template
+func (field FieldSequence) GetName() string {
+	return field.FieldDetails.Name
+}
+
+// Keys of the dictionary entry holding the previous length of this sequence, the keys of the units within it are the keys of those units
+func (field FieldSequence) Keys() []dictionary.Key {
+	return field.LengthField.Keys()
+}
+
+// Units within each repeating group of this sequence
+func (field FieldSequence) Units() []store.Unit {
+	return field.SequenceFields
+}
+
 // RequiresPmap returns whether the length element for this sequence requires a pmap
 func (field FieldSequence) RequiresPmap() bool {
 	return field.LengthField.RequiresPmap()
@@ -87,6 +219,7 @@ func New(properties properties.Properties, length fielduint32.FieldUInt32, seque
 		FieldDetails:   properties,
 		LengthField:    length,
 		SequenceFields: sequenceFields,
+		GroupLayout:    store.LayoutOf(sequenceFields),
 	}
 
 	return field
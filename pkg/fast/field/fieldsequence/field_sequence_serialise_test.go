@@ -0,0 +1,140 @@
+package fieldsequence
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/fieldasciistring"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/fieldint64"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/fielduint32"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/properties"
+	"github.com/Guardian-Development/fastengine/pkg/fast/presencemap"
+	"github.com/Guardian-Development/fastengine/pkg/fast/template/store"
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+//<sequence id="1">
+//	<length />
+// 	<int64 id="2"/>
+// 	<string id="3"/>
+//</sequence>
+func TestCanSeraliseRequiredSequenceOfLengthTwo(t *testing.T) {
+	// Arrange length(2) = 10000010 int64(2) = 10000010 string(ABC) = 01000001 01000010 11000011 int64(3) = 10000011 string(DEF) = 01000100 01000101 11000110
+	outputSource := bytes.Buffer{}
+	pmap := presencemap.PresenceMap{}
+	dict := dictionary.New()
+	expectedBytes := []byte{130, 130, 65, 66, 195, 131, 68, 69, 198}
+	toWrite := fix.NewSequenceValue(2)
+	toWrite.SetValue(0, 2, fix.NewRawValue(int64(2)))
+	toWrite.SetValue(0, 3, fix.NewRawValue("ABC"))
+	toWrite.SetValue(1, 2, fix.NewRawValue(int64(3)))
+	toWrite.SetValue(1, 3, fix.NewRawValue("DEF"))
+	unitUnderTest := New(
+		properties.New(1, "SequenceField", true, testLog),
+		fielduint32.New(properties.New(1, "SequenceField", true, testLog)),
+		[]store.Unit{
+			fieldint64.New(properties.New(2, "Int64Field", true, testLog)),
+			fieldasciistring.New(properties.New(3, "AsciiStringField", true, testLog)),
+		})
+
+	// Act
+	err := unitUnderTest.Serialise(&outputSource, &pmap, &dict, toWrite)
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+
+	// Assert
+	if !reflect.DeepEqual(expectedBytes, outputSource.Bytes()) {
+		t.Errorf("Expected bytes and serialised bytes were not equal, expected: %#v, actual: %#v", expectedBytes, outputSource.Bytes())
+	}
+}
+
+//<sequence id="1">
+//	<length />
+// 	<int64 id="2">
+//		<copy />
+//	</int64>
+//</sequence>
+func TestCanSeraliseRequiredSequenceWithPmapPerRepeatingGroup(t *testing.T) {
+	// Arrange length(2) = 10000010 pmap = 11000000 int64(2) = 10000010 pmap = 10000000
+	outputSource := bytes.Buffer{}
+	pmap := presencemap.PresenceMap{}
+	dict := dictionary.New()
+	expectedBytes := []byte{130, 192, 130, 128}
+	toWrite := fix.NewSequenceValue(2)
+	toWrite.SetValue(0, 2, fix.NewRawValue(int64(2)))
+	toWrite.SetValue(1, 2, fix.NewRawValue(int64(2)))
+	unitUnderTest := New(
+		properties.New(1, "SequenceField", true, testLog),
+		fielduint32.New(properties.New(1, "SequenceField", true, testLog)),
+		[]store.Unit{
+			fieldint64.NewCopyOperation(properties.New(2, "Int64Field", true, testLog)),
+		})
+
+	// Act
+	err := unitUnderTest.Serialise(&outputSource, &pmap, &dict, toWrite)
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+
+	// Assert
+	if !reflect.DeepEqual(expectedBytes, outputSource.Bytes()) {
+		t.Errorf("Expected bytes and serialised bytes were not equal, expected: %#v, actual: %#v", expectedBytes, outputSource.Bytes())
+	}
+}
+
+//<sequence id="1" presence="optional">
+//	<length />
+// 	<int64 id="2"/>
+//</sequence>
+func TestCanSeraliseOptionalSequenceNull(t *testing.T) {
+	// Arrange length(nil) = 10000000
+	outputSource := bytes.Buffer{}
+	pmap := presencemap.PresenceMap{}
+	dict := dictionary.New()
+	expectedBytes := []byte{128}
+	unitUnderTest := New(
+		properties.New(1, "SequenceField", false, testLog),
+		fielduint32.New(properties.New(1, "SequenceField", false, testLog)),
+		[]store.Unit{
+			fieldint64.New(properties.New(2, "Int64Field", true, testLog)),
+		})
+
+	// Act
+	err := unitUnderTest.Serialise(&outputSource, &pmap, &dict, fix.NullValue{})
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+
+	// Assert
+	if !reflect.DeepEqual(expectedBytes, outputSource.Bytes()) {
+		t.Errorf("Expected bytes and serialised bytes were not equal, expected: %#v, actual: %#v", expectedBytes, outputSource.Bytes())
+	}
+}
+
+//<sequence id="1">
+//	<length />
+// 	<int64 id="2"/>
+//</sequence>
+func TestSeraliseRequiredSequenceReturnsErrorForNonSequenceValue(t *testing.T) {
+	// Arrange
+	outputSource := bytes.Buffer{}
+	pmap := presencemap.PresenceMap{}
+	dict := dictionary.New()
+	unitUnderTest := New(
+		properties.New(1, "SequenceField", true, testLog),
+		fielduint32.New(properties.New(1, "SequenceField", true, testLog)),
+		[]store.Unit{
+			fieldint64.New(properties.New(2, "Int64Field", true, testLog)),
+		})
+
+	// Act
+	err := unitUnderTest.Serialise(&outputSource, &pmap, &dict, fix.NewRawValue(int64(2)))
+
+	// Assert
+	if err == nil {
+		t.Errorf("Expected error serialising a non sequence value, but got none")
+	}
+}
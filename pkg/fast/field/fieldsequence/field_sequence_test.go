@@ -2,6 +2,7 @@ package fieldsequence
 
 import (
 	"bytes"
+	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
 	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
 	"github.com/Guardian-Development/fastengine/pkg/fast/field/fieldasciistring"
 	"github.com/Guardian-Development/fastengine/pkg/fast/field/fieldint64"
@@ -11,6 +12,7 @@ import (
 	"log"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/Guardian-Development/fastengine/pkg/fast/template/store"
@@ -434,3 +436,70 @@ func TestRequiresPmapReturnsFalseForOptionalSequenceWithNoLengthOperator(t *test
 		t.Errorf("Expected RequiresPmap to return false, but got true")
 	}
 }
+
+//<sequence id="1">
+//	<length />
+// 	<int64 id="2"/>
+// 	<string id="3"/>
+//</sequence>
+func TestRequiredSequenceLongerThanMaxSequenceLengthReturnsError(t *testing.T) {
+	// Arrange length(2) = 10000010
+	// 1: int64 = 10000011	string(TEST1) = 01010100 01000101 01010011 01010100 10110001
+	// 2: int64 = 10000010	string(TEST2) = 01010100 01000101 01010011 01010100 10110010
+	messageAsBytes := &decoder.SettingsReader{
+		Reader:   bytes.NewBuffer([]byte{130, 131, 84, 69, 83, 84, 177, 130, 84, 69, 83, 84, 178}),
+		Settings: decoder.Settings{MaxSequenceLength: 1},
+	}
+	pmap, _ := presencemap.New(bytes.NewBuffer([]byte{128}))
+	dict := dictionary.New()
+	unitUnderTest := New(
+		properties.New(1, "SequenceField", true, testLog),
+		fielduint32.New(properties.New(1, "SequenceField", true, testLog)),
+		[]store.Unit{
+			fieldint64.New(properties.New(2, "Int64Field", true, testLog)),
+			fieldasciistring.New(properties.New(3, "AsciiStringField", true, testLog)),
+		})
+
+	// Act
+	_, err := unitUnderTest.Deserialise(messageAsBytes, &pmap, &dict)
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "exceeds the maximum sequence length of 1") {
+		t.Errorf("Expected error about the sequence exceeding the maximum sequence length, but got: %v", err)
+	}
+}
+
+//<sequence id="1">
+//	<length />
+// 	<int64 id="2"/>
+// 	<string id="3"/>
+//</sequence>
+func TestCanDeseraliseRequiredSequenceOfMaxSequenceLength(t *testing.T) {
+	// Arrange length(2) = 10000010
+	// 1: int64 = 10000011	string(TEST1) = 01010100 01000101 01010011 01010100 10110001
+	// 2: int64 = 10000010	string(TEST2) = 01010100 01000101 01010011 01010100 10110010
+	messageAsBytes := &decoder.SettingsReader{
+		Reader:   bytes.NewBuffer([]byte{130, 131, 84, 69, 83, 84, 177, 130, 84, 69, 83, 84, 178}),
+		Settings: decoder.Settings{MaxSequenceLength: 2},
+	}
+	pmap, _ := presencemap.New(bytes.NewBuffer([]byte{128}))
+	dict := dictionary.New()
+	unitUnderTest := New(
+		properties.New(1, "SequenceField", true, testLog),
+		fielduint32.New(properties.New(1, "SequenceField", true, testLog)),
+		[]store.Unit{
+			fieldint64.New(properties.New(2, "Int64Field", true, testLog)),
+			fieldasciistring.New(properties.New(3, "AsciiStringField", true, testLog)),
+		})
+
+	// Act
+	result, err := unitUnderTest.Deserialise(messageAsBytes, &pmap, &dict)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+	if sequence, ok := result.(fix.SequenceValue); !ok || len(sequence.Values) != 2 {
+		t.Errorf("Expected a sequence of two repeating groups, actual: %v", result)
+	}
+}
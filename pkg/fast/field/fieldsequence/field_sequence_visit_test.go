@@ -0,0 +1,108 @@
+package fieldsequence
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/fieldasciistring"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/fieldint64"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/fielduint32"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/properties"
+	"github.com/Guardian-Development/fastengine/pkg/fast/presencemap"
+	"github.com/Guardian-Development/fastengine/pkg/fast/template/store"
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+type recordingVisitor struct {
+	events []string
+}
+
+func (visitor *recordingVisitor) OnField(tag uint64, name string, value fix.Value) {
+	visitor.events = append(visitor.events, fmt.Sprintf("field %d %s %s", tag, name, value.String()))
+}
+
+func (visitor *recordingVisitor) OnSequenceStart(tag uint64, length uint32) {
+	visitor.events = append(visitor.events, fmt.Sprintf("sequence %d %d", tag, length))
+}
+
+func (visitor *recordingVisitor) OnGroupStart(index uint32) {
+	visitor.events = append(visitor.events, fmt.Sprintf("group %d", index))
+}
+
+func (visitor *recordingVisitor) OnSequenceEnd() {
+	visitor.events = append(visitor.events, "end")
+}
+
+//<sequence id="1">
+//	<length />
+// 	<int64 id="2"/>
+// 	<string id="3"/>
+//</sequence>
+func TestCanVisitRequiredSequenceOfLengthTwo(t *testing.T) {
+	// Arrange length(2) = 10000010
+	// 1: int64 = 10000011	string(TEST1) = 01010100 01000101 01010011 01010100 10110001
+	// 2: int64 = 10000010	string(TEST2) = 01010100 01000101 01010011 01010100 10110010
+	messageAsBytes := bytes.NewBuffer([]byte{130, 131, 84, 69, 83, 84, 177, 130, 84, 69, 83, 84, 178})
+	pmap, _ := presencemap.New(bytes.NewBuffer([]byte{128}))
+	dict := dictionary.New()
+	visitor := &recordingVisitor{}
+	expectedEvents := []string{
+		"sequence 1 2",
+		"group 0", "field 2 Int64Field 3|", "field 3 AsciiStringField TEST1|",
+		"group 1", "field 2 Int64Field 2|", "field 3 AsciiStringField TEST2|",
+		"end",
+	}
+	unitUnderTest := New(
+		properties.New(1, "SequenceField", true, testLog),
+		fielduint32.New(properties.New(1, "SequenceField", true, testLog)),
+		[]store.Unit{
+			fieldint64.New(properties.New(2, "Int64Field", true, testLog)),
+			fieldasciistring.New(properties.New(3, "AsciiStringField", true, testLog)),
+		})
+
+	// Act
+	err := unitUnderTest.Visit(messageAsBytes, &pmap, &dict, visitor)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+	if !reflect.DeepEqual(expectedEvents, visitor.events) {
+		t.Errorf("Expected events and visited events were not equal, expected: %v, actual: %v", expectedEvents, visitor.events)
+	}
+}
+
+//<sequence presence="optional">
+//	<length />
+// 	<int64 id="2"/>
+// 	<string id="3"/>
+//</sequence>
+func TestCanVisitOptionalSequenceNull(t *testing.T) {
+	// Arrange length = 10000000
+	messageAsBytes := bytes.NewBuffer([]byte{128})
+	pmap, _ := presencemap.New(bytes.NewBuffer([]byte{128}))
+	dict := dictionary.New()
+	visitor := &recordingVisitor{}
+	expectedEvents := []string{"field 1 SequenceField nil|"}
+	unitUnderTest := New(
+		properties.New(1, "SequenceField", false, testLog),
+		fielduint32.New(properties.New(1, "SequenceField", false, testLog)),
+		[]store.Unit{
+			fieldint64.New(properties.New(2, "Int64Field", true, testLog)),
+			fieldasciistring.New(properties.New(3, "AsciiStringField", true, testLog)),
+		})
+
+	// Act
+	err := unitUnderTest.Visit(messageAsBytes, &pmap, &dict, visitor)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+	if !reflect.DeepEqual(expectedEvents, visitor.events) {
+		t.Errorf("Expected events and visited events were not equal, expected: %v, actual: %v", expectedEvents, visitor.events)
+	}
+}
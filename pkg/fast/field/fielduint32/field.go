@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
 	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
+	"github.com/Guardian-Development/fastengine/pkg/fast/encoder"
 	"github.com/Guardian-Development/fastengine/pkg/fast/field/properties"
 	"github.com/Guardian-Development/fastengine/pkg/fast/operation"
 	"github.com/Guardian-Development/fastengine/pkg/fast/presencemap"
@@ -19,11 +20,12 @@ type FieldUInt32 struct {
 	Operation    operation.Operation
 
 	decode decoder.Decoder
+	encode encoder.Encoder
 }
 
 // Deserialise an <uint32/> from the input source
-func (field FieldUInt32) Deserialise(inputSource *bytes.Buffer, pMap *presencemap.PresenceMap, dictionary *dictionary.Dictionary) (fix.Value, error) {
-	previousValue := dictionary.GetValue(field.FieldDetails.Name)
+func (field FieldUInt32) Deserialise(inputSource decoder.Reader, pMap *presencemap.PresenceMap, dictionary *dictionary.Dictionary) (fix.Value, error) {
+	previousValue := dictionary.GetKeyValue(field.FieldDetails.Key)
 	if field.Operation.ShouldReadValue(pMap) {
 		var readValue value.Value
 		var err error
@@ -45,7 +47,7 @@ func (field FieldUInt32) Deserialise(inputSource *bytes.Buffer, pMap *presencema
 			return nil, fmt.Errorf("[FieldUInt32][%#v][%#v] failed to apply operation with readValue %#v, previousValue: %#v, reason: %s", field.FieldDetails, field.Operation, readValue, previousValue, err)
 		}
 
-		dictionary.SetValue(field.FieldDetails.Name, transformedValue)
+		dictionary.SetKeyValue(field.FieldDetails.Key, transformedValue)
 		return transformedValue, nil
 	}
 
@@ -55,15 +57,57 @@ func (field FieldUInt32) Deserialise(inputSource *bytes.Buffer, pMap *presencema
 		return nil, fmt.Errorf("[FieldUInt32][%#v][%#v] failed to get value for field when not encoded in message, reason: %s", field.FieldDetails, field.Operation, err)
 	}
 
-	dictionary.SetValue(field.FieldDetails.Name, transformedValue)
+	dictionary.SetKeyValue(field.FieldDetails.Key, transformedValue)
 	return transformedValue, nil
 }
 
+// Serialise a <uint32/> to the output source
+func (field FieldUInt32) Serialise(outputSource *bytes.Buffer, pMap *presencemap.PresenceMap, dictionary *dictionary.Dictionary, fixValue fix.Value) error {
+	previousValue := dictionary.GetKeyValue(field.FieldDetails.Key)
+	shouldWrite, err := field.Operation.ShouldWriteValue(pMap, field.FieldDetails.Required, fixValue, previousValue)
+	if err != nil {
+		field.FieldDetails.Logger.Printf("[FieldUInt32][%#v][%#v] failed to evaluate whether to encode value %#v, previousValue: %#v, reason: %s", field.FieldDetails, field.Operation, fixValue, previousValue, err)
+		return fmt.Errorf("[FieldUInt32][%#v][%#v] failed to evaluate whether to encode value %#v, previousValue: %#v, reason: %s", field.FieldDetails, field.Operation, fixValue, previousValue, err)
+	}
+
+	if shouldWrite {
+		writeValue, err := field.Operation.GetEncodedValue(fixValue, previousValue)
+		if err != nil {
+			field.FieldDetails.Logger.Printf("[FieldUInt32][%#v][%#v] failed to apply operation with value %#v, previousValue: %#v, reason: %s", field.FieldDetails, field.Operation, fixValue, previousValue, err)
+			return fmt.Errorf("[FieldUInt32][%#v][%#v] failed to apply operation with value %#v, previousValue: %#v, reason: %s", field.FieldDetails, field.Operation, fixValue, previousValue, err)
+		}
+
+		if field.FieldDetails.Required {
+			err = field.encode.WriteValue(outputSource, writeValue)
+		} else {
+			err = field.encode.WriteOptionalValue(outputSource, writeValue)
+		}
+
+		if err != nil {
+			field.FieldDetails.Logger.Printf("[FieldUInt32][%#v][%#v] failed to encode value to byte buffer, reason: %s", field.FieldDetails, field.Operation, err)
+			return fmt.Errorf("[FieldUInt32][%#v][%#v] failed to encode value to byte buffer, reason: %s", field.FieldDetails, field.Operation, err)
+		}
+	}
+
+	dictionary.SetKeyValue(field.FieldDetails.Key, fixValue)
+	return nil
+}
+
 // GetTagId for this field
 func (field FieldUInt32) GetTagId() uint64 {
 	return field.FieldDetails.ID
 }
 
+// GetName of this field, as given in the template
+func (field FieldUInt32) GetName() string {
+	return field.FieldDetails.Name
+}
+
+// Keys of the dictionary entry holding the previous value of this field
+func (field FieldUInt32) Keys() []dictionary.Key {
+	return []dictionary.Key{field.FieldDetails.Key}
+}
+
 // RequiresPmap returns whether the underlying operation for this field requires a pmap bit being set
 func (field FieldUInt32) RequiresPmap() bool {
 	return field.Operation.RequiresPmap(field.FieldDetails.Required)
@@ -74,6 +118,7 @@ func New(properties properties.Properties) FieldUInt32 {
 	field := FieldUInt32{
 		FieldDetails: properties,
 		decode:       decoder.UInt32Decoder{},
+		encode:       encoder.UInt32Encoder{},
 		Operation:    operation.None{},
 	}
 
@@ -85,6 +130,7 @@ func NewConstantOperation(properties properties.Properties, constantValue uint32
 	field := FieldUInt32{
 		FieldDetails: properties,
 		decode:       decoder.UInt32Decoder{},
+		encode:       encoder.UInt32Encoder{},
 		Operation: operation.Constant{
 			ConstantValue: fix.NewRawValue(constantValue),
 		},
@@ -98,6 +144,7 @@ func NewDefaultOperation(properties properties.Properties) FieldUInt32 {
 	field := FieldUInt32{
 		FieldDetails: properties,
 		decode:       decoder.UInt32Decoder{},
+		encode:       encoder.UInt32Encoder{},
 		Operation: operation.Default{
 			DefaultValue: fix.NullValue{},
 		},
@@ -111,6 +158,7 @@ func NewDefaultOperationWithValue(properties properties.Properties, defaultValue
 	field := FieldUInt32{
 		FieldDetails: properties,
 		decode:       decoder.UInt32Decoder{},
+		encode:       encoder.UInt32Encoder{},
 		Operation: operation.Default{
 			DefaultValue: fix.NewRawValue(defaultValue),
 		},
@@ -124,6 +172,7 @@ func NewCopyOperation(properties properties.Properties) FieldUInt32 {
 	field := FieldUInt32{
 		FieldDetails: properties,
 		decode:       decoder.UInt32Decoder{},
+		encode:       encoder.UInt32Encoder{},
 		Operation: operation.Copy{
 			InitialValue: fix.NullValue{},
 		},
@@ -137,6 +186,7 @@ func NewCopyOperationWithInitialValue(properties properties.Properties, initialV
 	field := FieldUInt32{
 		FieldDetails: properties,
 		decode:       decoder.UInt32Decoder{},
+		encode:       encoder.UInt32Encoder{},
 		Operation: operation.Copy{
 			InitialValue: fix.NewRawValue(initialValue),
 		},
@@ -150,6 +200,7 @@ func NewIncrementOperation(properties properties.Properties) FieldUInt32 {
 	field := FieldUInt32{
 		FieldDetails: properties,
 		decode:       decoder.UInt32Decoder{},
+		encode:       encoder.UInt32Encoder{},
 		Operation: operation.Increment{
 			InitialValue: fix.NullValue{},
 		},
@@ -163,6 +214,7 @@ func NewIncrementOperationWithInitialValue(properties properties.Properties, ini
 	field := FieldUInt32{
 		FieldDetails: properties,
 		decode:       decoder.UInt32Decoder{},
+		encode:       encoder.UInt32Encoder{},
 		Operation: operation.Increment{
 			InitialValue: fix.NewRawValue(initialValue),
 		},
@@ -176,6 +228,7 @@ func NewDeltaOperation(properties properties.Properties) FieldUInt32 {
 	field := FieldUInt32{
 		FieldDetails: properties,
 		decode:       decoder.Int64Decoder{},
+		encode:       encoder.Int64Encoder{},
 		Operation: operation.Delta{
 			InitialValue: fix.NullValue{},
 			BaseValue:    fix.NewRawValue(uint32(0)),
@@ -190,6 +243,7 @@ func NewDeltaOperationWithInitialValue(properties properties.Properties, initial
 	field := FieldUInt32{
 		FieldDetails: properties,
 		decode:       decoder.Int64Decoder{},
+		encode:       encoder.Int64Encoder{},
 		Operation: operation.Delta{
 			InitialValue: fix.NewRawValue(initialValue),
 			BaseValue:    fix.NewRawValue(uint32(0)),
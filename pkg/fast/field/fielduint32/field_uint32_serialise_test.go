@@ -0,0 +1,216 @@
+package fielduint32
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/properties"
+	"github.com/Guardian-Development/fastengine/pkg/fast/presencemap"
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+//<uInt32 />
+func TestCanSeraliseRequiredUInt32(t *testing.T) {
+	// Arrange 10 = 10001010
+	outputSource := bytes.Buffer{}
+	pmap := presencemap.PresenceMap{}
+	dict := dictionary.New()
+	expectedBytes := []byte{138}
+	unitUnderTest := New(properties.New(1, "UInt32Field", true, testLog))
+
+	// Act
+	err := unitUnderTest.Serialise(&outputSource, &pmap, &dict, fix.NewRawValue(uint32(10)))
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+
+	// Assert
+	if !reflect.DeepEqual(expectedBytes, outputSource.Bytes()) {
+		t.Errorf("Expected bytes and serialised bytes were not equal, expected: %#v, actual: %#v", expectedBytes, outputSource.Bytes())
+	}
+}
+
+//<uInt32 presence="optional"/>
+func TestCanSeraliseOptionalUInt32Null(t *testing.T) {
+	// Arrange nil = 10000000
+	outputSource := bytes.Buffer{}
+	pmap := presencemap.PresenceMap{}
+	dict := dictionary.New()
+	expectedBytes := []byte{128}
+	unitUnderTest := New(properties.New(1, "UInt32Field", false, testLog))
+
+	// Act
+	err := unitUnderTest.Serialise(&outputSource, &pmap, &dict, fix.NullValue{})
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+
+	// Assert
+	if !reflect.DeepEqual(expectedBytes, outputSource.Bytes()) {
+		t.Errorf("Expected bytes and serialised bytes were not equal, expected: %#v, actual: %#v", expectedBytes, outputSource.Bytes())
+	}
+}
+
+//<uInt32>
+//	<copy value="12"/>
+//</uInt32>
+func TestCanSeraliseRequiredUInt32CopyOperatorMatchingInitialValueIsNotEncoded(t *testing.T) {
+	// Arrange pmap = 10000000
+	outputSource := bytes.Buffer{}
+	pmap := presencemap.PresenceMap{}
+	dict := dictionary.New()
+	expectedPmap := []byte{128}
+	unitUnderTest := NewCopyOperationWithInitialValue(properties.New(1, "UInt32Field", true, testLog), 12)
+
+	// Act
+	err := unitUnderTest.Serialise(&outputSource, &pmap, &dict, fix.NewRawValue(uint32(12)))
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+
+	// Assert
+	if outputSource.Len() != 0 {
+		t.Errorf("Expected no bytes to be serialised, actual: %#v", outputSource.Bytes())
+	}
+	if !reflect.DeepEqual(expectedPmap, pmap.Bytes()) {
+		t.Errorf("Expected pmap and serialised pmap were not equal, expected: %#v, actual: %#v", expectedPmap, pmap.Bytes())
+	}
+}
+
+//<uInt32>
+//	<copy value="12"/>
+//</uInt32>
+func TestCanSeraliseRequiredUInt32CopyOperatorDifferentToPreviousValueIsEncoded(t *testing.T) {
+	// Arrange pmap = 11000000 7 = 10000111
+	outputSource := bytes.Buffer{}
+	pmap := presencemap.PresenceMap{}
+	dict := dictionary.New()
+	dict.SetValue("UInt32Field", fix.NewRawValue(uint32(12)))
+	expectedBytes := []byte{135}
+	expectedPmap := []byte{192}
+	unitUnderTest := NewCopyOperationWithInitialValue(properties.New(1, "UInt32Field", true, testLog), 12)
+
+	// Act
+	err := unitUnderTest.Serialise(&outputSource, &pmap, &dict, fix.NewRawValue(uint32(7)))
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+
+	// Assert
+	if !reflect.DeepEqual(expectedBytes, outputSource.Bytes()) {
+		t.Errorf("Expected bytes and serialised bytes were not equal, expected: %#v, actual: %#v", expectedBytes, outputSource.Bytes())
+	}
+	if !reflect.DeepEqual(expectedPmap, pmap.Bytes()) {
+		t.Errorf("Expected pmap and serialised pmap were not equal, expected: %#v, actual: %#v", expectedPmap, pmap.Bytes())
+	}
+}
+
+//<uInt32>
+//	<increment />
+//</uInt32>
+func TestCanSeraliseRequiredUInt32IncrementOperatorOneMoreThanPreviousValueIsNotEncoded(t *testing.T) {
+	// Arrange pmap = 10000000
+	outputSource := bytes.Buffer{}
+	pmap := presencemap.PresenceMap{}
+	dict := dictionary.New()
+	dict.SetValue("UInt32Field", fix.NewRawValue(uint32(5)))
+	expectedPmap := []byte{128}
+	unitUnderTest := NewIncrementOperation(properties.New(1, "UInt32Field", true, testLog))
+
+	// Act
+	err := unitUnderTest.Serialise(&outputSource, &pmap, &dict, fix.NewRawValue(uint32(6)))
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+
+	// Assert
+	if outputSource.Len() != 0 {
+		t.Errorf("Expected no bytes to be serialised, actual: %#v", outputSource.Bytes())
+	}
+	if !reflect.DeepEqual(expectedPmap, pmap.Bytes()) {
+		t.Errorf("Expected pmap and serialised pmap were not equal, expected: %#v, actual: %#v", expectedPmap, pmap.Bytes())
+	}
+}
+
+//<uInt32>
+//	<delta />
+//</uInt32>
+func TestCanSeraliseRequiredUInt32DeltaOperatorWritesDifferenceToPreviousValue(t *testing.T) {
+	// Arrange -3 = 11111101
+	outputSource := bytes.Buffer{}
+	pmap := presencemap.PresenceMap{}
+	dict := dictionary.New()
+	dict.SetValue("UInt32Field", fix.NewRawValue(uint32(10)))
+	expectedBytes := []byte{253}
+	unitUnderTest := NewDeltaOperation(properties.New(1, "UInt32Field", true, testLog))
+
+	// Act
+	err := unitUnderTest.Serialise(&outputSource, &pmap, &dict, fix.NewRawValue(uint32(7)))
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+
+	// Assert
+	if !reflect.DeepEqual(expectedBytes, outputSource.Bytes()) {
+		t.Errorf("Expected bytes and serialised bytes were not equal, expected: %#v, actual: %#v", expectedBytes, outputSource.Bytes())
+	}
+}
+
+//<uInt32>
+//	<constant value="132"/>
+//</uInt32>
+func TestSeraliseRequiredUInt32ConstantOperatorReturnsErrorIfValueDoesNotMatchConstant(t *testing.T) {
+	// Arrange
+	outputSource := bytes.Buffer{}
+	pmap := presencemap.PresenceMap{}
+	dict := dictionary.New()
+	unitUnderTest := NewConstantOperation(properties.New(1, "UInt32Field", true, testLog), 132)
+
+	// Act
+	err := unitUnderTest.Serialise(&outputSource, &pmap, &dict, fix.NewRawValue(uint32(131)))
+
+	// Assert
+	if err == nil {
+		t.Errorf("Expected error serialising value that does not match constant, but got none")
+	}
+}
+
+func TestSeralisedUInt32OperatorsCanBeDeserialised(t *testing.T) {
+	values := []fix.Value{fix.NewRawValue(uint32(1)), fix.NewRawValue(uint32(1)), fix.NewRawValue(uint32(2)), fix.NullValue{}, fix.NewRawValue(uint32(4294967295)), fix.NewRawValue(uint32(0))}
+	cases := []FieldUInt32{
+		New(properties.New(1, "UInt32Field", false, testLog)),
+		NewDefaultOperationWithValue(properties.New(1, "UInt32Field", false, testLog), 1),
+		NewCopyOperationWithInitialValue(properties.New(1, "UInt32Field", false, testLog), 1),
+		NewIncrementOperationWithInitialValue(properties.New(1, "UInt32Field", false, testLog), 1),
+		NewDeltaOperationWithInitialValue(properties.New(1, "UInt32Field", false, testLog), 1),
+	}
+
+	for _, unitUnderTest := range cases {
+		// Arrange
+		outputSource := bytes.Buffer{}
+		writePmap := presencemap.PresenceMap{}
+		writeDict := dictionary.New()
+		for _, toWrite := range values {
+			if err := unitUnderTest.Serialise(&outputSource, &writePmap, &writeDict, toWrite); err != nil {
+				t.Errorf("Got an error serialising %#v when none was expected: %s", toWrite, err)
+			}
+		}
+
+		// Act
+		readPmap, _ := presencemap.New(bytes.NewBuffer(writePmap.Bytes()))
+		readDict := dictionary.New()
+		for _, expected := range values {
+			result, err := unitUnderTest.Deserialise(&outputSource, &readPmap, &readDict)
+
+			// Assert
+			if err != nil {
+				t.Errorf("Got an error deserialising %#v when none was expected: %s", expected, err)
+			}
+			if !reflect.DeepEqual(expected.Get(), result.Get()) {
+				t.Errorf("Expected value and deserialised value were not equal, operation: %#v, expected: %v, actual: %v", unitUnderTest.Operation, expected, result)
+			}
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
 	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
+	"github.com/Guardian-Development/fastengine/pkg/fast/encoder"
 	"github.com/Guardian-Development/fastengine/pkg/fast/field/properties"
 	"github.com/Guardian-Development/fastengine/pkg/fast/operation"
 	"github.com/Guardian-Development/fastengine/pkg/fast/presencemap"
@@ -19,11 +20,12 @@ type FieldUnicodeString struct {
 	Operation    operation.Operation
 
 	decode decoder.Decoder
+	encode encoder.Encoder
 }
 
 // Deserialise a <string charset="unicode"/> from the input source
-func (field FieldUnicodeString) Deserialise(inputSource *bytes.Buffer, pMap *presencemap.PresenceMap, dictionary *dictionary.Dictionary) (fix.Value, error) {
-	previousValue := dictionary.GetValue(field.FieldDetails.Name)
+func (field FieldUnicodeString) Deserialise(inputSource decoder.Reader, pMap *presencemap.PresenceMap, dictionary *dictionary.Dictionary) (fix.Value, error) {
+	previousValue := dictionary.GetKeyValue(field.FieldDetails.Key)
 	if field.Operation.ShouldReadValue(pMap) {
 		var stringValue value.Value
 		var err error
@@ -50,7 +52,7 @@ func (field FieldUnicodeString) Deserialise(inputSource *bytes.Buffer, pMap *pre
 			return nil, fmt.Errorf("[FieldUnicodeString][%#v][%#v] failed to apply operation with readValue %#v, previousValue: %#v, reason: %s", field.FieldDetails, field.Operation, stringValue, previousValue, err)
 		}
 
-		dictionary.SetValue(field.FieldDetails.Name, transformedValue)
+		dictionary.SetKeyValue(field.FieldDetails.Key, transformedValue)
 		return transformedValue, nil
 	}
 
@@ -60,15 +62,62 @@ func (field FieldUnicodeString) Deserialise(inputSource *bytes.Buffer, pMap *pre
 		return nil, fmt.Errorf("[FieldUnicodeString][%#v][%#v] failed to get value for field when not encoded in message, reason: %s", field.FieldDetails, field.Operation, err)
 	}
 
-	dictionary.SetValue(field.FieldDetails.Name, transformedValue)
+	dictionary.SetKeyValue(field.FieldDetails.Key, transformedValue)
 	return transformedValue, nil
 }
 
+// Serialise a <string charset="unicode"/> to the output source
+func (field FieldUnicodeString) Serialise(outputSource *bytes.Buffer, pMap *presencemap.PresenceMap, dictionary *dictionary.Dictionary, fixValue fix.Value) error {
+	previousValue := dictionary.GetKeyValue(field.FieldDetails.Key)
+	shouldWrite, err := field.Operation.ShouldWriteValue(pMap, field.FieldDetails.Required, fixValue, previousValue)
+	if err != nil {
+		field.FieldDetails.Logger.Printf("[FieldUnicodeString][%#v][%#v] failed to evaluate whether to encode value %#v, previousValue: %#v, reason: %s", field.FieldDetails, field.Operation, fixValue, previousValue, err)
+		return fmt.Errorf("[FieldUnicodeString][%#v][%#v] failed to evaluate whether to encode value %#v, previousValue: %#v, reason: %s", field.FieldDetails, field.Operation, fixValue, previousValue, err)
+	}
+
+	if shouldWrite {
+		writeValue, err := field.Operation.GetEncodedValue(fixValue, previousValue)
+		if err != nil {
+			field.FieldDetails.Logger.Printf("[FieldUnicodeString][%#v][%#v] failed to apply operation with value %#v, previousValue: %#v, reason: %s", field.FieldDetails, field.Operation, fixValue, previousValue, err)
+			return fmt.Errorf("[FieldUnicodeString][%#v][%#v] failed to apply operation with value %#v, previousValue: %#v, reason: %s", field.FieldDetails, field.Operation, fixValue, previousValue, err)
+		}
+
+		switch t := writeValue.(type) {
+		case value.StringValue:
+			writeValue = value.ByteVector{Value: []byte(t.Value), ItemsToRemove: t.ItemsToRemove}
+		}
+
+		if field.FieldDetails.Required {
+			err = field.encode.WriteValue(outputSource, writeValue)
+		} else {
+			err = field.encode.WriteOptionalValue(outputSource, writeValue)
+		}
+
+		if err != nil {
+			field.FieldDetails.Logger.Printf("[FieldUnicodeString][%#v][%#v] failed to encode value to byte buffer, reason: %s", field.FieldDetails, field.Operation, err)
+			return fmt.Errorf("[FieldUnicodeString][%#v][%#v] failed to encode value to byte buffer, reason: %s", field.FieldDetails, field.Operation, err)
+		}
+	}
+
+	dictionary.SetKeyValue(field.FieldDetails.Key, fixValue)
+	return nil
+}
+
 // GetTagId for this field
 func (field FieldUnicodeString) GetTagId() uint64 {
 	return field.FieldDetails.ID
 }
 
+// GetName of this field, as given in the template
+func (field FieldUnicodeString) GetName() string {
+	return field.FieldDetails.Name
+}
+
+// Keys of the dictionary entry holding the previous value of this field
+func (field FieldUnicodeString) Keys() []dictionary.Key {
+	return []dictionary.Key{field.FieldDetails.Key}
+}
+
 // RequiresPmap returns whether the underlying operation for this field requires a pmap bit being set
 func (field FieldUnicodeString) RequiresPmap() bool {
 	return field.Operation.RequiresPmap(field.FieldDetails.Required)
@@ -79,6 +128,7 @@ func New(properties properties.Properties) FieldUnicodeString {
 	field := FieldUnicodeString{
 		FieldDetails: properties,
 		decode:       decoder.ByteVectorDecoder{},
+		encode:       encoder.ByteVectorEncoder{},
 		Operation:    operation.None{},
 	}
 
@@ -90,6 +140,7 @@ func NewConstantOperation(properties properties.Properties, constantValue string
 	field := FieldUnicodeString{
 		FieldDetails: properties,
 		decode:       decoder.ByteVectorDecoder{},
+		encode:       encoder.ByteVectorEncoder{},
 		Operation: operation.Constant{
 			ConstantValue: fix.NewRawValue(constantValue),
 		},
@@ -103,6 +154,7 @@ func NewDefaultOperation(properties properties.Properties) FieldUnicodeString {
 	field := FieldUnicodeString{
 		FieldDetails: properties,
 		decode:       decoder.ByteVectorDecoder{},
+		encode:       encoder.ByteVectorEncoder{},
 		Operation: operation.Default{
 			DefaultValue: fix.NullValue{},
 		},
@@ -116,6 +168,7 @@ func NewDefaultOperationWithValue(properties properties.Properties, defaultValue
 	field := FieldUnicodeString{
 		FieldDetails: properties,
 		decode:       decoder.ByteVectorDecoder{},
+		encode:       encoder.ByteVectorEncoder{},
 		Operation: operation.Default{
 			DefaultValue: fix.NewRawValue(defaultValue),
 		},
@@ -129,6 +182,7 @@ func NewCopyOperation(properties properties.Properties) FieldUnicodeString {
 	field := FieldUnicodeString{
 		FieldDetails: properties,
 		decode:       decoder.ByteVectorDecoder{},
+		encode:       encoder.ByteVectorEncoder{},
 		Operation: operation.Copy{
 			InitialValue: fix.NullValue{},
 		},
@@ -142,6 +196,7 @@ func NewCopyOperationWithInitialValue(properties properties.Properties, initialV
 	field := FieldUnicodeString{
 		FieldDetails: properties,
 		decode:       decoder.ByteVectorDecoder{},
+		encode:       encoder.ByteVectorEncoder{},
 		Operation: operation.Copy{
 			InitialValue: fix.NewRawValue(initialValue),
 		},
@@ -155,6 +210,7 @@ func NewTailOperation(properties properties.Properties) FieldUnicodeString {
 	field := FieldUnicodeString{
 		FieldDetails: properties,
 		decode:       decoder.ByteVectorDecoder{},
+		encode:       encoder.ByteVectorEncoder{},
 		Operation: operation.Tail{
 			InitialValue: fix.NullValue{},
 			BaseValue:    fix.NewRawValue(""),
@@ -168,6 +224,7 @@ func NewTailOperationWithInitialValue(properties properties.Properties, initialV
 	field := FieldUnicodeString{
 		FieldDetails: properties,
 		decode:       decoder.ByteVectorDecoder{},
+		encode:       encoder.ByteVectorEncoder{},
 		Operation: operation.Tail{
 			InitialValue: fix.NewRawValue(initialValue),
 			BaseValue:    fix.NewRawValue(""),
@@ -181,6 +238,7 @@ func NewDeltaOperation(properties properties.Properties) FieldUnicodeString {
 	field := FieldUnicodeString{
 		FieldDetails: properties,
 		decode:       decoder.ByteVectorDeltaDecoder{},
+		encode:       encoder.ByteVectorDeltaEncoder{},
 		Operation: operation.Delta{
 			InitialValue: fix.NullValue{},
 			BaseValue:    fix.NewRawValue(""),
@@ -195,6 +253,7 @@ func NewDeltaOperationWithInitialValue(properties properties.Properties, initial
 	field := FieldUnicodeString{
 		FieldDetails: properties,
 		decode:       decoder.ByteVectorDeltaDecoder{},
+		encode:       encoder.ByteVectorDeltaEncoder{},
 		Operation: operation.Delta{
 			InitialValue: fix.NewRawValue(initialValue),
 			BaseValue:    fix.NewRawValue(""),
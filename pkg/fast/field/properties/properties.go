@@ -1,12 +1,19 @@
 package properties
 
-import "log"
+import (
+	"log"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
+)
 
 // Properties contains information about a TemplateUnit within a FAST Template
 type Properties struct {
 	ID       uint64
 	Name     string
 	Required bool
+	// Key of the dictionary entry holding the previous value of the unit, which is its name within the global dictionary unless it is given another key
+	// or moved to another dictionary
+	Key dictionary.Key
 
 	Logger *log.Logger
 }
@@ -17,8 +24,31 @@ func New(id uint64, name string, required bool, logger *log.Logger) Properties {
 		ID:       id,
 		Name:     name,
 		Required: required,
+		Key:      dictionary.GlobalKey(name),
 		Logger:   logger,
 	}
 
 	return props
 }
+
+// Rename the unit, if the unit is keyed by its name its dictionary entry is keyed by the new name within the same dictionary
+func (props *Properties) Rename(name string) {
+	if props.Key.Name == props.Name {
+		props.Key.Name = name
+	}
+	props.Name = name
+}
+
+// Rekey the dictionary entry of the unit to the key name within the namespace, within the same dictionary
+func (props *Properties) Rekey(name string, namespace string) {
+	props.Key.Name = name
+	props.Key.Namespace = namespace
+}
+
+// InDictionary moves the dictionary entry of the unit to the dictionary named, where an entry of a template or type dictionary is scoped to the template
+// or application type given
+func (props *Properties) InDictionary(dictionaryName string, templateName string, typeName string) {
+	namespace := props.Key.Namespace
+	props.Key = dictionary.KeyOf(dictionaryName, templateName, typeName, props.Key.Name)
+	props.Key.Namespace = namespace
+}
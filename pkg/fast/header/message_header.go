@@ -5,39 +5,92 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
 	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
-	"github.com/Guardian-Development/fastengine/pkg/fast/field/fielduint32"
-	"github.com/Guardian-Development/fastengine/pkg/fast/field/properties"
+	"github.com/Guardian-Development/fastengine/pkg/fast/encoder"
+	"github.com/Guardian-Development/fastengine/pkg/fast/errors"
 	"github.com/Guardian-Development/fastengine/pkg/fast/presencemap"
 	"github.com/Guardian-Development/fastengine/pkg/fix"
 )
 
+// TemplateIDKey is the dictionary key of the template id, which is copied from the previous message when it is not present
+var TemplateIDKey = dictionary.GlobalKey("TemplateId")
+
 // MessageHeader represents the beginning of every fast message. It contains the presence map for the message and the template id to use when decoding the message.
 type MessageHeader struct {
 	PMap       *presencemap.PresenceMap
 	TemplateID uint32
 }
 
+// Serialise the template id of the MessageHeader to the byte buffer, setting the template id bit in the presence map.
+// The presence map itself is not written, as it can only be written once the rest of the message has been encoded.
+func (messageHeader MessageHeader) Serialise(message *bytes.Buffer, dict *dictionary.Dictionary, logger *log.Logger) error {
+	// the template id is a required uint32 with a copy operator, so it is only written when it is not the template id of the previous message
+	slot := dict.SlotOf(TemplateIDKey)
+	previousTemplateID := dict.Entry(slot)
+	if previous := previousTemplateID.Value(); previousTemplateID.State() == dictionary.Assigned && previous.Kind == fix.UInt32Kind &&
+		uint32(previous.Unsigned) == messageHeader.TemplateID {
+		messageHeader.PMap.SetIsSetAndIncrement(false)
+		return nil
+	}
+
+	messageHeader.PMap.SetIsSetAndIncrement(true)
+	encoder.WriteUInt32(message, messageHeader.TemplateID)
+	var templateID fix.TypedValue
+	templateID.SetUInt32(messageHeader.TemplateID)
+	dict.Set(slot, &templateID)
+	return nil
+}
+
 // New MessageHeader read from the byte buffer
-func New(message *bytes.Buffer, dict *dictionary.Dictionary, logger *log.Logger) (MessageHeader, error) {
-	pMap, err := presencemap.New(message)
+func New(message decoder.Reader, dict *dictionary.Dictionary, logger *log.Logger) (MessageHeader, error) {
+	pMap := presencemap.PresenceMap{}
+	templateID, err := Read(message, &pMap, dict, logger)
 	if err != nil {
+		return MessageHeader{}, err
+	}
+
+	return MessageHeader{PMap: &pMap, TemplateID: templateID}, nil
+}
+
+// Read the header of the message, loading its presence map into pMap and returning the template id. The presence map and dictionary entry of the
+// template id are reused, so reading a header does not allocate.
+func Read(message decoder.Reader, pMap *presencemap.PresenceMap, dict *dictionary.Dictionary, logger *log.Logger) (uint32, error) {
+	if err := pMap.Load(message); err != nil {
 		logger.Printf("could not deserialise presence map from byte buffer, reason: %s", err)
-		return MessageHeader{}, fmt.Errorf("unable to create presence map for message")
+		return 0, fmt.Errorf("unable to create presence map for message")
 	}
 
-	templateIDAttribute := fielduint32.NewCopyOperation(properties.New(0, "TemplateId", true, logger))
-	templateID, err := templateIDAttribute.Deserialise(message, &pMap, dict)
-	if err != nil {
-		logger.Printf("could not deserialise template id from byte buffer, reason: %v", err)
-		return MessageHeader{}, fmt.Errorf("could not deserialise template id from byte buffer")
+	// the template id is a required uint32 with a copy operator, which has no initial value
+	slot := dict.SlotOf(TemplateIDKey)
+	previousTemplateID := dict.Entry(slot)
+	if pMap.GetIsSetAndIncrement() {
+		templateID, err := decoder.ReadUInt32(message)
+		if err != nil {
+			logger.Printf("could not deserialise template id from byte buffer, reason: %v", err)
+			return 0, fmt.Errorf("could not deserialise template id from byte buffer")
+		}
+
+		var readValue fix.TypedValue
+		readValue.SetUInt32(templateID.Value)
+		dict.Set(slot, &readValue)
+		return templateID.Value, nil
 	}
 
-	switch t := templateID.(type) {
-	case fix.RawValue:
-		return MessageHeader{PMap: &pMap, TemplateID: t.Get().(uint32)}, nil
+	switch previousTemplateID.State() {
+	case dictionary.Assigned:
+		if templateID := previousTemplateID.Value(); templateID.Kind == fix.UInt32Kind {
+			// the copied template id is assigned to its entry again, as it is by the copy operator of any other field
+			var copiedValue fix.TypedValue
+			copiedValue.SetUInt32(uint32(templateID.Unsigned))
+			dict.Set(slot, &copiedValue)
+			return uint32(templateID.Unsigned), nil
+		}
+	case dictionary.Undefined:
+		logger.Printf("could not deserialise template id from byte buffer, reason: %s", errors.D5)
+		return 0, fmt.Errorf("could not deserialise template id from byte buffer")
 	}
 
-	logger.Printf("no template id was found in the byte buffer, unable to calculate format of message, reason: %s", err)
-	return MessageHeader{}, fmt.Errorf("message not supported: message must have template id encoded")
+	logger.Printf("no template id was found in the byte buffer, unable to calculate format of message")
+	return 0, fmt.Errorf("message not supported: message must have template id encoded")
 }
@@ -2,6 +2,7 @@ package operation
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
 	"github.com/Guardian-Development/fastengine/pkg/fast/errors"
@@ -11,12 +12,14 @@ import (
 	"github.com/Guardian-Development/fastengine/pkg/fix"
 )
 
-// Operation is applied when reading a fast value off a byte buffer
+// Operation is applied when reading a fast value off a byte buffer, or writing a fast value to a byte buffer
 type Operation interface {
 	ShouldReadValue(pMap *presencemap.PresenceMap) bool
 	GetNotEncodedValue(pMap *presencemap.PresenceMap, required bool, previousValue dictionary.Value) (fix.Value, error)
 	Apply(readValue value.Value, previousValue dictionary.Value) (fix.Value, error)
 	RequiresPmap(required bool) bool
+	ShouldWriteValue(pMap *presencemap.PresenceMap, required bool, writeValue fix.Value, previousValue dictionary.Value) (bool, error)
+	GetEncodedValue(writeValue fix.Value, previousValue dictionary.Value) (value.Value, error)
 }
 
 // None represents applying no operation to the read value
@@ -43,6 +46,16 @@ func (operation None) RequiresPmap(required bool) bool {
 	return false
 }
 
+// ShouldWriteValue if no operator is present must always write the value to the stream
+func (operation None) ShouldWriteValue(pMap *presencemap.PresenceMap, required bool, writeValue fix.Value, previousValue dictionary.Value) (bool, error) {
+	return true, nil
+}
+
+// GetEncodedValue does no transformation on the value as no operator is present
+func (operation None) GetEncodedValue(writeValue fix.Value, previousValue dictionary.Value) (value.Value, error) {
+	return value.FromFix(writeValue)
+}
+
 // Constant represents the fast <constant/> operation
 type Constant struct {
 	ConstantValue fix.Value
@@ -75,6 +88,29 @@ func (operation Constant) Apply(readValue value.Value, previousValue dictionary.
 	return readValue.GetAsFix(), nil
 }
 
+// ShouldWriteValue always returns false for constant operations. If optional, the pMap bit is set to 1 when the value is present, else 0.
+// If the value is present but does not match the constant value an error is returned, as it cannot be represented in the stream.
+func (operation Constant) ShouldWriteValue(pMap *presencemap.PresenceMap, required bool, writeValue fix.Value, previousValue dictionary.Value) (bool, error) {
+	_, isNull := writeValue.(fix.NullValue)
+	if !isNull && !isEqual(writeValue, operation.ConstantValue) {
+		return false, fmt.Errorf("value %s does not match the constant value %s", writeValue, operation.ConstantValue)
+	}
+	if required {
+		if isNull {
+			return false, fmt.Errorf("value must be present for required field with constant value %s", operation.ConstantValue)
+		}
+		return false, nil
+	}
+
+	pMap.SetIsSetAndIncrement(!isNull)
+	return false, nil
+}
+
+// GetEncodedValue does not modify the value, however the Constant operator never writes a value to the stream
+func (operation Constant) GetEncodedValue(writeValue fix.Value, previousValue dictionary.Value) (value.Value, error) {
+	return value.FromFix(writeValue)
+}
+
 // Default represents the fast <default/> operation
 type Default struct {
 	DefaultValue fix.Value
@@ -100,6 +136,18 @@ func (operation Default) RequiresPmap(required bool) bool {
 	return true
 }
 
+// ShouldWriteValue sets the next pMap bit to 0 and returns false if the value matches the DefaultValue, else sets the pMap bit to 1 and returns true.
+func (operation Default) ShouldWriteValue(pMap *presencemap.PresenceMap, required bool, writeValue fix.Value, previousValue dictionary.Value) (bool, error) {
+	shouldWrite := !isEqual(writeValue, operation.DefaultValue)
+	pMap.SetIsSetAndIncrement(shouldWrite)
+	return shouldWrite, nil
+}
+
+// GetEncodedValue does not modify the value, as the Default operator only applies to whether a value is written to the stream
+func (operation Default) GetEncodedValue(writeValue fix.Value, previousValue dictionary.Value) (value.Value, error) {
+	return value.FromFix(writeValue)
+}
+
 // Copy represents the fast <copy/> operation
 type Copy struct {
 	InitialValue fix.Value
@@ -143,6 +191,17 @@ func (operation Copy) RequiresPmap(required bool) bool {
 	return true
 }
 
+// ShouldWriteValue sets the next pMap bit to 0 and returns false if the value is the same as the value that would be copied from the dictionary,
+// else sets the pMap bit to 1 and returns true.
+func (operation Copy) ShouldWriteValue(pMap *presencemap.PresenceMap, required bool, writeValue fix.Value, previousValue dictionary.Value) (bool, error) {
+	return writeIfNotEqual(pMap, operation, required, writeValue, previousValue), nil
+}
+
+// GetEncodedValue does not modify the value, as the Copy operator only applies to whether a value is written to the stream
+func (operation Copy) GetEncodedValue(writeValue fix.Value, previousValue dictionary.Value) (value.Value, error) {
+	return value.FromFix(writeValue)
+}
+
 // Increment represents the fast <increment/> operation
 type Increment struct {
 	InitialValue fix.Value
@@ -200,6 +259,17 @@ func (operation Increment) RequiresPmap(required bool) bool {
 	return true
 }
 
+// ShouldWriteValue sets the next pMap bit to 0 and returns false if the value is the previous value incremented by 1,
+// else sets the pMap bit to 1 and returns true.
+func (operation Increment) ShouldWriteValue(pMap *presencemap.PresenceMap, required bool, writeValue fix.Value, previousValue dictionary.Value) (bool, error) {
+	return writeIfNotEqual(pMap, operation, required, writeValue, previousValue), nil
+}
+
+// GetEncodedValue does not modify the value, as the Increment operator only applies when there is no value in the stream
+func (operation Increment) GetEncodedValue(writeValue fix.Value, previousValue dictionary.Value) (value.Value, error) {
+	return value.FromFix(writeValue)
+}
+
 // Tail represents the fast <tail/> operation
 type Tail struct {
 	InitialValue fix.Value
@@ -274,6 +344,16 @@ func (operation Tail) RequiresPmap(required bool) bool {
 	return true
 }
 
+// ShouldWriteValue sets the next pMap bit to 0 and returns false if the value is the same as the previous value, else sets the pMap bit to 1 and returns true.
+func (operation Tail) ShouldWriteValue(pMap *presencemap.PresenceMap, required bool, writeValue fix.Value, previousValue dictionary.Value) (bool, error) {
+	return writeIfNotEqual(pMap, operation, required, writeValue, previousValue), nil
+}
+
+// GetEncodedValue returns the tail of the value that differs from the previous value. If theres no previous value, the initial value (or default value) is used.
+func (operation Tail) GetEncodedValue(writeValue fix.Value, previousValue dictionary.Value) (value.Value, error) {
+	return value.Tail(writeValue, baseValueOf(previousValue, operation.InitialValue, operation.BaseValue))
+}
+
 // Delta represents the fast <delta/> operation.
 type Delta struct {
 	InitialValue fix.Value
@@ -320,3 +400,40 @@ func (operation Delta) Apply(readValue value.Value, previousValue dictionary.Val
 func (operation Delta) RequiresPmap(required bool) bool {
 	return false
 }
+
+// ShouldWriteValue is always true as the delta is always written to the stream
+func (operation Delta) ShouldWriteValue(pMap *presencemap.PresenceMap, required bool, writeValue fix.Value, previousValue dictionary.Value) (bool, error) {
+	return true, nil
+}
+
+// GetEncodedValue returns the result of value - previous value (delta). If theres no previous value, the initial value (or default value) is used.
+func (operation Delta) GetEncodedValue(writeValue fix.Value, previousValue dictionary.Value) (value.Value, error) {
+	return value.Delta(writeValue, baseValueOf(previousValue, operation.InitialValue, operation.BaseValue))
+}
+
+// baseValueOf returns the previous value if assigned, else the initial value, falling back to the base value of the type if there is no initial value
+func baseValueOf(previousValue dictionary.Value, initialValue fix.Value, baseValue fix.Value) fix.Value {
+	switch t := previousValue.(type) {
+	case dictionary.AssignedValue:
+		return t.Value
+	}
+
+	switch initialValue.(type) {
+	case fix.NullValue:
+		return baseValue
+	}
+	return initialValue
+}
+
+// writeIfNotEqual sets the next pMap bit to 0 and returns false if the value matches the value the operation would produce when no value is in the stream,
+// else sets the pMap bit to 1 and returns true.
+func writeIfNotEqual(pMap *presencemap.PresenceMap, operation Operation, required bool, writeValue fix.Value, previousValue dictionary.Value) bool {
+	notEncodedValue, err := operation.GetNotEncodedValue(pMap, required, previousValue)
+	shouldWrite := err != nil || !isEqual(writeValue, notEncodedValue)
+	pMap.SetIsSetAndIncrement(shouldWrite)
+	return shouldWrite
+}
+
+func isEqual(writeValue fix.Value, otherValue fix.Value) bool {
+	return reflect.DeepEqual(writeValue.Get(), otherValue.Get())
+}
@@ -0,0 +1,71 @@
+package presencemap
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
+	"github.com/Guardian-Development/fastengine/pkg/fast/errors"
+)
+
+// Layout of a presence map, naming the unit that reads each bit in the order the bits are read. The bits a unit reads only depend on its operator and
+// whether it is required, so a layout is computed for each template and repeating group as templates are loaded. A unit only skips its bit when a unit
+// before it is null, as the mantissa of a decimal with a null exponent does, so the layout holds every bit that can be read.
+type Layout struct {
+	Units []string
+}
+
+// Bits is the number of bits of the presence map read by the units of the layout
+func (layout Layout) Bits() int {
+	return len(layout.Units)
+}
+
+// Check the pMap once it has been loaded, given the number of bits its template or repeating group reads. A pMap is overlong (R7) if its last byte has no
+// bits set, and too long (R8) if it has bits set beyond the bits that are read. These are errors when read with strict settings, and are counted in the
+// Counters of the settings when read with lenient settings.
+func (pMap *PresenceMap) Check(inputSource decoder.Reader, bits int) error {
+	length := len(pMap.pMap)
+	overlong := length > 1 && pMap.pMap[length-1]&127 == 0
+	tooLong := pMap.hasBitsSetFrom(bits)
+	if !overlong && !tooLong {
+		return nil
+	}
+
+	settings := decoder.SettingsOf(inputSource)
+	if !settings.Lenient {
+		if overlong {
+			return fmt.Errorf("%s, presence map: %v", errors.R7, pMap.pMap)
+		}
+		return fmt.Errorf("%s, presence map: %v, bits read: %d", errors.R8, pMap.pMap, bits)
+	}
+
+	if counters := settings.Counters; counters != nil {
+		if overlong {
+			atomic.AddUint64(&counters.OverlongPresenceMaps, 1)
+		}
+		if tooLong {
+			atomic.AddUint64(&counters.TooLongPresenceMaps, 1)
+		}
+	}
+	return nil
+}
+
+// hasBitsSetFrom returns whether any bit from the index of bit onwards is set
+func (pMap *PresenceMap) hasBitsSetFrom(bit int) bool {
+	offset := bit / 7
+	if offset >= len(pMap.pMap) {
+		return false
+	}
+
+	// the bit at index offset*7+n is 64 >> n, so the bits of the byte from bit onwards are the bits below 128 >> (bit - offset*7)
+	if pMap.pMap[offset]&(127>>byte(bit-offset*7)) != 0 {
+		return true
+	}
+	for _, b := range pMap.pMap[offset+1:] {
+		if b&127 != 0 {
+			return true
+		}
+	}
+
+	return false
+}
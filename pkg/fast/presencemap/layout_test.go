@@ -0,0 +1,97 @@
+package presencemap
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
+	"github.com/Guardian-Development/fastengine/pkg/fast/errors"
+)
+
+func TestCheckAcceptsPMapHoldingOnlyBitsRead(t *testing.T) {
+	cases := []struct {
+		pMap []byte
+		bits int
+	}{
+		// 1000000 <- pMap, no bits are set
+		{[]byte{128}, 0},
+		// 1100000 <- pMap, the first of the two bits read is set
+		{[]byte{224}, 2},
+		// 1111111 <- pMap, every bit read is set and the trailing bits are implicitly not set
+		{[]byte{255}, 10},
+		// 0000000 0100000 <- pMap, the last bit read is the second bit of the second byte
+		{[]byte{0, 160}, 9},
+	}
+
+	for _, table := range cases {
+		// Arrange
+		pMap, _ := New(bytes.NewBuffer(table.pMap))
+
+		// Act
+		err := pMap.Check(bytes.NewBuffer(nil), table.bits)
+
+		// Assert
+		if err != nil {
+			t.Errorf("Got an error checking pMap %v with %d bits when none was expected: %s", table.pMap, table.bits, err)
+		}
+	}
+}
+
+func TestCheckReturnsR7ErrorWhenLastByteHasNoBitsSet(t *testing.T) {
+	// Arrange 64 = (01000000) 128 = (10000000)
+	pMap, _ := New(bytes.NewBuffer([]byte{64, 128}))
+
+	// Act
+	err := pMap.Check(bytes.NewBuffer(nil), 14)
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), errors.R7) {
+		t.Errorf("Expected an R7 error checking an overlong pMap, but got: %v", err)
+	}
+}
+
+func TestCheckReturnsR8ErrorWhenBitIsSetBeyondBitsRead(t *testing.T) {
+	cases := []struct {
+		pMap []byte
+		bits int
+	}{
+		// 0100000 <- pMap, the second bit is set but only one bit is read
+		{[]byte{160}, 1},
+		// 0000000 0000001 <- pMap, the last bit of the second byte is set but only 13 bits are read
+		{[]byte{0, 129}, 13},
+		// 1000000 <- pMap, the first bit is set but the template reads no bits
+		{[]byte{192}, 0},
+	}
+
+	for _, table := range cases {
+		// Arrange
+		pMap, _ := New(bytes.NewBuffer(table.pMap))
+
+		// Act
+		err := pMap.Check(bytes.NewBuffer(nil), table.bits)
+
+		// Assert
+		if err == nil || !strings.Contains(err.Error(), errors.R8) {
+			t.Errorf("Expected an R8 error checking pMap %v with %d bits, but got: %v", table.pMap, table.bits, err)
+		}
+	}
+}
+
+func TestCheckCountsInvalidPMapsWhenLenient(t *testing.T) {
+	// Arrange 96 = (01100000) 128 = (10000000), the pMap is both overlong and too long
+	counters := decoder.Counters{}
+	inputSource := &decoder.SettingsReader{Reader: bytes.NewBuffer(nil), Settings: decoder.Settings{Lenient: true, Counters: &counters}}
+	pMap, _ := New(bytes.NewBuffer([]byte{96, 128}))
+
+	// Act
+	err := pMap.Check(inputSource, 1)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error checking pMap when lenient when none was expected: %s", err)
+	}
+	if counters.Load() != (decoder.Counters{OverlongPresenceMaps: 1, TooLongPresenceMaps: 1}) {
+		t.Errorf("Expected the pMap to be counted as overlong and too long, counters: %#v", counters.Load())
+	}
+}
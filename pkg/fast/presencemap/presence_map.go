@@ -1,7 +1,6 @@
 package presencemap
 
 import (
-	"bytes"
 	"fmt"
 	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
 )
@@ -27,8 +26,41 @@ func (pMap *PresenceMap) GetIsSetAndIncrement() bool {
 	return isSet
 }
 
+// SetIsSetAndIncrement sets the next value in the pMap, incrementing the internal counter. This is used when building a pMap to encode a message.
+func (pMap *PresenceMap) SetIsSetAndIncrement(isSet bool) {
+	offset := pMap.currentIndex / 7
+	for offset >= len(pMap.pMap) {
+		pMap.pMap = append(pMap.pMap, 0)
+	}
+
+	if isSet {
+		bit := 64 >> byte(pMap.currentIndex-(offset*7))
+		pMap.pMap[offset] = pMap.pMap[offset] | byte(bit)
+	}
+	pMap.currentIndex = pMap.currentIndex + 1
+}
+
+// Bytes returns the FAST encoded pMap, with the stop bit set on the final byte. Trailing bytes with no bits set are not included, as these are
+// implicitly not set when the pMap is read.
+func (pMap PresenceMap) Bytes() []byte {
+	length := len(pMap.pMap)
+	for length > 1 && pMap.pMap[length-1]&127 == 0 {
+		length--
+	}
+
+	encoded := make([]byte, length)
+	copy(encoded, pMap.pMap[:length])
+	if length == 0 {
+		encoded = append(encoded, 0)
+	}
+
+	// 128 = 10000000, mark the final byte with the stop bit
+	encoded[len(encoded)-1] = encoded[len(encoded)-1] | 128
+	return encoded
+}
+
 // New pMap is created reading the next FAST encoded value off the message buffer to represent the pMap
-func New(message *bytes.Buffer) (PresenceMap, error) {
+func New(message decoder.Reader) (PresenceMap, error) {
 	value, err := decoder.ReadValue(message)
 
 	if err != nil {
@@ -37,3 +69,17 @@ func New(message *bytes.Buffer) (PresenceMap, error) {
 
 	return PresenceMap{pMap: value, currentIndex: 0}, nil
 }
+
+// Load the next FAST encoded value off the message buffer into the pMap, in the same way as New, reusing the bytes of the pMap
+func (pMap *PresenceMap) Load(message decoder.Reader) error {
+	value, err := decoder.AppendValue(pMap.pMap[:0], message)
+	if err != nil {
+		pMap.pMap = pMap.pMap[:0]
+		pMap.currentIndex = 0
+		return fmt.Errorf("unable to read a valid value from byte buffer for presence map, reason: %s", err)
+	}
+
+	pMap.pMap = value
+	pMap.currentIndex = 0
+	return nil
+}
@@ -112,3 +112,92 @@ func TestCanReadThreeBytePMap(t *testing.T) {
 		}
 	}
 }
+
+func TestCanWriteSingleBytePMap(t *testing.T) {
+	// Arrange 170 = (10101010)
+	pMap := PresenceMap{}
+	expectedBytes := []byte{170}
+
+	// Act 0101010 <- pMap
+	for _, isSet := range []bool{false, true, false, true, false, true, false} {
+		pMap.SetIsSetAndIncrement(isSet)
+	}
+
+	// Assert
+	if !bytes.Equal(expectedBytes, pMap.Bytes()) {
+		t.Errorf("Did not write the expected pMap, expected: %#v, result: %#v", expectedBytes, pMap.Bytes())
+	}
+}
+
+func TestCanWriteTwoBytePMap(t *testing.T) {
+	// Arrange 42 = (00101010) 193 = (11000001)
+	pMap := PresenceMap{}
+	expectedBytes := []byte{42, 193}
+
+	// Act 0101010 1000001 <- pMap
+	for _, isSet := range []bool{false, true, false, true, false, true, false, true, false, false, false, false, false, true} {
+		pMap.SetIsSetAndIncrement(isSet)
+	}
+
+	// Assert
+	if !bytes.Equal(expectedBytes, pMap.Bytes()) {
+		t.Errorf("Did not write the expected pMap, expected: %#v, result: %#v", expectedBytes, pMap.Bytes())
+	}
+}
+
+func TestWritePMapDoesNotIncludeTrailingUnsetBytes(t *testing.T) {
+	// Arrange 192 = (11000000)
+	pMap := PresenceMap{}
+	expectedBytes := []byte{192}
+
+	// Act 1000000 0000000 <- pMap
+	pMap.SetIsSetAndIncrement(true)
+	for i := 0; i < 13; i++ {
+		pMap.SetIsSetAndIncrement(false)
+	}
+
+	// Assert
+	if !bytes.Equal(expectedBytes, pMap.Bytes()) {
+		t.Errorf("Did not write the expected pMap, expected: %#v, result: %#v", expectedBytes, pMap.Bytes())
+	}
+}
+
+func TestWrittenPMapCanBeRead(t *testing.T) {
+	// Arrange
+	toWrite := []bool{true, false, false, true, true, false, true, false, true, true, false, false, false, true, true}
+	written := PresenceMap{}
+	for _, isSet := range toWrite {
+		written.SetIsSetAndIncrement(isSet)
+	}
+
+	// Act
+	pMap, err := New(bytes.NewBuffer(written.Bytes()))
+	if err != nil {
+		t.Errorf("Got an error reading written pMap when none was expected: %s", err)
+	}
+
+	// Assert
+	for bitNumber, expectedSet := range toWrite {
+		if isSet := pMap.GetIsSetAndIncrement(); isSet != expectedSet {
+			t.Errorf("Written pMap did not match read pMap at bitNumber: %d", bitNumber)
+		}
+	}
+}
+
+func TestLoadReusesPMapForNextMessage(t *testing.T) {
+	// Arrange 42 = (00101010) 170 = (10101010), 192 = (11000000)
+	message := bytes.NewBuffer([]byte{42, 170, 192})
+	pMap, _ := New(message)
+	pMap.GetIsSetAndIncrement()
+
+	// Act
+	err := pMap.Load(message)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error loading pMap when none was expected: %s", err)
+	}
+	if !pMap.GetIsSetAndIncrement() || pMap.GetIsSetAndIncrement() {
+		t.Errorf("Expected the loaded pMap to be read from its first bit, pMap: %#v", pMap)
+	}
+}
@@ -0,0 +1,561 @@
+package codegen
+
+import (
+	"bytes"
+	encodingxml "encoding/xml"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/fieldasciistring"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/fieldbytevector"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/fielddecimal"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/fieldint32"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/fieldint64"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/fieldsequence"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/fielduint32"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/fielduint64"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/fieldunicodestring"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/properties"
+	"github.com/Guardian-Development/fastengine/pkg/fast/operation"
+	"github.com/Guardian-Development/fastengine/pkg/fast/template/loader"
+	"github.com/Guardian-Development/fastengine/pkg/fast/template/store"
+	"github.com/Guardian-Development/fastengine/pkg/fast/template/structure"
+
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+
+	tokenxml "github.com/Guardian-Development/fastengine/internal/xml"
+)
+
+// Options for the code generated from a template file
+type Options struct {
+	// Package the generated code belongs to
+	Package string
+	// Source is the name of the template file, recorded in the header of the generated code
+	Source string
+	// TemplateIDs to generate code for, if empty code is generated for every template in the file
+	TemplateIDs []uint32
+}
+
+// Generate Go source from the FAST templates XML file. Each template becomes a struct with a field per template unit, and a decode function that reads the
+// message straight into the struct without going through store.Unit, operation.Operation or a fix.Message. Values are read with the same decoder primitives
+// as the engine, and operators applied with the same semantics, with the dictionary of previous values held in typed fields of the generated Decoder.
+func Generate(templateFile *os.File, options Options, logger *log.Logger) ([]byte, error) {
+	templateStore, err := loader.Load(templateFile, logger)
+	if err != nil {
+		logger.Printf("unable to load templates to generate code from, reason: %v", err)
+		return nil, fmt.Errorf("unable to load templates to generate code from, reason: %v", err)
+	}
+	if _, err := templateFile.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("unable to read template names, reason: %v", err)
+	}
+	templateNames, err := loadTemplateNames(templateFile)
+	if err != nil {
+		logger.Printf("unable to read template names to generate code from, reason: %v", err)
+		return nil, fmt.Errorf("unable to read template names, reason: %v", err)
+	}
+
+	templateIDs := options.TemplateIDs
+	if len(templateIDs) == 0 {
+		for templateID := range templateStore.Templates {
+			templateIDs = append(templateIDs, templateID)
+		}
+	}
+	sort.Slice(templateIDs, func(i, j int) bool { return templateIDs[i] < templateIDs[j] })
+
+	generator := newGenerator()
+	for _, templateID := range templateIDs {
+		template, exists := templateStore.Templates[templateID]
+		if !exists {
+			return nil, fmt.Errorf("template with ID %d is not within the template file", templateID)
+		}
+		if err := generator.template(templateID, templateNames[templateID], template); err != nil {
+			logger.Printf("unable to generate code for template %d, reason: %v", templateID, err)
+			return nil, fmt.Errorf("unable to generate code for template %d, reason: %v", templateID, err)
+		}
+	}
+
+	source := generator.source(options)
+	formatted, err := format.Source(source)
+	if err != nil {
+		logger.Printf("generated code could not be formatted, reason: %v\n%s", err, source)
+		return nil, fmt.Errorf("generated code could not be formatted, reason: %v", err)
+	}
+
+	return formatted, nil
+}
+
+// loadTemplateNames from the template file by template ID, as these are not kept in the template store
+func loadTemplateNames(templateFile *os.File) (map[uint32]string, error) {
+	xmlTags, err := tokenxml.LoadTagsFrom(encodingxml.NewDecoder(templateFile))
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[uint32]string)
+	for _, templateTag := range xmlTags.NestedTags {
+		if templateTag.Type != structure.TemplateTag {
+			continue
+		}
+		templateID, err := strconv.ParseUint(templateTag.Attributes["id"], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse template ID, make sure it is present and uint: %v", err)
+		}
+		names[uint32(templateID)] = templateTag.Attributes["name"]
+	}
+
+	return names, nil
+}
+
+// kind of value held by a field, and by its entry in the dictionary
+type kind struct {
+	name   string
+	goType string
+	zero   string
+}
+
+var (
+	uint32Kind     = kind{name: "UInt32", goType: "uint32", zero: "0"}
+	int32Kind      = kind{name: "Int32", goType: "int32", zero: "0"}
+	uint64Kind     = kind{name: "UInt64", goType: "uint64", zero: "0"}
+	int64Kind      = kind{name: "Int64", goType: "int64", zero: "0"}
+	stringKind     = kind{name: "String", goType: "string", zero: `""`}
+	byteVectorKind = kind{name: "ByteVector", goType: "[]byte", zero: "nil"}
+)
+
+// scalar is a field holding a single value, read with the helpers for its reader and with its operation applied to its dictionary entry
+type scalar struct {
+	details   properties.Properties
+	operation operation.Operation
+	kind      kind
+	// reader of the value, and of the delta when the field has the <delta/> operator
+	reader      string
+	deltaReader string
+	// applyDelta and applyTail formats combine the read value (first argument) with the base value (second argument)
+	applyDelta string
+	applyTail  string
+}
+
+type slotKey struct {
+	key  dictionary.Key
+	kind string
+}
+
+type generator struct {
+	types     bytes.Buffer
+	functions bytes.Buffer
+
+	templates  []generatedTemplate
+	slots      map[slotKey]string
+	slotOrder  []slotKey
+	slotKinds  map[string]kind
+	readers    map[string]bool
+	typeNames  map[string]bool
+	usesMath   bool
+	usesValue  bool
+	readHeader bytes.Buffer
+}
+
+type generatedTemplate struct {
+	id       uint32
+	name     string
+	typeName string
+	// reset the dictionary before the body of the template is decoded, as the template is marked reset="Y"
+	reset bool
+}
+
+func newGenerator() *generator {
+	generator := &generator{
+		slots:     make(map[slotKey]string),
+		slotKinds: make(map[string]kind),
+		readers:   make(map[string]bool),
+		typeNames: map[string]bool{"Decoder": true, "Messages": true},
+	}
+
+	// the template ID is read with the <copy/> operator, in the same way as header.New
+	templateID, _ := scalarOf(fielduint32.NewCopyOperation(properties.New(0, "TemplateId", true, nil)))
+	_ = generator.scalar(&generator.readHeader, templateID, func(value string, null string) string {
+		return fmt.Sprintf("if %s {\nreturn pMap, 0, fmt.Errorf(\"message not supported: message must have template id encoded\")\n}\ntemplateID = %s\n", null, value)
+	}, "return pMap, 0, ")
+	return generator
+}
+
+func (generator *generator) template(templateID uint32, name string, template store.Template) error {
+	if name == "" {
+		name = fmt.Sprintf("Template%d", templateID)
+	}
+	typeName := generator.typeName(exportedIdentifier(name))
+	generator.templates = append(generator.templates, generatedTemplate{id: templateID, name: name, typeName: typeName, reset: template.Reset})
+
+	fmt.Fprintf(&generator.types, "// %sTemplateID of the %s template\nconst %sTemplateID uint32 = %d\n\n", typeName, name, typeName, templateID)
+	fmt.Fprintf(&generator.functions, "// Decode%s decodes the body of a message encoded with the %s template (%d) into out, after its header has been read by ReadHeader\n", typeName, name, templateID)
+	fmt.Fprintf(&generator.functions, "func (d *Decoder) Decode%s(message decoder.Reader, pMap *presencemap.PresenceMap, out *%s) error {\n", typeName, typeName)
+	// the presence map of a message holds the bit of the template id read by ReadHeader before the bits of the template
+	fmt.Fprintf(&generator.functions, "if err := pMap.Check(message, %d); err != nil {\nreturn err\n}\n", 1+store.LayoutOf(template.TemplateUnits).Bits())
+
+	return generator.group(typeName, fmt.Sprintf("%s is decoded from the %s template (%d)", typeName, name, templateID), template.TemplateUnits)
+}
+
+// group generates the struct for a template or repeating group, and the body of the function decoding into it
+func (generator *generator) group(typeName string, comment string, units []store.Unit) error {
+	fields := bytes.Buffer{}
+	body := bytes.Buffer{}
+	fieldNames := make(map[string]bool)
+	var nested []func() error
+
+	for _, unit := range units {
+		fieldName := uniqueIdentifier(exportedIdentifier(unit.GetName()), fieldNames, unit.GetTagId())
+		target := "out." + fieldName
+		hasTarget := ""
+		required := true
+
+		switch field := unit.(type) {
+		case fieldsequence.FieldSequence:
+			groupTypeName := generator.typeName(typeName + fieldName)
+			required = field.FieldDetails.Required
+			fmt.Fprintf(&fields, "\t%s []%s `fast:\"%d\"`\n", fieldName, groupTypeName, field.FieldDetails.ID)
+			if !required {
+				hasTarget = "out." + uniqueIdentifier("Has"+fieldName, fieldNames, field.FieldDetails.ID)
+				fmt.Fprintf(&fields, "\t%s bool\n", strings.TrimPrefix(hasTarget, "out."))
+			}
+			if err := generator.sequence(&body, field, groupTypeName, target, hasTarget); err != nil {
+				return err
+			}
+
+			sequenceFields := field.SequenceFields
+			sequenceName := field.FieldDetails.Name
+			nested = append(nested, func() error {
+				fmt.Fprintf(&generator.functions, "func (d *Decoder) decode%s(message decoder.Reader, pMap *presencemap.PresenceMap, out *%s) error {\n", groupTypeName, groupTypeName)
+				return generator.group(groupTypeName, fmt.Sprintf("%s is a repeating group of the %s sequence", groupTypeName, sequenceName), sequenceFields)
+			})
+			continue
+		case fielddecimal.FieldDecimal:
+			required = field.FieldDetails.Required
+			fmt.Fprintf(&fields, "\t%s float64 `fast:\"%d\"`\n", fieldName, field.FieldDetails.ID)
+		default:
+			scalar, err := scalarOf(unit)
+			if err != nil {
+				return err
+			}
+			required = scalar.details.Required
+			fmt.Fprintf(&fields, "\t%s %s `fast:\"%d\"`\n", fieldName, scalar.kind.goType, scalar.details.ID)
+		}
+
+		if !required {
+			hasTarget = "out." + uniqueIdentifier("Has"+fieldName, fieldNames, unit.GetTagId())
+			fmt.Fprintf(&fields, "\t%s bool\n", strings.TrimPrefix(hasTarget, "out."))
+		}
+		assign := func(value string, null string) string {
+			if hasTarget == "" {
+				return fmt.Sprintf("%s = %s\n", target, value)
+			}
+			return fmt.Sprintf("%s, %s = %s, !%s\n", target, hasTarget, value, null)
+		}
+
+		switch field := unit.(type) {
+		case fielddecimal.FieldDecimal:
+			if err := generator.decimal(&body, field, assign, "return "); err != nil {
+				return err
+			}
+		default:
+			scalar, _ := scalarOf(unit)
+			if err := generator.scalar(&body, scalar, assign, "return "); err != nil {
+				return err
+			}
+		}
+	}
+
+	fmt.Fprintf(&generator.types, "// %s\ntype %s struct {\n%s}\n\n", comment, typeName, fields.String())
+	fmt.Fprintf(&generator.functions, "%s\treturn nil\n}\n\n", body.String())
+
+	for _, generateNested := range nested {
+		if err := generateNested(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scalar generates a block decoding the field into v and null, applying its operation and updating its dictionary entry, before passing both to assign
+func (generator *generator) scalar(body *bytes.Buffer, field scalar, assign func(value string, null string) string, ret string) error {
+	slot := "d.dictionary." + generator.slot(field.details.Key, field.kind)
+	reader := generator.useReader(field.reader)
+	if !field.details.Required {
+		reader = generator.useReader("Optional" + field.reader)
+	}
+	name := field.details.Name
+	fail := fmt.Sprintf("if err != nil {\n%sfmt.Errorf(\"[%%s] failed to decode value, reason: %%s\", %q, err)\n}\n", ret, name)
+
+	fmt.Fprintf(body, "\t// %s (%d)\n\t{\n", name, field.details.ID)
+	switch op := field.operation.(type) {
+	case operation.None:
+		fmt.Fprintf(body, "v, null, err := %s(message)\n%s", reader, fail)
+	case operation.Constant:
+		constant, _ := literal(op.ConstantValue)
+		if field.details.Required {
+			fmt.Fprintf(body, "v, null := %s(%s), false\n", field.kind.goType, constant)
+		} else {
+			fmt.Fprintf(body, "v, null := %s(%s), true\n", field.kind.goType, field.kind.zero)
+			fmt.Fprintf(body, "if pMap.GetIsSetAndIncrement() {\nv, null = %s, false\n}\n", constant)
+		}
+	case operation.Default:
+		defaultValue, hasDefault := literal(op.DefaultValue)
+		if !hasDefault {
+			defaultValue = field.kind.zero
+		}
+		fmt.Fprintf(body, "v, null := %s(%s), %t\n", field.kind.goType, defaultValue, !hasDefault)
+		fmt.Fprintf(body, "if pMap.GetIsSetAndIncrement() {\nvar err error\nv, null, err = %s(message)\n%s}\n", reader, fail)
+	case operation.Copy, operation.Increment:
+		notEncoded := "copied"
+		var initial string
+		var hasInitial bool
+		switch t := op.(type) {
+		case operation.Copy:
+			initial, hasInitial = literal(t.InitialValue)
+		case operation.Increment:
+			initial, hasInitial = literal(t.InitialValue)
+			notEncoded = "incremented"
+		}
+		if !hasInitial {
+			initial = field.kind.zero
+		}
+		fmt.Fprintf(body, "var v %s\nvar null bool\nvar err error\n", field.kind.goType)
+		fmt.Fprintf(body, "if pMap.GetIsSetAndIncrement() {\nv, null, err = %s(message)\n} else {\n", reader)
+		fmt.Fprintf(body, "v, null, err = %s.%s(%s, %t, %t)\n}\n%s", slot, notEncoded, initial, hasInitial, field.details.Required, fail)
+	case operation.Tail:
+		initial, hasInitial := literal(op.InitialValue)
+		base := initial
+		if !hasInitial {
+			initial = field.kind.zero
+			base, _ = literal(op.BaseValue)
+		}
+		fmt.Fprintf(body, "var v %s\nvar null bool\nvar err error\n", field.kind.goType)
+		fmt.Fprintf(body, "if pMap.GetIsSetAndIncrement() {\nif v, null, err = %s(message); err == nil && !null {\n", reader)
+		fmt.Fprintf(body, "v = %s\n}\n} else {\n", fmt.Sprintf(field.applyTail, "v", fmt.Sprintf("%s.base(%s)", slot, base)))
+		fmt.Fprintf(body, "v, null, err = %s.copied(%s, %t, %t)\n}\n%s", slot, initial, hasInitial, field.details.Required, fail)
+		generator.usesValue = true
+	case operation.Delta:
+		if field.deltaReader == "" {
+			return fmt.Errorf("[%s] %s", name, "delta operator is not supported for this type")
+		}
+		deltaReader := generator.useReader(field.deltaReader)
+		if !field.details.Required {
+			deltaReader = generator.useReader("Optional" + field.deltaReader)
+		}
+		base, hasInitial := literal(op.InitialValue)
+		if !hasInitial {
+			base, _ = literal(op.BaseValue)
+		}
+		fmt.Fprintf(body, "var v %s\ndelta, null, err := %s(message)\n", field.kind.goType, deltaReader)
+		fmt.Fprintf(body, "if err == nil && !null {\nv, err = %s\n}\n%s", fmt.Sprintf(field.applyDelta, "delta", fmt.Sprintf("%s.base(%s)", slot, base)), fail)
+	default:
+		return fmt.Errorf("[%s] unsupported operation: %#v", name, field.operation)
+	}
+
+	fmt.Fprintf(body, "%s.assign(v, null)\n%s\t}\n", slot, assign("v", "null"))
+	return nil
+}
+
+// decimal generates a block decoding the exponent, and if it is not null the mantissa, of the decimal into a float64 passed to assign
+func (generator *generator) decimal(body *bytes.Buffer, field fielddecimal.FieldDecimal, assign func(value string, null string) string, ret string) error {
+	exponent, err := scalarOf(field.ExponentField)
+	if err != nil {
+		return err
+	}
+	mantissa, err := scalarOf(field.MantissaField)
+	if err != nil {
+		return err
+	}
+	generator.usesMath = true
+
+	fmt.Fprintf(body, "\t// %s (%d)\n\t{\nvar exponent int32\nvar exponentNull bool\n", field.FieldDetails.Name, field.FieldDetails.ID)
+	err = generator.scalar(body, exponent, func(value string, null string) string {
+		return fmt.Sprintf("exponent, exponentNull = %s, %s\n", value, null)
+	}, ret)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(body, "var decimal float64\nif !exponentNull {\nif exponent < -63 || exponent > 63 {\n")
+	fmt.Fprintf(body, "%sfmt.Errorf(\"[%%s] %%s\", %q, fasterrors.R1)\n}\nvar mantissa int64\n", ret, field.FieldDetails.Name)
+	err = generator.scalar(body, mantissa, func(value string, null string) string {
+		return fmt.Sprintf("mantissa = %s\n", value)
+	}, ret)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(body, "decimal = math.Pow(10, float64(exponent)) * float64(mantissa)\n}\n%s\t}\n", assign("decimal", "exponentNull"))
+	return nil
+}
+
+// sequence generates a block decoding the length of the sequence, and then each repeating group into the slice of the group type
+func (generator *generator) sequence(body *bytes.Buffer, field fieldsequence.FieldSequence, groupTypeName string, target string, hasTarget string) error {
+	length, err := scalarOf(field.LengthField)
+	if err != nil {
+		return err
+	}
+	name := field.FieldDetails.Name
+
+	fmt.Fprintf(body, "\t// %s (%d)\n\t{\nvar length uint32\nvar lengthNull bool\n", name, field.FieldDetails.ID)
+	err = generator.scalar(body, length, func(value string, null string) string {
+		return fmt.Sprintf("length, lengthNull = %s, %s\n", value, null)
+	}, "return ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(body, "if lengthNull {\n%s = %s[:0]\n", target, target)
+	if hasTarget != "" {
+		fmt.Fprintf(body, "%s = false\n", hasTarget)
+	}
+	fmt.Fprintf(body, "} else {\nif maxLength := decoder.SettingsOf(message).MaxSequenceLength; maxLength > 0 && length > maxLength {\n")
+	fmt.Fprintf(body, "return fmt.Errorf(\"[%%s] sequence of %%d repeating groups exceeds the maximum sequence length of %%d\", %q, length, maxLength)\n}\n", name)
+	fmt.Fprintf(body, "if uint32(cap(%s)) < length {\n%s = make([]%s, length)\n}\n%s = %s[:length]\n", target, target, groupTypeName, target, target)
+	fmt.Fprintf(body, "for index := range %s {\n", target)
+	if groupRequiresPmap(field.SequenceFields) {
+		fmt.Fprintf(body, "groupPMap, err := presencemap.New(message)\nif err == nil {\nerr = groupPMap.Check(message, %d)\n}\nif err != nil {\n", field.GroupLayout.Bits())
+		fmt.Fprintf(body, "return fmt.Errorf(\"[%%s] failed to decode pmap for repeating group [%%d], reason: %%s\", %q, index, err)\n}\n", name)
+	} else {
+		fmt.Fprintf(body, "groupPMap := presencemap.PresenceMap{}\n")
+	}
+	fmt.Fprintf(body, "if err := d.decode%s(message, &groupPMap, &%s[index]); err != nil {\n", groupTypeName, target)
+	fmt.Fprintf(body, "return fmt.Errorf(\"[%%s] failed to decode repeating group [%%d], reason: %%s\", %q, index, err)\n}\n}\n", name)
+	if hasTarget != "" {
+		fmt.Fprintf(body, "%s = true\n", hasTarget)
+	}
+	fmt.Fprintf(body, "}\n\t}\n")
+	return nil
+}
+
+func groupRequiresPmap(units []store.Unit) bool {
+	for _, unit := range units {
+		if unit.RequiresPmap() {
+			return true
+		}
+	}
+	return false
+}
+
+// scalarOf the unit, describing how its value is read and combined with previous values
+func scalarOf(unit store.Unit) (scalar, error) {
+	switch field := unit.(type) {
+	case fielduint32.FieldUInt32:
+		return scalar{details: field.FieldDetails, operation: field.Operation, kind: uint32Kind, reader: "UInt32", deltaReader: "Int64Delta", applyDelta: "%s.AddToUInt32(%s)"}, nil
+	case fieldint32.FieldInt32:
+		return scalar{details: field.FieldDetails, operation: field.Operation, kind: int32Kind, reader: "Int32", deltaReader: "Int64Delta", applyDelta: "%s.AddToInt32(%s)"}, nil
+	case fielduint64.FieldUInt64:
+		return scalar{details: field.FieldDetails, operation: field.Operation, kind: uint64Kind, reader: "UInt64", deltaReader: "BigIntDelta", applyDelta: "%s.AddToUInt64(%s)"}, nil
+	case fieldint64.FieldInt64:
+		return scalar{details: field.FieldDetails, operation: field.Operation, kind: int64Kind, reader: "Int64", deltaReader: "BigIntDelta", applyDelta: "%s.AddToInt64(%s)"}, nil
+	case fieldasciistring.FieldAsciiString:
+		return scalar{details: field.FieldDetails, operation: field.Operation, kind: stringKind, reader: "String", deltaReader: "StringDelta",
+			applyDelta: "%s.AddTo(%s)", applyTail: "value.StringValue{Value: %s}.TailOf(%s)"}, nil
+	case fieldunicodestring.FieldUnicodeString:
+		return scalar{details: field.FieldDetails, operation: field.Operation, kind: stringKind, reader: "UnicodeString", deltaReader: "UnicodeStringDelta",
+			applyDelta: "%s.AddTo(%s)", applyTail: "value.StringValue{Value: %s}.TailOf(%s)"}, nil
+	case fieldbytevector.FieldByteVector:
+		return scalar{details: field.FieldDetails, operation: field.Operation, kind: byteVectorKind, reader: "ByteVector", deltaReader: "ByteVectorDelta",
+			applyDelta: "%s.AddTo(%s)", applyTail: "value.ByteVector{Value: %s}.TailOf(%s)"}, nil
+	}
+
+	return scalar{}, fmt.Errorf("unsupported unit for code generation: %#v", unit)
+}
+
+// literal of the value of an operation as Go source, returning false if the value is null
+func literal(value fix.Value) (string, bool) {
+	if value == nil {
+		return "", false
+	}
+	switch t := value.Get().(type) {
+	case uint32, int32, uint64, int64:
+		return fmt.Sprintf("%d", t), true
+	case string:
+		return strconv.Quote(t), true
+	case []byte:
+		return fmt.Sprintf("[]byte(%q)", t), true
+	}
+	return "", false
+}
+
+// slot is the name of the field in the generated dictionary holding the previous value of the key
+func (generator *generator) slot(key dictionary.Key, valueKind kind) string {
+	slotKey := slotKey{key: key, kind: valueKind.name}
+	if name, exists := generator.slots[slotKey]; exists {
+		return name
+	}
+
+	taken := make(map[string]bool)
+	for _, name := range generator.slots {
+		taken[name] = true
+	}
+	name := exportedIdentifier(key.Name)
+	if key.Dictionary != dictionary.GlobalDictionary {
+		// keys outside the global dictionary are suffixed with the template or type they are scoped to, or else the dictionary they are in
+		scope := key.Scope
+		if scope == "" {
+			scope = key.Dictionary
+		}
+		name = exportedIdentifier(key.Name + " " + scope)
+	}
+	if taken[name] {
+		name = uniqueIdentifier(name+valueKind.name, taken, uint64(len(generator.slots)))
+	}
+
+	generator.slots[slotKey] = name
+	generator.slotOrder = append(generator.slotOrder, slotKey)
+	generator.slotKinds[valueKind.name] = valueKind
+	return name
+}
+
+func (generator *generator) useReader(reader string) string {
+	generator.readers[reader] = true
+	if strings.HasPrefix(reader, "Optional") || strings.HasSuffix(reader, "Delta") {
+		generator.usesValue = true
+	}
+	return "read" + reader
+}
+
+func (generator *generator) typeName(name string) string {
+	return uniqueIdentifier(name, generator.typeNames, uint64(len(generator.typeNames)))
+}
+
+// exportedIdentifier from a name within a template, removing every character that is not a letter or digit and capitalising the start of each word
+func exportedIdentifier(name string) string {
+	identifier := strings.Builder{}
+	startOfWord := true
+	for _, char := range name {
+		if !unicode.IsLetter(char) && !unicode.IsDigit(char) {
+			startOfWord = true
+			continue
+		}
+		if startOfWord {
+			char = unicode.ToUpper(char)
+			startOfWord = false
+		}
+		identifier.WriteRune(char)
+	}
+
+	if identifier.Len() == 0 || unicode.IsDigit([]rune(identifier.String())[0]) {
+		return "Field" + identifier.String()
+	}
+	return identifier.String()
+}
+
+// uniqueIdentifier returns the identifier, or if it is already taken, the identifier suffixed with the id given
+func uniqueIdentifier(identifier string, taken map[string]bool, id uint64) string {
+	unique := identifier
+	for suffix := 0; taken[unique]; suffix++ {
+		unique = fmt.Sprintf("%s%d", identifier, id)
+		if suffix > 0 {
+			unique = fmt.Sprintf("%s%d_%d", identifier, id, suffix)
+		}
+	}
+	taken[unique] = true
+	return unique
+}
@@ -0,0 +1,195 @@
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"io/ioutil"
+	"log"
+	"os"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/Guardian-Development/fastengine/pkg/engine"
+	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
+	"github.com/Guardian-Development/fastengine/pkg/fast/template/codegen/internal/exampledecoding"
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+const exampleTemplates = "../../../../test/example-decoding-tests/templates.xml"
+
+func TestGeneratedExampleDecodingIsUpToDate(t *testing.T) {
+	// Arrange
+	logger := log.New(os.Stdout, "codegen: ", log.Ldate|log.Ltime|log.Lshortfile)
+	templateFile, _ := os.Open(exampleTemplates)
+	defer templateFile.Close()
+	expected, _ := ioutil.ReadFile("internal/exampledecoding/templates_fast.go")
+
+	// Act
+	generated, err := Generate(templateFile, Options{Package: "exampledecoding", Source: "templates.xml"}, logger)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Got an error when none was expected: %s", err)
+	}
+	if !bytes.Equal(generated, expected) {
+		t.Errorf("Expected the generated code to match internal/exampledecoding/templates_fast.go, run go generate to update it")
+	}
+}
+
+func TestGenerateOnlyRequestedTemplates(t *testing.T) {
+	// Arrange
+	logger := log.New(os.Stdout, "codegen: ", log.Ldate|log.Ltime|log.Lshortfile)
+	templateFile, _ := os.Open(exampleTemplates)
+	defer templateFile.Close()
+
+	// Act
+	generated, err := Generate(templateFile, Options{Package: "b3", Source: "templates.xml", TemplateIDs: []uint32{122}}, logger)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Got an error when none was expected: %s", err)
+	}
+	if !bytes.Contains(generated, []byte("type MDSequenceReset struct")) || bytes.Contains(generated, []byte("MDSnapshotFullRefresh153")) {
+		t.Errorf("Expected only the MDSequenceReset template to be generated, actual: %s", generated)
+	}
+}
+
+func TestGenerateReturnsErrorForUnknownTemplate(t *testing.T) {
+	// Arrange
+	logger := log.New(os.Stdout, "codegen: ", log.Ldate|log.Ltime|log.Lshortfile)
+	templateFile, _ := os.Open(exampleTemplates)
+	defer templateFile.Close()
+
+	// Act
+	_, err := Generate(templateFile, Options{Package: "b3", Source: "templates.xml", TemplateIDs: []uint32{999}}, logger)
+
+	// Assert
+	if err == nil {
+		t.Errorf("Expected an error generating a template that is not in the file")
+	}
+}
+
+func TestGeneratedDecoderResetsBeforeDecodingTemplateMarkedReset(t *testing.T) {
+	// Arrange
+	logger := log.New(os.Stdout, "codegen: ", log.Ldate|log.Ltime|log.Lshortfile)
+	templateFile, _ := os.Open("../../../../test/test_reset_attribute_template.xml")
+	defer templateFile.Close()
+
+	// Act
+	generated, err := Generate(templateFile, Options{Package: "reset", Source: "test_reset_attribute_template.xml"}, logger)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Got an error when none was expected: %s", err)
+	}
+	if !bytes.Contains(generated, []byte("case SequenceResetTemplateID:\n\t\td.Reset()\n")) {
+		t.Errorf("Expected the dictionary to be reset before decoding the SequenceReset template, actual: %s", generated)
+	}
+	if bytes.Contains(generated, []byte("case HeartbeatTemplateID:\n\t\td.Reset()\n")) {
+		t.Errorf("Expected the dictionary not to be reset before decoding the Heartbeat template, actual: %s", generated)
+	}
+}
+
+func TestGeneratedDecoderMatchesEngineOnSnapshotMessages(t *testing.T) {
+	assertGeneratedDecoderMatchesEngine(t, "../../../../test/example-decoding-tests/snapshot-messages-hex.txt")
+}
+
+func TestGeneratedDecoderMatchesEngineOnInstrumentMessages(t *testing.T) {
+	assertGeneratedDecoderMatchesEngine(t, "../../../../test/example-decoding-tests/instrument-messages-hex.txt")
+}
+
+func assertGeneratedDecoderMatchesEngine(t *testing.T, messagesFile string) {
+	logger := log.New(os.Stdout, "codegen: ", log.Ldate|log.Ltime|log.Lshortfile)
+	fastEngine, err := engine.NewFromTemplateFile(exampleTemplates, engine.WithLogger(logger))
+	if err != nil {
+		t.Fatalf("unable to load engine: %v", err)
+	}
+	generated := exampledecoding.Decoder{}
+	messages := exampledecoding.Messages{}
+
+	for index, message := range readHexMessages(t, messagesFile) {
+		expected, err := fastEngine.Deserialise(decoder.NewCursor(message))
+		if err != nil {
+			t.Fatalf("unable to decode message %d with the engine: %v", index, err)
+		}
+		templateID, err := generated.Decode(decoder.NewCursor(message), &messages)
+		if err != nil {
+			t.Fatalf("unable to decode message %d with the generated decoder: %v", index, err)
+		}
+
+		var actual reflect.Value
+		switch templateID {
+		case exampledecoding.MDSequenceResetTemplateID:
+			actual = reflect.ValueOf(messages.MDSequenceReset)
+		case exampledecoding.MDSecurityList141TemplateID:
+			actual = reflect.ValueOf(messages.MDSecurityList141)
+		case exampledecoding.MDSnapshotFullRefresh153TemplateID:
+			actual = reflect.ValueOf(messages.MDSnapshotFullRefresh153)
+		default:
+			t.Fatalf("message %d decoded with unexpected template %d", index, templateID)
+		}
+		assertStructMatchesMessage(t, index, expected, actual)
+	}
+}
+
+// assertStructMatchesMessage checks every tagged field of the generated struct holds the value of the tag in the message, with its Has field set if not null
+func assertStructMatchesMessage(t *testing.T, index int, message *fix.Message, actual reflect.Value) {
+	actualType := actual.Type()
+	for i := 0; i < actualType.NumField(); i++ {
+		field := actualType.Field(i)
+		structTag, tagged := field.Tag.Lookup("fast")
+		if !tagged {
+			continue
+		}
+		tag, _ := strconv.ParseUint(structTag, 10, 64)
+		value, exists := message.Tags[tag]
+		if !exists {
+			t.Errorf("message %d: tag %d of %s.%s was not decoded by the engine", index, tag, actualType, field.Name)
+			continue
+		}
+
+		_, isNull := value.(fix.NullValue)
+		if has := actual.FieldByName("Has" + field.Name); has.IsValid() && has.Bool() == isNull {
+			t.Errorf("message %d: expected %s.Has%s to be %t", index, actualType, field.Name, !isNull)
+		}
+
+		fieldValue := actual.Field(i)
+		switch typedValue := value.(type) {
+		case fix.NullValue:
+			if fieldValue.Kind() == reflect.Slice && fieldValue.Len() != 0 || fieldValue.Kind() != reflect.Slice && !fieldValue.IsZero() {
+				t.Errorf("message %d: expected %s.%s to be empty for a null value, actual: %v", index, actualType, field.Name, fieldValue.Interface())
+			}
+		case fix.SequenceValue:
+			if fieldValue.Len() != len(typedValue.Values) {
+				t.Errorf("message %d: expected %s.%s to have %d groups, actual: %d", index, actualType, field.Name, len(typedValue.Values), fieldValue.Len())
+				continue
+			}
+			for group := range typedValue.Values {
+				assertStructMatchesMessage(t, index, &typedValue.Values[group], fieldValue.Index(group))
+			}
+		default:
+			if !reflect.DeepEqual(value.Get(), fieldValue.Interface()) {
+				t.Errorf("message %d: expected %s.%s to be %#v, actual: %#v", index, actualType, field.Name, value.Get(), fieldValue.Interface())
+			}
+		}
+	}
+}
+
+func readHexMessages(t *testing.T, messagesFile string) [][]byte {
+	file, err := os.Open(messagesFile)
+	if err != nil {
+		t.Fatalf("unable to open messages file: %v", err)
+	}
+	defer file.Close()
+
+	messages := [][]byte{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		message, _ := hex.DecodeString(scanner.Text())
+		messages = append(messages, message)
+	}
+
+	return messages
+}
@@ -0,0 +1,4 @@
+// Package exampledecoding holds the code generated by fastgen for the example decoding templates, used to test the generated decoders against the engine.
+package exampledecoding
+
+//go:generate go run github.com/Guardian-Development/fastengine/cmd/fastgen -template ../../../../../../test/example-decoding-tests/templates.xml -output templates_fast.go
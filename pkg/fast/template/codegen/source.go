@@ -0,0 +1,254 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// reader of a value off the message, as a pair of generated helpers reading the required and optional (nullable) encoding of the value
+type reader struct {
+	goType string
+	zero   string
+	// required and optional calls to the decoder primitives, and the formats converting their result into the value returned
+	required        string
+	requiredConvert string
+	optional        string
+	optionalType    string
+	optionalConvert string
+}
+
+var readers = map[string]reader{
+	"UInt32": {goType: "uint32", zero: "0", required: "decoder.ReadUInt32", requiredConvert: "%s.Value",
+		optional: "decoder.ReadOptionalUInt32", optionalType: "value.UInt32Value", optionalConvert: "%s.Value"},
+	"Int32": {goType: "int32", zero: "0", required: "decoder.ReadInt32", requiredConvert: "%s.Value",
+		optional: "decoder.ReadOptionalInt32", optionalType: "value.Int32Value", optionalConvert: "%s.Value"},
+	"UInt64": {goType: "uint64", zero: "0", required: "decoder.ReadUInt64", requiredConvert: "%s.Value",
+		optional: "decoder.ReadOptionalUInt64", optionalType: "value.UInt64Value", optionalConvert: "%s.Value"},
+	"Int64": {goType: "int64", zero: "0", required: "decoder.ReadInt64", requiredConvert: "%s.Value",
+		optional: "decoder.ReadOptionalInt64", optionalType: "value.Int64Value", optionalConvert: "%s.Value"},
+	"String": {goType: "string", zero: `""`, required: "decoder.ReadString", requiredConvert: "%s.Value",
+		optional: "decoder.ReadOptionalString", optionalType: "value.StringValue", optionalConvert: "%s.Value"},
+	"UnicodeString": {goType: "string", zero: `""`, required: "decoder.ReadByteVector", requiredConvert: "string(%s.Value)",
+		optional: "decoder.ReadOptionalByteVector", optionalType: "value.ByteVector", optionalConvert: "string(%s.Value)"},
+	"ByteVector": {goType: "[]byte", zero: "nil", required: "decoder.ReadByteVector", requiredConvert: "%s.Value",
+		optional: "decoder.ReadOptionalByteVector", optionalType: "value.ByteVector", optionalConvert: "%s.Value"},
+	"Int64Delta": {goType: "value.Int64Value", zero: "value.Int64Value{}", required: "decoder.ReadInt64", requiredConvert: "%s",
+		optional: "decoder.ReadOptionalInt64", optionalType: "value.Int64Value", optionalConvert: "%s"},
+	"BigIntDelta": {goType: "value.BigInt", zero: "value.BigInt{}", required: "decoder.ReadBigInt", requiredConvert: "%s",
+		optional: "decoder.ReadOptionalBigInt", optionalType: "value.BigInt", optionalConvert: "%s"},
+	"StringDelta": {goType: "value.StringValue", zero: "value.StringValue{}", required: "decoder.AsciiStringDeltaDecoder{}.ReadValue", requiredConvert: "%s.(value.StringValue)",
+		optional: "decoder.AsciiStringDeltaDecoder{}.ReadOptionalValue", optionalType: "value.StringValue", optionalConvert: "%s"},
+	"UnicodeStringDelta": {goType: "value.StringValue", zero: "value.StringValue{}", required: "decoder.ByteVectorDeltaDecoder{}.ReadValue",
+		requiredConvert: "value.StringValue{Value: string(%[1]s.(value.ByteVector).Value), ItemsToRemove: %[1]s.(value.ByteVector).ItemsToRemove}",
+		optional:        "decoder.ByteVectorDeltaDecoder{}.ReadOptionalValue", optionalType: "value.ByteVector",
+		optionalConvert: "value.StringValue{Value: string(%[1]s.Value), ItemsToRemove: %[1]s.ItemsToRemove}"},
+	"ByteVectorDelta": {goType: "value.ByteVector", zero: "value.ByteVector{}", required: "decoder.ByteVectorDeltaDecoder{}.ReadValue", requiredConvert: "%s.(value.ByteVector)",
+		optional: "decoder.ByteVectorDeltaDecoder{}.ReadOptionalValue", optionalType: "value.ByteVector", optionalConvert: "%s"},
+}
+
+// source of the generated file, before it is formatted
+func (generator *generator) source(options Options) []byte {
+	source := bytes.Buffer{}
+	fmt.Fprintf(&source, "// Code generated by fastgen from %s. DO NOT EDIT.\n\npackage %s\n\nimport (\n\"fmt\"\n", options.Source, options.Package)
+	if generator.usesMath {
+		fmt.Fprintf(&source, "\"math\"\n")
+	}
+	fmt.Fprintf(&source, "\n\"github.com/Guardian-Development/fastengine/pkg/fast/decoder\"\n")
+	fmt.Fprintf(&source, "fasterrors \"github.com/Guardian-Development/fastengine/pkg/fast/errors\"\n")
+	fmt.Fprintf(&source, "\"github.com/Guardian-Development/fastengine/pkg/fast/presencemap\"\n")
+	if generator.usesValue {
+		fmt.Fprintf(&source, "\"github.com/Guardian-Development/fastengine/pkg/fast/value\"\n")
+	}
+	fmt.Fprintf(&source, ")\n\n")
+
+	source.Write(generator.types.Bytes())
+	generator.writeDecoder(&source)
+	source.Write(generator.functions.Bytes())
+	generator.writeSlots(&source)
+	generator.writeReaders(&source)
+	return source.Bytes()
+}
+
+func (generator *generator) writeDecoder(source *bytes.Buffer) {
+	fmt.Fprintf(source, "// Messages holds a struct for every generated template, that Decode reuses for each message decoded with the template\ntype Messages struct {\n")
+	for _, template := range generator.templates {
+		fmt.Fprintf(source, "%s %s\n", template.typeName, template.typeName)
+	}
+	fmt.Fprintf(source, "}\n\n")
+
+	fmt.Fprintf(source, `// Decoder of messages encoded with the generated templates, holding the dictionary of previous values. A Decoder is not safe to use from many goroutines.
+type Decoder struct {
+	// ResetNever keeps the dictionary of previous values between messages, by default it is reset as each message header is read, as the engine does by default
+	ResetNever bool
+
+	dictionary dictionary
+}
+
+// Reset the dictionary of previous values
+func (d *Decoder) Reset() {
+	d.dictionary = dictionary{}
+}
+
+// ReadHeader of the next message, returning its presence map and template ID. The body of the message is then decoded with the Decode function of the template.
+func (d *Decoder) ReadHeader(message decoder.Reader) (presencemap.PresenceMap, uint32, error) {
+	if !d.ResetNever {
+		d.Reset()
+	}
+
+	pMap, err := presencemap.New(message)
+	if err != nil {
+		return pMap, 0, fmt.Errorf("unable to create presence map for message, reason: %%s", err)
+	}
+	var templateID uint32
+%s
+	return pMap, templateID, nil
+}
+
+// Decode the next message into the struct for its template within messages, returning the template ID of the message
+func (d *Decoder) Decode(message decoder.Reader, messages *Messages) (uint32, error) {
+	pMap, templateID, err := d.ReadHeader(message)
+	if err != nil {
+		return templateID, err
+	}
+
+	switch templateID {
+`, generator.readHeader.String())
+	for _, template := range generator.templates {
+		fmt.Fprintf(source, "case %sTemplateID:\n", template.typeName)
+		if template.reset {
+			fmt.Fprintf(source, "d.Reset()\n")
+		}
+		fmt.Fprintf(source, "return templateID, d.Decode%s(message, &pMap, &messages.%s)\n", template.typeName, template.typeName)
+	}
+	fmt.Fprintf(source, "}\n\nreturn templateID, fmt.Errorf(\"%%s: id %%d\", fasterrors.D9, templateID)\n}\n\n")
+}
+
+// writeSlots writes the dictionary of previous values, with a typed slot for each dictionary key, and the operators applied to each type of slot
+func (generator *generator) writeSlots(source *bytes.Buffer) {
+	fmt.Fprintf(source, `// previousState of a value in the dictionary
+type previousState uint8
+
+const (
+	undefinedValue previousState = iota
+	emptyValue
+	assignedValue
+)
+
+// dictionary of previous values, the zero value has every value undefined
+type dictionary struct {
+`)
+	for _, key := range generator.slotOrder {
+		fmt.Fprintf(source, "%s %sSlot // %s\n", generator.slots[key], strings.ToLower(key.kind), key.key)
+	}
+	fmt.Fprintf(source, "}\n\n")
+
+	kindNames := make([]string, 0, len(generator.slotKinds))
+	for name := range generator.slotKinds {
+		kindNames = append(kindNames, name)
+	}
+	sort.Strings(kindNames)
+
+	for _, name := range kindNames {
+		valueKind := generator.slotKinds[name]
+		slotType := strings.ToLower(name) + "Slot"
+		fmt.Fprintf(source, `// %[1]s is the previous value of a %[2]s dictionary entry
+type %[1]s struct {
+	value %[2]s
+	state previousState
+}
+
+func (slot *%[1]s) assign(value %[2]s, null bool) {
+	if null {
+		slot.state = emptyValue
+		return
+	}
+	slot.value, slot.state = value, assignedValue
+}
+
+// copied is the value of a field not encoded in the message with the <copy/> or <tail/> operators
+func (slot *%[1]s) copied(initialValue %[2]s, hasInitialValue bool, required bool) (%[2]s, bool, error) {
+	switch slot.state {
+	case assignedValue:
+		return slot.value, false, nil
+	case emptyValue:
+		return %[3]s, true, nil
+	}
+	if !hasInitialValue {
+		if required {
+			return %[3]s, false, fmt.Errorf("%%s", fasterrors.D5)
+		}
+		return %[3]s, true, nil
+	}
+	return initialValue, false, nil
+}
+
+// base value deltas and tails are applied to, the previous value if assigned, else the initial or base value of the field
+func (slot *%[1]s) base(baseValue %[2]s) %[2]s {
+	if slot.state == assignedValue {
+		return slot.value
+	}
+	return baseValue
+}
+
+`, slotType, valueKind.goType, valueKind.zero)
+		if valueKind != stringKind && valueKind != byteVectorKind {
+			fmt.Fprintf(source, `// incremented is the value of a field not encoded in the message with the <increment/> operator
+func (slot *%[1]s) incremented(initialValue %[2]s, hasInitialValue bool, required bool) (%[2]s, bool, error) {
+	switch slot.state {
+	case assignedValue:
+		return slot.value + 1, false, nil
+	case emptyValue:
+		if required {
+			return 0, false, fmt.Errorf("%%s", fasterrors.D6)
+		}
+		return 0, true, nil
+	}
+	return slot.copied(initialValue, hasInitialValue, required)
+}
+
+`, slotType, valueKind.goType)
+		}
+	}
+}
+
+// writeReaders writes every reader helper used, each returning the value read and whether it was null
+func (generator *generator) writeReaders(source *bytes.Buffer) {
+	names := make([]string, 0, len(generator.readers))
+	for name := range generator.readers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !strings.HasPrefix(name, "Optional") {
+			reader := readers[name]
+			fmt.Fprintf(source, `func read%s(message decoder.Reader) (%s, bool, error) {
+	readValue, err := %s(message)
+	if err != nil {
+		return %s, false, err
+	}
+	return %s, false, nil
+}
+
+`, name, reader.goType, reader.required, reader.zero, fmt.Sprintf(reader.requiredConvert, "readValue"))
+			continue
+		}
+
+		reader := readers[strings.TrimPrefix(name, "Optional")]
+		fmt.Fprintf(source, `func read%s(message decoder.Reader) (%s, bool, error) {
+	readValue, err := %s(message)
+	if err != nil {
+		return %s, false, err
+	}
+	switch t := readValue.(type) {
+	case %s:
+		return %s, false, nil
+	}
+	return %s, true, nil
+}
+
+`, name, reader.goType, reader.optional, reader.zero, reader.optionalType, fmt.Sprintf(reader.optionalConvert, "t"), reader.zero)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/Guardian-Development/fastengine/pkg/fast/template/loader/converter"
 	"github.com/Guardian-Development/fastengine/pkg/fast/template/loader/loadint32"
 	"github.com/Guardian-Development/fastengine/pkg/fast/template/loader/loadint64"
+	"github.com/Guardian-Development/fastengine/pkg/fast/template/loader/loadproperties"
 	"github.com/Guardian-Development/fastengine/pkg/fast/template/structure"
 )
 
@@ -19,14 +20,16 @@ func Load(tagInTemplate *xml.Tag, fieldDetails properties.Properties) (fielddeci
 		if err != nil {
 			return fielddecimal.FieldDecimal{}, fmt.Errorf("[%s][%v] failed to load exponent, reason: %s", tagInTemplate.Type, fieldDetails, err)
 		}
-		exponentField.FieldDetails.Name = fmt.Sprintf("%sExponent", fieldDetails.Name)
+		exponentField.FieldDetails.Rename(fmt.Sprintf("%sExponent", fieldDetails.Name))
+		exponentField.FieldDetails.Rekey(fmt.Sprintf("%sExponent", fieldDetails.Key.Name), fieldDetails.Key.Namespace)
 		mantissaField, err := loadint64.LoadWithConverter(tagInTemplate, fieldDetails, converter.ToMantissa)
 		if err != nil {
 			return fielddecimal.FieldDecimal{}, fmt.Errorf("[%s][%v] failed to load mantissa, reason: %s", tagInTemplate.Type, fieldDetails, err)
 		}
 
 		mantissaField.FieldDetails.Required = true
-		mantissaField.FieldDetails.Name = fmt.Sprintf("%sMantissa", fieldDetails.Name)
+		mantissaField.FieldDetails.Rename(fmt.Sprintf("%sMantissa", fieldDetails.Name))
+		mantissaField.FieldDetails.Rekey(fmt.Sprintf("%sMantissa", fieldDetails.Key.Name), fieldDetails.Key.Namespace)
 
 		return fielddecimal.New(fieldDetails, exponentField, mantissaField), nil
 	}
@@ -41,7 +44,9 @@ func Load(tagInTemplate *xml.Tag, fieldDetails properties.Properties) (fielddeci
 		if structure.IsNullString(exponentName) {
 			exponentName = fmt.Sprintf("%sExponent", fieldDetails.Name)
 		}
-		exponentField.FieldDetails.Name = exponentName
+		exponentField.FieldDetails.Rename(exponentName)
+		exponentField.FieldDetails.Rekey(exponentName, fieldDetails.Key.Namespace)
+		loadproperties.LoadKey(&exponentTag, &exponentField.FieldDetails)
 
 		mantissaTag := tagInTemplate.NestedTags[1]
 		mantissaField, err := loadint64.Load(&mantissaTag, fieldDetails)
@@ -53,7 +58,9 @@ func Load(tagInTemplate *xml.Tag, fieldDetails properties.Properties) (fielddeci
 		if structure.IsNullString(mantissaName) {
 			mantissaName = fmt.Sprintf("%sMantissa", fieldDetails.Name)
 		}
-		mantissaField.FieldDetails.Name = mantissaName
+		mantissaField.FieldDetails.Rename(mantissaName)
+		mantissaField.FieldDetails.Rekey(mantissaName, fieldDetails.Key.Namespace)
+		loadproperties.LoadKey(&mantissaTag, &mantissaField.FieldDetails)
 
 		return fielddecimal.New(fieldDetails, exponentField, mantissaField), nil
 	}
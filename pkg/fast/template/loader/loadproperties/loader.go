@@ -8,11 +8,12 @@ import (
 
 	"github.com/Guardian-Development/fastengine/internal/xml"
 	"github.com/Guardian-Development/fastengine/pkg/fast/field/properties"
+	"github.com/Guardian-Development/fastengine/pkg/fast/template/structure"
 )
 
 var letters = []rune("abcdefghijklmnopqrstuvwxyz")
 
-// Load id, name, and required presence of field
+// Load id, name, required presence, and dictionary key of field
 func Load(tagInTemplate *xml.Tag, logger *log.Logger) (properties.Properties, error) {
 	ID, err := getFieldID(tagInTemplate)
 	if err != nil {
@@ -32,9 +33,33 @@ func Load(tagInTemplate *xml.Tag, logger *log.Logger) (properties.Properties, er
 	}
 
 	fieldDetails := properties.New(ID, name, required, logger)
+	LoadKey(tagInTemplate, &fieldDetails)
 	return fieldDetails, nil
 }
 
+// LoadKey of the dictionary entry of the field from the key attribute of its operator, in the namespace given by the ns attribute of the operator, or else
+// of the field. A field without a key is keyed by its name, and a key without a namespace is left in the namespace it was in.
+func LoadKey(tagInTemplate *xml.Tag, fieldDetails *properties.Properties) {
+	key, namespace := fieldDetails.Key.Name, fieldDetails.Key.Namespace
+	if fieldNamespace := tagInTemplate.Attributes[structure.NamespaceAttribute]; fieldNamespace != "" {
+		namespace = fieldNamespace
+	}
+
+	if len(tagInTemplate.NestedTags) == 1 && structure.IsOperation(&tagInTemplate.NestedTags[0]) {
+		operationTag := tagInTemplate.NestedTags[0]
+		if operationKey := operationTag.Attributes[structure.KeyAttribute]; operationKey != "" {
+			key = operationKey
+		}
+		if operationNamespace := operationTag.Attributes[structure.NamespaceAttribute]; operationNamespace != "" {
+			namespace = operationNamespace
+		}
+	}
+
+	if key != fieldDetails.Key.Name || namespace != fieldDetails.Key.Namespace {
+		fieldDetails.Rekey(key, namespace)
+	}
+}
+
 func getRandomName(fieldName string) string {
 	b := make([]rune, 8)
 	for i := range b {
@@ -6,7 +6,9 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 
+	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
 	"github.com/Guardian-Development/fastengine/pkg/fast/field/fieldsequence"
 	"github.com/Guardian-Development/fastengine/pkg/fast/field/fielduint32"
 	"github.com/Guardian-Development/fastengine/pkg/fast/field/properties"
@@ -41,13 +43,105 @@ func Load(templateFile *os.File, logger *log.Logger) (store.Store, error) {
 	return loadStoreFromXML(xmlTags, logger)
 }
 
+// dictionaryScope of the units being loaded, which is inherited from the <templates/>, <template/> and <sequence/> tags the units are within. A unit
+// keeps its previous value in the dictionary named by the closest of these tags, or its own tag or operator, and in the global dictionary if none do.
+type dictionaryScope struct {
+	dictionary      string
+	templateName    string
+	applicationType string
+	namespace       string
+}
+
+// within the tag, taking the dictionary and namespace named by the tag, and the application type named by a <typeRef/> nested within it
+func (scope dictionaryScope) within(tagInTemplate *tokenxml.Tag) dictionaryScope {
+	if dictionaryName := tagInTemplate.Attributes[structure.DictionaryAttribute]; !structure.IsNullString(dictionaryName) {
+		scope.dictionary = dictionaryName
+	}
+	if namespace := tagInTemplate.Attributes[structure.NamespaceAttribute]; !structure.IsNullString(namespace) {
+		scope.namespace = namespace
+	}
+	for _, nestedTag := range tagInTemplate.NestedTags {
+		if nestedTag.Type == structure.TypeRefTag {
+			scope.applicationType = nestedTag.Attributes["name"]
+		}
+	}
+
+	return scope
+}
+
+// apply the scope to the unit of the tag, moving its dictionary entry to the dictionary named by the operator of the tag, the tag, or the scope. A key
+// not given a namespace by the operator or tag is in the namespace of the scope.
+func (scope dictionaryScope) apply(tagInTemplate *tokenxml.Tag, fieldDetails *properties.Properties) {
+	scope = scope.within(tagInTemplate)
+	if len(tagInTemplate.NestedTags) == 1 && structure.IsOperation(&tagInTemplate.NestedTags[0]) {
+		scope = scope.within(&tagInTemplate.NestedTags[0])
+	}
+
+	if structure.IsNullString(fieldDetails.Key.Namespace) {
+		fieldDetails.Key.Namespace = scope.namespace
+	}
+	fieldDetails.InDictionary(scope.dictionary, scope.templateName, scope.applicationType)
+}
+
+// templateRefs resolves each static <templateRef/> to the <template/> it names, which may be anywhere within the <templates/>. The templates being inlined
+// are tracked, so a template referring to itself, directly or through the templates it refers to, is rejected rather than inlined forever.
+type templateRefs struct {
+	templates map[string]*tokenxml.Tag
+	inlining  []string
+}
+
+// newTemplateRefs to every named template within the <templates/>, a name given to more than one template is kept as nil so it can not be referred to
+func newTemplateRefs(xmlTags *tokenxml.Tag) *templateRefs {
+	refs := &templateRefs{templates: make(map[string]*tokenxml.Tag)}
+	for index := range xmlTags.NestedTags {
+		templateTag := &xmlTags.NestedTags[index]
+		name := templateTag.Attributes["name"]
+		if templateTag.Type != structure.TemplateTag || structure.IsNullString(name) {
+			continue
+		}
+		if _, exists := refs.templates[name]; exists {
+			templateTag = nil
+		}
+		refs.templates[name] = templateTag
+	}
+
+	return refs
+}
+
+// inline the units of the template named by the static <templateRef/>, loaded within the scope of the template or sequence holding the reference, so
+// they take their place within its presence map and dictionaries as if they had been written there
+func (refs *templateRefs) inline(templateRef *tokenxml.Tag, scope dictionaryScope, logger *log.Logger) ([]store.Unit, error) {
+	name := templateRef.Attributes["name"]
+	if structure.IsNullString(name) {
+		return nil, fmt.Errorf("dynamic template references are not supported, <templateRef/> must name the template it refers to")
+	}
+	referenced, exists := refs.templates[name]
+	if !exists {
+		return nil, fmt.Errorf("<templateRef/> refers to template %s, which does not exist", name)
+	}
+	if referenced == nil {
+		return nil, fmt.Errorf("<templateRef/> refers to template %s, which is the name of more than one template", name)
+	}
+	for _, inlining := range refs.inlining {
+		if inlining == name {
+			return nil, fmt.Errorf("<templateRef/> to template %s is cyclic: %s -> %s", name, strings.Join(refs.inlining, " -> "), name)
+		}
+	}
+
+	refs.inlining = append(refs.inlining, name)
+	defer func() { refs.inlining = refs.inlining[:len(refs.inlining)-1] }()
+	return createTemplateUnits(referenced.NestedTags, scope, refs, logger)
+}
+
 func loadStoreFromXML(xmlTags tokenxml.Tag, logger *log.Logger) (store.Store, error) {
 	templateStore := store.Store{
 		Templates: make(map[uint32]store.Template),
 	}
+	scope := dictionaryScope{dictionary: dictionary.GlobalDictionary, applicationType: dictionary.AnyType}.within(&xmlTags)
+	refs := newTemplateRefs(&xmlTags)
 
 	for _, templateXMLElement := range xmlTags.NestedTags {
-		template, err := createTemplate(&templateXMLElement, logger)
+		template, err := createTemplate(&templateXMLElement, scope, refs, logger)
 		if err != nil {
 			logger.Printf("unable to create template, reason: %s", err)
 			return store.Store{}, fmt.Errorf("[%s][%s] failed loading templates at parsing xml element, reason: %s", templateXMLElement.Type, templateXMLElement.Attributes["id"], err)
@@ -66,38 +160,95 @@ func loadStoreFromXML(xmlTags tokenxml.Tag, logger *log.Logger) (store.Store, er
 		templateStore.Templates[uint32(templateID)] = template
 	}
 
+	templateStore.Slots = store.SlotsOf(templateStore.Templates)
 	return templateStore, nil
 }
 
-func createTemplate(templateRoot *tokenxml.Tag, logger *log.Logger) (store.Template, error) {
+func createTemplate(templateRoot *tokenxml.Tag, scope dictionaryScope, refs *templateRefs, logger *log.Logger) (store.Template, error) {
 	if templateRoot.Type != structure.TemplateTag {
 		return store.Template{}, fmt.Errorf("expected to find template tag, but found %s", templateRoot.Type)
 	}
 
+	reset, err := loadReset(templateRoot)
+	if err != nil {
+		logger.Printf("unable to load reset attribute of template: %v", err)
+		return store.Template{}, err
+	}
+
 	template := store.Template{
-		TemplateUnits: make([]store.Unit, len(templateRoot.NestedTags)),
-		Logger:        logger,
+		Logger: logger,
+		Reset:  reset,
+	}
+
+	// the template dictionary is scoped by the name of the template, or its id if it has no name
+	scope.templateName = templateRoot.Attributes["name"]
+	if structure.IsNullString(scope.templateName) {
+		scope.templateName = templateRoot.Attributes["id"]
+	}
+	scope = scope.within(templateRoot)
+
+	refs.inlining = append(refs.inlining[:0], templateRoot.Attributes["name"])
+	template.TemplateUnits, err = createTemplateUnits(templateRoot.NestedTags, scope, refs, logger)
+	if err != nil {
+		logger.Printf("unable to create unit within template, reason: %s, current template loaded: %v", err, templateRoot.Attributes["name"])
+		return store.Template{}, err
+	}
+
+	return template, nil
+}
+
+// createTemplateUnits of the tags within a template, skipping the <typeRef/> naming the application type of the template
+func createTemplateUnits(tagsInTemplate []tokenxml.Tag, scope dictionaryScope, refs *templateRefs, logger *log.Logger) ([]store.Unit, error) {
+	units := make([]store.Unit, 0, len(tagsInTemplate))
+	for index := range tagsInTemplate {
+		if tagsInTemplate[index].Type == structure.TypeRefTag {
+			continue
+		}
+
+		var err error
+		if units, err = appendTemplateUnit(units, &tagsInTemplate[index], scope, refs, logger); err != nil {
+			return nil, err
+		}
 	}
 
-	for unitNumber, tagInTemplate := range templateRoot.NestedTags {
-		templateUnit, err := createTemplateUnit(&tagInTemplate, logger)
+	return units, nil
+}
 
+// appendTemplateUnit of the tag to the units, or every unit of the template named by a static <templateRef/>
+func appendTemplateUnit(units []store.Unit, tagInTemplate *tokenxml.Tag, scope dictionaryScope, refs *templateRefs, logger *log.Logger) ([]store.Unit, error) {
+	if tagInTemplate.Type == structure.TemplateRefTag {
+		referencedUnits, err := refs.inline(tagInTemplate, scope, logger)
 		if err != nil {
-			logger.Printf("unable to create unit within template, reason: %s, current template loaded: %v", err, template)
-			return store.Template{}, err
+			return nil, err
 		}
+		return append(units, referencedUnits...), nil
+	}
 
-		template.TemplateUnits[unitNumber] = templateUnit
+	templateUnit, err := createTemplateUnit(tagInTemplate, scope, refs, logger)
+	if err != nil {
+		return nil, err
 	}
+	return append(units, templateUnit), nil
+}
 
-	return template, nil
+// loadReset attribute of the template, which is Y or yes for a template that resets every dictionary before its messages are decoded, and N, no or not set otherwise
+func loadReset(templateRoot *tokenxml.Tag) (bool, error) {
+	switch reset := templateRoot.Attributes[structure.ResetAttribute]; reset {
+	case "Y", "yes":
+		return true, nil
+	case "", "N", "no":
+		return false, nil
+	default:
+		return false, fmt.Errorf("unsupported reset attribute, must be Y or N but found: %s", reset)
+	}
 }
 
-func createTemplateUnit(tagInTemplate *tokenxml.Tag, logger *log.Logger) (store.Unit, error) {
+func createTemplateUnit(tagInTemplate *tokenxml.Tag, scope dictionaryScope, refs *templateRefs, logger *log.Logger) (store.Unit, error) {
 	fieldDetails, err := loadproperties.Load(tagInTemplate, logger)
 	if err != nil {
 		return nil, fmt.Errorf("[%s][%s] failed to create properties of template unit, reason: %s", tagInTemplate.Type, tagInTemplate.Attributes["id"], err)
 	}
+	scope.apply(tagInTemplate, &fieldDetails)
 
 	switch tagInTemplate.Type {
 	case structure.StringTag:
@@ -114,55 +265,74 @@ func createTemplateUnit(tagInTemplate *tokenxml.Tag, logger *log.Logger) (store.
 	case structure.Int64Tag:
 		return loadint64.Load(tagInTemplate, fieldDetails)
 	case structure.DecimalTag:
-		return loaddecimal.Load(tagInTemplate, fieldDetails)
+		decimal, err := loaddecimal.Load(tagInTemplate, fieldDetails)
+		if err == nil && len(tagInTemplate.NestedTags) == 2 {
+			// an <exponent/> or <mantissa/> can name its own dictionary, otherwise it is in the dictionary of the decimal
+			decimalScope := scope.within(tagInTemplate)
+			decimalScope.apply(&tagInTemplate.NestedTags[0], &decimal.ExponentField.FieldDetails)
+			decimalScope.apply(&tagInTemplate.NestedTags[1], &decimal.MantissaField.FieldDetails)
+		}
+		return decimal, err
 	case structure.ByteVectorTag:
 		return loadbytevector.Load(tagInTemplate, fieldDetails)
 	case structure.SequenceTag:
-		return loadSequence(tagInTemplate, fieldDetails, logger)
+		return loadSequence(tagInTemplate, fieldDetails, scope.within(tagInTemplate), refs, logger)
 	default:
 		return nil, fmt.Errorf("unsupported tag type: %s", tagInTemplate.Type)
 	}
 }
 
-func loadSequence(tagInTemplate *tokenxml.Tag, fieldDetails properties.Properties, logger *log.Logger) (fieldsequence.FieldSequence, error) {
+// loadSequence within the scope of the sequence, which is the scope of its length and every unit within it
+func loadSequence(tagInTemplate *tokenxml.Tag, fieldDetails properties.Properties, scope dictionaryScope, refs *templateRefs, logger *log.Logger) (fieldsequence.FieldSequence, error) {
 	fields := make([]store.Unit, 0)
 	for _, tagInTemplate := range tagInTemplate.NestedTags {
-		if tagInTemplate.Type == structure.LengthTag {
+		if tagInTemplate.Type == structure.LengthTag || tagInTemplate.Type == structure.TypeRefTag {
 			continue
 		}
-		templateUnit, err := createTemplateUnit(&tagInTemplate, logger)
-		if err != nil {
+
+		var err error
+		if fields, err = appendTemplateUnit(fields, &tagInTemplate, scope, refs, logger); err != nil {
 			logger.Printf("[%s][%s] could not create template unit within xml sequence, reason: %s", tagInTemplate.Type, tagInTemplate.Attributes["id"], err)
 			return fieldsequence.FieldSequence{}, err
 		}
+	}
 
-		fields = append(fields, templateUnit)
+	// the <length/> of a sequence comes first, after any <typeRef/> naming the application type of the sequence
+	var lengthTag *tokenxml.Tag
+	for index := range tagInTemplate.NestedTags {
+		if tagInTemplate.NestedTags[index].Type == structure.LengthTag {
+			lengthTag = &tagInTemplate.NestedTags[index]
+			break
+		}
 	}
 
-	if tagInTemplate.NestedTags[0].Type == structure.LengthTag {
-		lengthProperties, err := loadproperties.Load(&tagInTemplate.NestedTags[0], logger)
+	if lengthTag != nil {
+		lengthProperties, err := loadproperties.Load(lengthTag, logger)
 		if err != nil {
 			logger.Printf("[%s][%s] unable to load length tag properties for xml sequence: %v", tagInTemplate.Type, tagInTemplate.Attributes["id"], err)
 			return fieldsequence.FieldSequence{}, err
 		}
+		scope.apply(lengthTag, &lengthProperties)
 
 		// if sequence tag does not have id, use id of length field
 		if fieldDetails.ID == 0 {
 			fieldDetails.ID = lengthProperties.ID
 		}
 
-		length, err := loaduint32.Load(&tagInTemplate.NestedTags[0], lengthProperties)
+		length, err := loaduint32.Load(lengthTag, lengthProperties)
 		if err != nil {
 			logger.Printf("[%s][%s] unable to load length tag for xml sequence: %v", tagInTemplate.Type, tagInTemplate.Attributes["id"], err)
 			return fieldsequence.FieldSequence{}, err
 		}
 		if structure.IsNullString(length.FieldDetails.Name) {
-			length.FieldDetails.Name = fieldDetails.Name
+			length.FieldDetails.Rename(fieldDetails.Name)
 		}
 		length.FieldDetails.Required = fieldDetails.Required
 		return fieldsequence.New(fieldDetails, length, fields), nil
 	} else {
-		length := fielduint32.New(properties.New(0, fieldDetails.Name, fieldDetails.Required, logger))
+		lengthProperties := properties.New(0, fieldDetails.Name, fieldDetails.Required, logger)
+		lengthProperties.Key = fieldDetails.Key
+		length := fielduint32.New(lengthProperties)
 		return fieldsequence.New(fieldDetails, length, fields), nil
 	}
 }
@@ -5,8 +5,10 @@ import (
 	"log"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
 	"github.com/Guardian-Development/fastengine/pkg/fast/field/fieldasciistring"
 	"github.com/Guardian-Development/fastengine/pkg/fast/field/fieldbytevector"
 	"github.com/Guardian-Development/fastengine/pkg/fast/field/fielddecimal"
@@ -67,6 +69,7 @@ func TestCanLoadAllSupportedTypesFromTemplateFile(t *testing.T) {
 		t.Errorf("Got an error loading the template when none was expected: %s", err)
 	}
 
+	expectedStore.Slots = store.SlotsOf(expectedStore.Templates)
 	areEqual := reflect.DeepEqual(expectedStore, loadedStore)
 	if !areEqual {
 		t.Errorf("The returned store and expected store were not equal:\nexpected:\t%#v\nactual:\t\t%#v", expectedStore, loadedStore)
@@ -113,6 +116,7 @@ func TestCanLoadAllSupportedOptionalTypesFromTemplateFile(t *testing.T) {
 		t.Errorf("Got an error loading the template when none was expected: %s", err)
 	}
 
+	expectedStore.Slots = store.SlotsOf(expectedStore.Templates)
 	areEqual := reflect.DeepEqual(expectedStore, loadedStore)
 	if !areEqual {
 		t.Errorf("The returned store and expected store were not equal:\nexpected:\t%v\nactual:\t\t%v", expectedStore, loadedStore)
@@ -157,6 +161,7 @@ func TestCanLoadConstantOperationOnAllSupportedTypesFromTemplateFile(t *testing.
 		t.Errorf("Got an error loading the template when none was expected: %s", err)
 	}
 
+	expectedStore.Slots = store.SlotsOf(expectedStore.Templates)
 	areEqual := reflect.DeepEqual(expectedStore, loadedStore)
 	if !areEqual {
 		t.Errorf("The returned store and expected store were not equal:\nexpected:\t%v\nactual:\t\t%v", expectedStore, loadedStore)
@@ -201,6 +206,7 @@ func TestCanLoadDefaultOperationOnAllSupportedTypesFromTemplateFile(t *testing.T
 		t.Errorf("Got an error loading the template when none was expected: %s", err)
 	}
 
+	expectedStore.Slots = store.SlotsOf(expectedStore.Templates)
 	areEqual := reflect.DeepEqual(expectedStore, loadedStore)
 	if !areEqual {
 		t.Errorf("The returned store and expected store were not equal:\nexpected:\t%v\nactual:\t\t%v", expectedStore, loadedStore)
@@ -245,6 +251,7 @@ func TestCanLoadCopyOperationOnAllSupportedTypesFromTemplateFile(t *testing.T) {
 		t.Errorf("Got an error loading the template when none was expected: %s", err)
 	}
 
+	expectedStore.Slots = store.SlotsOf(expectedStore.Templates)
 	areEqual := reflect.DeepEqual(expectedStore, loadedStore)
 	if !areEqual {
 		t.Errorf("The returned store and expected store were not equal:\nexpected:\t%v\nactual:\t\t%v", expectedStore, loadedStore)
@@ -280,6 +287,7 @@ func TestCanLoadIncrementOperationOnAllSupportedTypesFromTemplateFile(t *testing
 		t.Errorf("Got an error loading the template when none was expected: %s", err)
 	}
 
+	expectedStore.Slots = store.SlotsOf(expectedStore.Templates)
 	areEqual := reflect.DeepEqual(expectedStore, loadedStore)
 	if !areEqual {
 		t.Errorf("The returned store and expected store were not equal:\nexpected:\t%v\nactual:\t\t%v", expectedStore, loadedStore)
@@ -309,6 +317,7 @@ func TestCanLoadTailOperationOnAllSupportedTypesFromTemplateFile(t *testing.T) {
 		t.Errorf("Got an error loading the template when none was expected: %s", err)
 	}
 
+	expectedStore.Slots = store.SlotsOf(expectedStore.Templates)
 	areEqual := reflect.DeepEqual(expectedStore, loadedStore)
 	if !areEqual {
 		t.Errorf("The returned store and expected store were not equal:\nexpected:\t%v\nactual:\t\t%v", expectedStore, loadedStore)
@@ -353,8 +362,190 @@ func TestCanLoadDeltaOperationOnAllSupportedTypesFromTemplateFile(t *testing.T)
 		t.Errorf("Got an error loading the template when none was expected: %s", err)
 	}
 
+	expectedStore.Slots = store.SlotsOf(expectedStore.Templates)
 	areEqual := reflect.DeepEqual(expectedStore, loadedStore)
 	if !areEqual {
 		t.Errorf("The returned store and expected store were not equal:\nexpected:\t%v\nactual:\t\t%v", expectedStore, loadedStore)
 	}
 }
+
+func TestLayoutOfLoadedTemplateNamesUnitReadingEachPmapBit(t *testing.T) {
+	// Arrange
+	file, _ := os.Open("../../../../test/template-loader-tests/test_load_copy_operation_on_all_supported_types.xml")
+	loadedStore, _ := Load(file, testLog)
+	expectedUnits := []string{"String", "unsigned int32", "signed int32", "unsigned int64", "signed int64", "decimalExponent", "decimalMantissa",
+		"decimal with exp/manExponent", "decimal with exp/manMantissa", "StringUnicode", "byteVector", "sequence"}
+
+	// Act
+	layout := store.LayoutOf(loadedStore.Templates[144].TemplateUnits)
+
+	// Assert
+	if !reflect.DeepEqual(layout.Units, expectedUnits) {
+		t.Errorf("The layout of the template was not the layout expected:\nexpected:\t%v\nactual:\t\t%v", expectedUnits, layout.Units)
+	}
+	sequence := loadedStore.Templates[144].TemplateUnits[9].(fieldsequence.FieldSequence)
+	if sequence.GroupLayout.Bits() != 0 {
+		t.Errorf("Expected the repeating groups of the sequence to read no pmap bits, layout: %v", sequence.GroupLayout.Units)
+	}
+}
+
+func TestLoadedUnitsKeepPreviousValuesInTheDictionaryTheyAreScopedTo(t *testing.T) {
+	// Arrange
+	file, _ := os.Open("../../../../test/template-loader-tests/test_load_dictionary_scopes.xml")
+	loadedStore, err := Load(file, testLog)
+	if err != nil {
+		t.Fatalf("Got an error loading templates when none was expected: %s", err)
+	}
+	first, second := loadedStore.Templates[1].TemplateUnits, loadedStore.Templates[2].TemplateUnits
+	entries := first[4].(fieldsequence.FieldSequence)
+	price := entries.SequenceFields[1].(fielddecimal.FieldDecimal)
+
+	// Act
+	keys := []struct {
+		unit     string
+		details  properties.Properties
+		expected dictionary.Key
+	}{
+		{"first price", first[0].(fielduint32.FieldUInt32).FieldDetails, dictionary.Key{Dictionary: "template", Scope: "FirstQuote", Name: "Price"}},
+		{"second price", second[0].(fielduint32.FieldUInt32).FieldDetails, dictionary.Key{Dictionary: "template", Scope: "SecondQuote", Name: "Price"}},
+		{"first shared", first[1].(fielduint32.FieldUInt32).FieldDetails, dictionary.Key{Dictionary: "global", Name: "Shared"}},
+		{"first typed", first[2].(fielduint32.FieldUInt32).FieldDetails, dictionary.Key{Dictionary: "type", Scope: "Quote", Name: "Typed"}},
+		{"second typed", second[2].(fielduint32.FieldUInt32).FieldDetails, dictionary.Key{Dictionary: "type", Scope: "Quote", Name: "Typed"}},
+		{"named", first[3].(fielduint32.FieldUInt32).FieldDetails, dictionary.Key{Dictionary: "prices", Name: "Named"}},
+		{"sequence length", entries.LengthField.FieldDetails, dictionary.Key{Dictionary: "type", Scope: "Entry", Name: "NoEntries"}},
+		{"sequence field", entries.SequenceFields[0].(fielduint32.FieldUInt32).FieldDetails, dictionary.Key{Dictionary: "type", Scope: "Entry", Name: "Size"}},
+		{"exponent", price.ExponentField.FieldDetails, dictionary.Key{Dictionary: "global", Name: "PxExponent"}},
+		{"mantissa", price.MantissaField.FieldDetails, dictionary.Key{Dictionary: "type", Scope: "Entry", Name: "PxMantissa"}},
+	}
+
+	// Assert
+	for _, key := range keys {
+		if key.details.Key != key.expected {
+			t.Errorf("The %s was not in the dictionary expected, expected: %#v, result: %#v", key.unit, key.expected, key.details.Key)
+		}
+		if _, ok := loadedStore.Slots.SlotOf(key.expected); !ok {
+			t.Errorf("The %s was not resolved to a slot of the store, key: %#v", key.unit, key.expected)
+		}
+	}
+	firstSlot, _ := loadedStore.Slots.SlotOf(first[0].(fielduint32.FieldUInt32).FieldDetails.Key)
+	secondSlot, _ := loadedStore.Slots.SlotOf(second[0].(fielduint32.FieldUInt32).FieldDetails.Key)
+	if firstSlot == secondSlot {
+		t.Errorf("Expected fields with the same name in different template dictionaries to have different slots")
+	}
+}
+
+func TestLoadedUnitsKeepPreviousValuesUnderTheirKey(t *testing.T) {
+	// Arrange
+	file, _ := os.Open("../../../../test/template-loader-tests/test_load_dictionary_keys.xml")
+	loadedStore, err := Load(file, testLog)
+	if err != nil {
+		t.Fatalf("Got an error loading templates when none was expected: %s", err)
+	}
+	units := loadedStore.Templates[1].TemplateUnits
+	price, last := units[3].(fielddecimal.FieldDecimal), units[4].(fielddecimal.FieldDecimal)
+
+	// Act
+	keys := []struct {
+		unit     string
+		details  properties.Properties
+		expected dictionary.Key
+	}{
+		{"bid size", units[0].(fielduint32.FieldUInt32).FieldDetails, dictionary.Key{Dictionary: "global", Namespace: "urn:quotes", Name: "Size"}},
+		{"ask size", units[1].(fielduint32.FieldUInt32).FieldDetails, dictionary.Key{Dictionary: "global", Namespace: "urn:quotes", Name: "Size"}},
+		{"other size", units[2].(fielduint32.FieldUInt32).FieldDetails, dictionary.Key{Dictionary: "global", Namespace: "urn:other", Name: "Size"}},
+		{"price exponent", price.ExponentField.FieldDetails, dictionary.Key{Dictionary: "global", Namespace: "urn:quotes", Name: "PriceExponent"}},
+		{"price mantissa", price.MantissaField.FieldDetails, dictionary.Key{Dictionary: "global", Namespace: "urn:quotes", Name: "PriceMantissa"}},
+		{"last exponent", last.ExponentField.FieldDetails, dictionary.Key{Dictionary: "global", Namespace: "urn:quotes", Name: "LastExp"}},
+		{"last mantissa", last.MantissaField.FieldDetails, dictionary.Key{Dictionary: "global", Namespace: "urn:quotes", Name: "LastMantissa"}},
+		{"plain", units[5].(fielduint32.FieldUInt32).FieldDetails, dictionary.Key{Dictionary: "global", Namespace: "urn:quotes", Name: "Plain"}},
+	}
+
+	// Assert
+	for _, key := range keys {
+		if key.details.Key != key.expected {
+			t.Errorf("The %s was not keyed as expected, expected: %#v, result: %#v", key.unit, key.expected, key.details.Key)
+		}
+		if _, ok := loadedStore.Slots.SlotOf(key.expected); !ok {
+			t.Errorf("The %s was not resolved to a slot of the store, key: %#v", key.unit, key.expected)
+		}
+	}
+	if units[0].(fielduint32.FieldUInt32).FieldDetails.Name != "BidSize" {
+		t.Errorf("Expected a keyed field to keep its name, result: %s", units[0].(fielduint32.FieldUInt32).FieldDetails.Name)
+	}
+}
+
+func TestLoadedTemplatesResetWhenMarkedReset(t *testing.T) {
+	// Arrange
+	file, _ := os.Open("../../../../test/test_reset_attribute_template.xml")
+
+	// Act
+	loadedStore, err := Load(file, testLog)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Got an error loading templates when none was expected: %s", err)
+	}
+	if loadedStore.Templates[144].Reset {
+		t.Errorf("Expected the template without a reset attribute not to reset")
+	}
+	if !loadedStore.Templates[145].Reset {
+		t.Errorf("Expected the template marked reset=\"Y\" to reset")
+	}
+}
+
+func TestLoadReturnsErrorForUnsupportedResetAttribute(t *testing.T) {
+	// Arrange
+	file, _ := os.Open("../../../../test/test_invalid_reset_template.xml")
+
+	// Act
+	_, err := Load(file, testLog)
+
+	// Assert
+	if err == nil {
+		t.Errorf("Expected an error loading a template with a reset attribute that is neither Y or N")
+	}
+}
+
+func TestLoadedTemplateRefsAreInlinedWithinTheReferringTemplate(t *testing.T) {
+	// Arrange
+	file, _ := os.Open("../../../../test/template-loader-tests/test_load_template_refs.xml")
+
+	// Act
+	loadedStore, err := Load(file, testLog)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Got an error loading templates when none was expected: %s", err)
+	}
+	quote, header := loadedStore.Templates[1].TemplateUnits, loadedStore.Templates[2].TemplateUnits
+	if len(quote) != 3 || quote[0].GetTagId() != 35 || quote[1].GetTagId() != 34 || quote[2].GetTagId() != 268 {
+		t.Fatalf("Expected the header and session templates to be inlined before the sequence, result: %#v", quote)
+	}
+	entries := quote[2].(fieldsequence.FieldSequence).SequenceFields
+	if len(entries) != 2 || entries[0].GetTagId() != 271 || entries[1].GetTagId() != 270 {
+		t.Errorf("Expected the entry template to be inlined within the sequence, result: %#v", entries)
+	}
+	if bits := store.LayoutOf(quote).Bits(); bits != 1 {
+		t.Errorf("Expected the inlined copy to read a bit of the presence map of the referring template, bits: %d", bits)
+	}
+	expected := dictionary.Key{Dictionary: "template", Scope: "Quote", Name: "MsgSeqNum"}
+	if key := quote[1].(fielduint32.FieldUInt32).FieldDetails.Key; key != expected {
+		t.Errorf("Expected the inlined unit to be in the dictionary of the referring template, expected: %#v, result: %#v", expected, key)
+	}
+	if key := header[1].(fielduint32.FieldUInt32).FieldDetails.Key; key != dictionary.GlobalKey("MsgSeqNum") {
+		t.Errorf("Expected the unit of the referenced template to keep its own dictionary, result: %#v", key)
+	}
+}
+
+func TestLoadReturnsErrorForCyclicTemplateRefs(t *testing.T) {
+	// Arrange
+	file, _ := os.Open("../../../../test/test_cyclic_template_ref_template.xml")
+
+	// Act
+	_, err := Load(file, testLog)
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "Quote -> Header -> Quote") {
+		t.Errorf("Expected an error naming the cycle of template references, error: %v", err)
+	}
+}
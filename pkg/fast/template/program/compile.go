@@ -0,0 +1,251 @@
+package program
+
+import (
+	"fmt"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
+	"github.com/Guardian-Development/fastengine/pkg/fast/errors"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/fieldasciistring"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/fieldbytevector"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/fielddecimal"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/fieldint32"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/fieldint64"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/fieldsequence"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/fielduint32"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/fielduint64"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/fieldunicodestring"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/properties"
+	"github.com/Guardian-Development/fastengine/pkg/fast/operation"
+	"github.com/Guardian-Development/fastengine/pkg/fast/template/store"
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+// Compile every template within the store into a Program, by template ID, reading and setting the dictionary entries of the slots of the store
+func Compile(templateStore store.Store) map[uint32]Program {
+	programs := make(map[uint32]Program, len(templateStore.Templates))
+	for templateID, template := range templateStore.Templates {
+		programs[templateID] = New(template, templateStore.Slots)
+	}
+
+	return programs
+}
+
+// New Program lowered from the template, whose dictionary keys are resolved to the slots given. Units the compiler does not know, such as units implemented
+// outside of this module, or units with a key that is not within the slots, are kept as a single instruction deserialising them through the store.Unit
+// interface, so every template can be compiled.
+func New(template store.Template, slots *dictionary.Slots) Program {
+	compiler := compiler{slots: slots}
+	for _, unit := range template.TemplateUnits {
+		compiler.unit = unit.GetTagId()
+		compiler.lower(unit)
+	}
+
+	return Program{instructions: compiler.instructions, layout: store.LayoutOf(template.TemplateUnits), keys: store.KeysOf(template.TemplateUnits), logger: template.Logger}
+}
+
+// compiler lowers units into instructions, appended in the order they are run
+type compiler struct {
+	instructions []instruction
+	slots        *dictionary.Slots
+	unit         uint64
+	// unsupported is set when a value of the unit being lowered cannot be held by the instructions, so the unit is deserialised by opUnit instead
+	unsupported bool
+}
+
+// scalar field of a type, with the opcodes reading its value and delta
+type scalar struct {
+	details                      properties.Properties
+	operation                    operation.Operation
+	read, readOptional           opcode
+	readDelta, readOptionalDelta opcode
+}
+
+func scalarOf(unit store.Unit) (scalar, bool) {
+	switch field := unit.(type) {
+	case fielduint32.FieldUInt32:
+		return scalar{field.FieldDetails, field.Operation, opReadUInt32, opReadOptionalUInt32, opReadInt64Delta, opReadOptionalInt64Delta}, true
+	case fieldint32.FieldInt32:
+		return scalar{field.FieldDetails, field.Operation, opReadInt32, opReadOptionalInt32, opReadInt64Delta, opReadOptionalInt64Delta}, true
+	case fielduint64.FieldUInt64:
+		return scalar{field.FieldDetails, field.Operation, opReadUInt64, opReadOptionalUInt64, opReadBigIntDelta, opReadOptionalBigIntDelta}, true
+	case fieldint64.FieldInt64:
+		return scalar{field.FieldDetails, field.Operation, opReadInt64, opReadOptionalInt64, opReadBigIntDelta, opReadOptionalBigIntDelta}, true
+	case fieldasciistring.FieldAsciiString:
+		return scalar{field.FieldDetails, field.Operation, opReadASCIIString, opReadOptionalASCIIString, opReadASCIIStringDelta, opReadOptionalASCIIStringDelta}, true
+	case fieldunicodestring.FieldUnicodeString:
+		return scalar{field.FieldDetails, field.Operation, opReadUnicodeString, opReadOptionalUnicodeString, opReadUnicodeStringDelta, opReadOptionalUnicodeStringDelta}, true
+	case fieldbytevector.FieldByteVector:
+		return scalar{field.FieldDetails, field.Operation, opReadByteVector, opReadOptionalByteVector, opReadByteVectorDelta, opReadOptionalByteVectorDelta}, true
+	}
+
+	return scalar{}, false
+}
+
+// lower the unit into instructions that store its value under its tag, falling back to deserialising the unit itself if it cannot be lowered
+func (compiler *compiler) lower(unit store.Unit) {
+	start := len(compiler.instructions)
+	enclosingUnsupported := compiler.unsupported
+	compiler.unsupported = false
+	if !compiler.lowerValue(unit) || compiler.unsupported {
+		compiler.instructions = compiler.instructions[:start]
+		compiler.emit(instruction{op: opUnit, name: unit.GetName(), tag: unit.GetTagId(), fallback: unit})
+	}
+	compiler.unsupported = enclosingUnsupported
+}
+
+// lowerValue of the unit, returning false if any part of the unit could not be lowered
+func (compiler *compiler) lowerValue(unit store.Unit) bool {
+	switch field := unit.(type) {
+	case fielddecimal.FieldDecimal:
+		return compiler.lowerDecimal(field)
+	case fieldsequence.FieldSequence:
+		return compiler.lowerSequence(field)
+	}
+
+	field, ok := scalarOf(unit)
+	if !ok || !compiler.lowerScalar(field) {
+		return false
+	}
+	compiler.emit(instruction{op: opSetTag, name: field.details.Name, tag: field.details.ID})
+	return true
+}
+
+// lowerScalar into instructions leaving its value in the register, and storing it as the previous value of its dictionary key
+func (compiler *compiler) lowerScalar(field scalar) bool {
+	details := field.details
+	read := field.readOptional
+	if details.Required {
+		read = field.read
+	}
+	missing := fmt.Sprintf("%s", errors.D5)
+
+	switch op := field.operation.(type) {
+	case operation.None:
+		compiler.emit(instruction{op: read, name: details.Name})
+	case operation.Constant:
+		if details.Required {
+			compiler.emit(instruction{op: opLoad, name: details.Name, value: compiler.typed(op.ConstantValue)})
+			break
+		}
+		compiler.ifPmapSet(details.Name, func() {
+			compiler.emit(instruction{op: opLoad, name: details.Name, value: compiler.typed(op.ConstantValue)})
+		}, func() {
+			compiler.emit(instruction{op: opLoad, name: details.Name})
+		})
+	case operation.Default:
+		compiler.ifPmapSet(details.Name, func() {
+			compiler.emit(instruction{op: read, name: details.Name})
+		}, func() {
+			compiler.emit(instruction{op: opLoad, name: details.Name, value: compiler.typed(op.DefaultValue)})
+		})
+	case operation.Copy:
+		compiler.ifPmapSet(details.Name, func() {
+			compiler.emit(instruction{op: read, name: details.Name})
+		}, func() {
+			compiler.emit(instruction{op: opCopyFromSlot, name: details.Name, slot: compiler.slotOf(details.Key), required: details.Required, missing: missing, value: compiler.typed(op.InitialValue)})
+		})
+	case operation.Increment:
+		compiler.ifPmapSet(details.Name, func() {
+			compiler.emit(instruction{op: read, name: details.Name})
+		}, func() {
+			compiler.emit(instruction{op: opIncrementFromSlot, name: details.Name, slot: compiler.slotOf(details.Key), required: details.Required, missing: missing, value: compiler.typed(op.InitialValue)})
+		})
+	case operation.Tail:
+		compiler.ifPmapSet(details.Name, func() {
+			compiler.emit(instruction{op: read, name: details.Name})
+			compiler.emit(instruction{op: opApplyTail, name: details.Name, slot: compiler.slotOf(details.Key), value: compiler.typed(op.InitialValue), base: compiler.typed(op.BaseValue)})
+		}, func() {
+			compiler.emit(instruction{op: opCopyFromSlot, name: details.Name, slot: compiler.slotOf(details.Key), required: details.Required,
+				missing: "no value supplied in message and no initial value with required field", value: compiler.typed(op.InitialValue)})
+		})
+	case operation.Delta:
+		readDelta := field.readOptionalDelta
+		if details.Required {
+			readDelta = field.readDelta
+		}
+		compiler.emit(instruction{op: readDelta, name: details.Name})
+		compiler.emit(instruction{op: opApplyDelta, name: details.Name, slot: compiler.slotOf(details.Key), value: compiler.typed(op.InitialValue), base: compiler.typed(op.BaseValue)})
+	default:
+		return false
+	}
+
+	compiler.emit(instruction{op: opSetSlot, name: details.Name, slot: compiler.slotOf(details.Key)})
+	return true
+}
+
+// ifPmapSet emits the instructions of encoded when the next presence map bit is set, else the instructions of notEncoded
+func (compiler *compiler) ifPmapSet(name string, encoded func(), notEncoded func()) {
+	ifNotSet := compiler.emit(instruction{op: opIfPmapNotSet, name: name})
+	encoded()
+	jumpToEnd := compiler.emit(instruction{op: opJump, name: name})
+	compiler.instructions[ifNotSet].jump = len(compiler.instructions)
+	notEncoded()
+	compiler.instructions[jumpToEnd].jump = len(compiler.instructions)
+}
+
+// lowerDecimal into its exponent, then its mantissa if the exponent is not null, combining the two into the value of the decimal
+func (compiler *compiler) lowerDecimal(field fielddecimal.FieldDecimal) bool {
+	exponent, _ := scalarOf(field.ExponentField)
+	if !compiler.lowerScalar(exponent) {
+		return false
+	}
+	beginDecimal := compiler.emit(instruction{op: opBeginDecimal, name: field.FieldDetails.Name, tag: field.FieldDetails.ID})
+
+	mantissa, _ := scalarOf(field.MantissaField)
+	if !compiler.lowerScalar(mantissa) {
+		return false
+	}
+	compiler.emit(instruction{op: opEndDecimal, name: field.FieldDetails.Name, tag: field.FieldDetails.ID})
+	compiler.emit(instruction{op: opSetSlot, name: field.FieldDetails.Name, slot: compiler.slotOf(field.FieldDetails.Key)})
+	compiler.emit(instruction{op: opSetTag, name: field.FieldDetails.Name, tag: field.FieldDetails.ID})
+
+	compiler.instructions[beginDecimal].jump = len(compiler.instructions)
+	return true
+}
+
+// lowerSequence into its length, then the instructions of a repeating group that are run once for each group within the sequence
+func (compiler *compiler) lowerSequence(field fieldsequence.FieldSequence) bool {
+	length, _ := scalarOf(field.LengthField)
+	if !compiler.lowerScalar(length) {
+		return false
+	}
+
+	groupRequiresPmap := false
+	for _, unit := range field.SequenceFields {
+		groupRequiresPmap = groupRequiresPmap || unit.RequiresPmap()
+	}
+
+	beginSequence := compiler.emit(instruction{op: opBeginSequence, name: field.FieldDetails.Name, tag: field.FieldDetails.ID})
+	startOfGroup := len(compiler.instructions)
+	if groupRequiresPmap {
+		compiler.emit(instruction{op: opReadPmap, name: field.FieldDetails.Name, bits: field.GroupLayout.Bits()})
+	}
+	for _, unit := range field.SequenceFields {
+		compiler.lower(unit)
+	}
+	compiler.emit(instruction{op: opEndGroup, name: field.FieldDetails.Name, tag: field.FieldDetails.ID, jump: startOfGroup})
+
+	compiler.instructions[beginSequence].jump = len(compiler.instructions)
+	return true
+}
+
+// typed value of the constant, default, initial or base value of an operation
+func (compiler *compiler) typed(fixValue fix.Value) fix.TypedValue {
+	typedValue, ok := typedOf(fixValue)
+	compiler.unsupported = compiler.unsupported || !ok
+	return typedValue
+}
+
+// slotOf the dictionary key, a key that is not within the slots can not be held by the instructions
+func (compiler *compiler) slotOf(key dictionary.Key) dictionary.Slot {
+	slot, exists := compiler.slots.SlotOf(key)
+	compiler.unsupported = compiler.unsupported || !exists
+	return slot
+}
+
+// emit the instruction, returning its index
+func (compiler *compiler) emit(instruction instruction) int {
+	instruction.unit = compiler.unit
+	compiler.instructions = append(compiler.instructions, instruction)
+	return len(compiler.instructions) - 1
+}
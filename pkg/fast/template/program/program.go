@@ -0,0 +1,217 @@
+package program
+
+import (
+	"fmt"
+	"hash/fnv"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
+	"github.com/Guardian-Development/fastengine/pkg/fast/presencemap"
+	"github.com/Guardian-Development/fastengine/pkg/fast/template/store"
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+// Program is a store.Template lowered into a flat slice of instructions, run by a single interpreter loop rather than walking the tree of store.Unit,
+// operation.Operation and decoder.Decoder interfaces each field is made of. Running a program decodes exactly the same fix.Message as the template it was
+// compiled from, reading and writing the dictionary of previous values in the same order.
+type Program struct {
+	instructions []instruction
+	layout       presencemap.Layout
+	keys         []dictionary.Key
+	logger       *log.Logger
+}
+
+// Layout of the presence map read by the template the program was compiled from, this does not include the bit of the template id read by the header
+func (program Program) Layout() presencemap.Layout {
+	return program.layout
+}
+
+// Keys of the dictionary entries read or set by the program, each key once in the order it is first used, including the keys of units kept as a single
+// opUnit instruction
+func (program Program) Keys() []dictionary.Key {
+	return program.keys
+}
+
+// Fingerprint of the programs compiled from a template store, which is the same for the same templates compiled in any process, and differs when an
+// instruction of any template, or the dictionary key of any of its fields, differs
+func Fingerprint(programs map[uint32]Program) uint64 {
+	templateIDs := make([]uint32, 0, len(programs))
+	for templateID := range programs {
+		templateIDs = append(templateIDs, templateID)
+	}
+	sort.Slice(templateIDs, func(i, j int) bool { return templateIDs[i] < templateIDs[j] })
+
+	hash := fnv.New64a()
+	for _, templateID := range templateIDs {
+		fmt.Fprintf(hash, "template %d\n%s", templateID, programs[templateID])
+		for _, key := range programs[templateID].Keys() {
+			fmt.Fprintf(hash, "key %q %q %q %q\n", key.Dictionary, key.Scope, key.Namespace, key.Name)
+		}
+	}
+
+	return hash.Sum64()
+}
+
+// opcode of an instruction, each instruction acts on the register of the value being decoded, the delta read for it, or the sequence being decoded
+type opcode uint8
+
+const (
+	// opReadPmap reads the presence map of a repeating group
+	opReadPmap opcode = iota
+	// opIfPmapNotSet jumps if the next bit of the presence map is not set
+	opIfPmapNotSet
+	// opJump always jumps
+	opJump
+
+	// opRead<Type> and opReadOptional<Type> read a value of the type into the register, optional reads treat the null encoding as null
+	opReadUInt32
+	opReadOptionalUInt32
+	opReadInt32
+	opReadOptionalInt32
+	opReadUInt64
+	opReadOptionalUInt64
+	opReadInt64
+	opReadOptionalInt64
+	opReadASCIIString
+	opReadOptionalASCIIString
+	opReadUnicodeString
+	opReadOptionalUnicodeString
+	opReadByteVector
+	opReadOptionalByteVector
+
+	// opRead<Type>Delta and opReadOptional<Type>Delta read the delta of a value, applied to its base value by opApplyDelta
+	opReadInt64Delta
+	opReadOptionalInt64Delta
+	opReadBigIntDelta
+	opReadOptionalBigIntDelta
+	opReadASCIIStringDelta
+	opReadOptionalASCIIStringDelta
+	opReadUnicodeStringDelta
+	opReadOptionalUnicodeStringDelta
+	opReadByteVectorDelta
+	opReadOptionalByteVectorDelta
+
+	// opLoad the constant, default or null value of the instruction into the register
+	opLoad
+	// opCopyFromSlot loads the previous value of the dictionary key, or the initial value if there is none (<copy/> and <tail/>)
+	opCopyFromSlot
+	// opIncrementFromSlot loads the previous value of the dictionary key incremented by 1, or the initial value if there is none (<increment/>)
+	opIncrementFromSlot
+	// opApplyDelta adds the delta read to the previous value of the dictionary key, or the initial or base value if there is none (<delta/>)
+	opApplyDelta
+	// opApplyTail overwrites the end of the previous value of the dictionary key with the value read, or the initial or base value if there is none (<tail/>)
+	opApplyTail
+
+	// opSetSlot stores the register as the previous value of the dictionary key
+	opSetSlot
+	// opSetTag stores the register as the value of the tag, in the message or repeating group being decoded
+	opSetTag
+
+	// opBeginDecimal checks the exponent in the register, jumping past the mantissa if it is null
+	opBeginDecimal
+	// opEndDecimal combines the exponent with the mantissa in the register
+	opEndDecimal
+
+	// opBeginSequence starts the sequence with the length in the register, jumping past its repeating groups if it is null or empty
+	opBeginSequence
+	// opEndGroup moves onto the next repeating group of the sequence, jumping back to the start of the group, or ends the sequence after the last group
+	opEndGroup
+
+	// opUnit deserialises a unit the compiler has no instructions for through the store.Unit interface
+	opUnit
+)
+
+var opcodeNames = [...]string{
+	opReadPmap:                       "read-pmap",
+	opIfPmapNotSet:                   "if-pmap-not-set",
+	opJump:                           "jump",
+	opReadUInt32:                     "read-uint32",
+	opReadOptionalUInt32:             "read-uint32-optional",
+	opReadInt32:                      "read-int32",
+	opReadOptionalInt32:              "read-int32-optional",
+	opReadUInt64:                     "read-uint64",
+	opReadOptionalUInt64:             "read-uint64-optional",
+	opReadInt64:                      "read-int64",
+	opReadOptionalInt64:              "read-int64-optional",
+	opReadASCIIString:                "read-ascii",
+	opReadOptionalASCIIString:        "read-ascii-optional",
+	opReadUnicodeString:              "read-unicode",
+	opReadOptionalUnicodeString:      "read-unicode-optional",
+	opReadByteVector:                 "read-bytevector",
+	opReadOptionalByteVector:         "read-bytevector-optional",
+	opReadInt64Delta:                 "read-int64-delta",
+	opReadOptionalInt64Delta:         "read-int64-delta-optional",
+	opReadBigIntDelta:                "read-bigint-delta",
+	opReadOptionalBigIntDelta:        "read-bigint-delta-optional",
+	opReadASCIIStringDelta:           "read-ascii-delta",
+	opReadOptionalASCIIStringDelta:   "read-ascii-delta-optional",
+	opReadUnicodeStringDelta:         "read-unicode-delta",
+	opReadOptionalUnicodeStringDelta: "read-unicode-delta-optional",
+	opReadByteVectorDelta:            "read-bytevector-delta",
+	opReadOptionalByteVectorDelta:    "read-bytevector-delta-optional",
+	opLoad:                           "load",
+	opCopyFromSlot:                   "copy-from-slot",
+	opIncrementFromSlot:              "increment-from-slot",
+	opApplyDelta:                     "apply-delta",
+	opApplyTail:                      "apply-tail",
+	opSetSlot:                        "set-slot",
+	opSetTag:                         "set-tag",
+	opBeginDecimal:                   "begin-decimal",
+	opEndDecimal:                     "end-decimal",
+	opBeginSequence:                  "begin-sequence",
+	opEndGroup:                       "end-group",
+	opUnit:                           "unit",
+}
+
+func (op opcode) String() string {
+	return opcodeNames[op]
+}
+
+// instruction of a program, only the operands used by its opcode are set
+type instruction struct {
+	op opcode
+	// name of the field, used to describe errors
+	name string
+	// slot of the dictionary entry read and set by slot instructions
+	slot dictionary.Slot
+	// tag the value is stored under by opSetTag, opBeginDecimal and opBeginSequence
+	tag uint64
+	// jump is the index of the instruction jumped to
+	jump int
+	// bits of the presence map read by the repeating group whose presence map is read by opReadPmap
+	bits int
+	// required fields return an error when there is no value to copy or increment, missing is the error returned
+	required bool
+	missing  string
+	// value loaded by opLoad, or the initial value of copy, increment, delta and tail operators. base is the value deltas and tails are applied to when
+	// there is neither a previous or initial value.
+	value fix.TypedValue
+	base  fix.TypedValue
+	// unit is the tag of the unit within the template this instruction was lowered from, used to describe errors. opUnit deserialises fallback.
+	unit     uint64
+	fallback store.Unit
+}
+
+// String disassembles the program, one instruction per line
+func (program Program) String() string {
+	builder := strings.Builder{}
+	for index, instruction := range program.instructions {
+		fmt.Fprintf(&builder, "%d: %s", index, instruction.op)
+		switch instruction.op {
+		case opIfPmapNotSet, opJump:
+			fmt.Fprintf(&builder, " %d", instruction.jump)
+		case opLoad:
+			fmt.Fprintf(&builder, " %s", &instruction.value)
+		case opCopyFromSlot, opIncrementFromSlot, opApplyDelta, opApplyTail, opSetSlot:
+			fmt.Fprintf(&builder, " %s", instruction.name)
+		case opSetTag, opEndDecimal, opUnit:
+			fmt.Fprintf(&builder, " %d", instruction.tag)
+		case opBeginDecimal, opBeginSequence, opEndGroup:
+			fmt.Fprintf(&builder, " %d %d", instruction.tag, instruction.jump)
+		}
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
@@ -0,0 +1,294 @@
+package program
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
+	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
+	"github.com/Guardian-Development/fastengine/pkg/fast/field/fielddecimal"
+	"github.com/Guardian-Development/fastengine/pkg/fast/header"
+	"github.com/Guardian-Development/fastengine/pkg/fast/presencemap"
+	"github.com/Guardian-Development/fastengine/pkg/fast/template/loader"
+	"github.com/Guardian-Development/fastengine/pkg/fast/template/store"
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+func TestProgramIsFlatInstructions(t *testing.T) {
+	// Arrange
+	templateStore := loadStore(t, "../../../../test/test_optional_value_template.xml")
+	expected := "0: load 9|\n" +
+		"1: set-slot ApplVerID\n" +
+		"2: set-tag 1128\n" +
+		"3: load 0|\n" +
+		"4: set-slot MsgType\n" +
+		"5: set-tag 35\n" +
+		"6: read-uint32-optional\n" +
+		"7: set-slot MsgSeqNum\n" +
+		"8: set-tag 34\n" +
+		"9: read-uint64\n" +
+		"10: set-slot SendingTime\n" +
+		"11: set-tag 52\n"
+
+	// Act
+	program := New(templateStore.Templates[144], templateStore.Slots)
+
+	// Assert
+	if program.String() != expected {
+		t.Errorf("Expected the template to be lowered into flat instructions, expected:\n%s\nactual:\n%s", expected, program.String())
+	}
+}
+
+// fallbackDecimal is a decimal the compiler does not know, so it is kept as a single opUnit instruction
+type fallbackDecimal struct {
+	fielddecimal.FieldDecimal
+}
+
+func TestKeysOfProgramIncludeKeysOfUnitsKeptAsSingleInstruction(t *testing.T) {
+	// Arrange
+	templateStore := loadStore(t, "../../../../test/template-loader-tests/test_load_copy_operation_on_all_supported_types.xml")
+	template := templateStore.Templates[144]
+	decimal := template.TemplateUnits[6].(fielddecimal.FieldDecimal)
+	template.TemplateUnits = []store.Unit{template.TemplateUnits[1], fallbackDecimal{decimal}}
+
+	// Act
+	program := New(template, templateStore.Slots)
+
+	// Assert
+	if program.instructions[len(program.instructions)-1].op != opUnit {
+		t.Fatalf("Expected the decimal to be kept as a single instruction, program:\n%s", program)
+	}
+	expected := append(template.TemplateUnits[0].(store.KeyedUnit).Keys(), decimal.Keys()...)
+	if !reflect.DeepEqual(program.Keys(), expected) {
+		t.Errorf("Expected the keys of the decimal to be keys of the program, expected: %v, result: %v", expected, program.Keys())
+	}
+}
+
+func TestProgramDecodesSameMessagesAsTemplateOnSnapshotMessages(t *testing.T) {
+	assertProgramDecodesSameMessagesAsTemplate(t, "../../../../test/example-decoding-tests/snapshot-messages-hex.txt")
+}
+
+func TestProgramDecodesSameMessagesAsTemplateOnInstrumentMessages(t *testing.T) {
+	assertProgramDecodesSameMessagesAsTemplate(t, "../../../../test/example-decoding-tests/instrument-messages-hex.txt")
+}
+
+func assertProgramDecodesSameMessagesAsTemplate(t *testing.T, messagesFile string) {
+	templateStore := loadStore(t, "../../../../test/example-decoding-tests/templates.xml")
+	programs := Compile(templateStore)
+	logger := log.New(ioutil.Discard, "", 0)
+
+	for index, message := range readHexMessages(t, messagesFile) {
+		templateDictionary := dictionary.New()
+		templateHeader, err := header.New(decoder.NewCursor(message), &templateDictionary, logger)
+		if err != nil {
+			t.Fatalf("unable to read header of message %d: %v", index, err)
+		}
+
+		expected, actual := decodeWithTemplateAndProgram(templateStore.Templates[templateHeader.TemplateID], programs[templateHeader.TemplateID], templateStore.Slots, message)
+		if expected != actual {
+			t.Errorf("message %d: expected the program to decode the same message as the template, expected: %s, actual: %s", index, expected, actual)
+		}
+	}
+}
+
+// TestProgramDecodesSameMessagesAsTemplateOnRandomInput decodes random bytes with every template, keeping the dictionary of previous values between
+// messages, checking the program decodes the same message, or fails, as the template does, both into new messages and into a single reused message, and
+// that both decode the same message reading strings and byte vectors as views with the ZeroCopy setting
+func TestProgramDecodesSameMessagesAsTemplateOnRandomInput(t *testing.T) {
+	// Arrange
+	templateFiles, _ := filepath.Glob("../../../../test/template-loader-tests/*.xml")
+	templateFiles = append(templateFiles, "../../../../test/example-decoding-tests/templates.xml")
+	random := rand.New(rand.NewSource(1))
+
+	for _, templateFile := range templateFiles {
+		templateStore := loadStore(t, templateFile)
+		for templateID, template := range templateStore.Templates {
+			program := New(template, templateStore.Slots)
+			templateDictionary, programDictionary, reusingDictionary := dictionary.New(), dictionary.NewOf(templateStore.Slots), dictionary.NewOf(templateStore.Slots)
+			zeroCopyTemplateDictionary, zeroCopyProgramDictionary := dictionary.New(), dictionary.NewOf(templateStore.Slots)
+			zeroCopy := decoder.Settings{MaxSequenceLength: 64, ZeroCopy: true}
+			machine, reusedMessage, reusedPMap := Machine{}, fix.New(), presencemap.PresenceMap{}
+
+			for attempt := 0; attempt < 2000; attempt++ {
+				message := randomMessage(random)
+
+				// Act
+				expected := decodeWith(func(inputSource decoder.Reader) (fmt.Stringer, error) {
+					pMap, err := presencemap.New(inputSource)
+					if err != nil {
+						return nil, err
+					}
+					return template.Deserialise(inputSource, &pMap, &templateDictionary)
+				}, message)
+				actual := decodeWith(func(inputSource decoder.Reader) (fmt.Stringer, error) {
+					pMap, err := presencemap.New(inputSource)
+					if err != nil {
+						return nil, err
+					}
+					return program.Deserialise(inputSource, &pMap, &programDictionary)
+				}, message)
+				reused := decodeWith(func(inputSource decoder.Reader) (fmt.Stringer, error) {
+					if err := reusedPMap.Load(inputSource); err != nil {
+						return nil, err
+					}
+					return &reusedMessage, program.DeserialiseReusing(inputSource, &reusedPMap, &reusingDictionary, &machine, &reusedMessage)
+				}, message)
+
+				zeroCopyTemplate := decodeWithSettings(func(inputSource decoder.Reader) (fmt.Stringer, error) {
+					pMap, err := presencemap.New(inputSource)
+					if err != nil {
+						return nil, err
+					}
+					return template.Deserialise(inputSource, &pMap, &zeroCopyTemplateDictionary)
+				}, message, zeroCopy)
+				zeroCopyProgram := decodeWithSettings(func(inputSource decoder.Reader) (fmt.Stringer, error) {
+					pMap, err := presencemap.New(inputSource)
+					if err != nil {
+						return nil, err
+					}
+					return program.Deserialise(inputSource, &pMap, &zeroCopyProgramDictionary)
+				}, message, zeroCopy)
+
+				// Assert
+				if expected != zeroCopyTemplate || expected != zeroCopyProgram {
+					t.Fatalf("%s template %d: expected %x to decode the same with zero copy, expected: %s, template: %s, program: %s", templateFile, templateID, message, expected, zeroCopyTemplate, zeroCopyProgram)
+				}
+				if expected != actual {
+					t.Fatalf("%s template %d: expected the program to decode %x the same as the template, expected: %s, actual: %s", templateFile, templateID, message, expected, actual)
+				}
+				if expected != reused {
+					t.Fatalf("%s template %d: expected the program to decode %x into a reused message the same as the template, expected: %s, actual: %s", templateFile, templateID, message, expected, reused)
+				}
+			}
+		}
+	}
+}
+
+func TestZeroCopyOnlyViewsTheFieldThatWasRead(t *testing.T) {
+	// Arrange first message: 11000000 pmap, 10000010 00000001 00000010 Viewed = [1 2], 10000010 00000001 00000010 Copied = [1 2]
+	templateStore := loadStore(t, "../../../../test/test_zero_copy_template.xml")
+	program := New(templateStore.Templates[1], templateStore.Slots)
+	dict := dictionary.NewOf(templateStore.Slots)
+	zeroCopy := decoder.Settings{ZeroCopy: true}
+	decode := func(message []byte) *fix.Message {
+		inputSource := &decoder.SettingsReader{Reader: decoder.NewCursor(message), Settings: zeroCopy}
+		pMap, _ := presencemap.New(inputSource)
+		fixMessage, err := program.Deserialise(inputSource, &pMap, &dict)
+		if err != nil {
+			t.Fatalf("Got an error decoding the message when none was expected: %s", err)
+		}
+		return fixMessage
+	}
+	decode([]byte{192, 130, 1, 2, 130, 1, 2})
+	// second message: 10000000 pmap, 10000010 00000001 00000010 Viewed = [1 2], Copied is copied
+	message := []byte{128, 130, 1, 2}
+
+	// Act
+	fixMessage := decode(message)
+	message[2], message[3] = 3, 4
+
+	// Assert
+	if viewed, _ := fixMessage.GetTag(1); !reflect.DeepEqual(viewed, []byte{3, 4}) {
+		t.Errorf("Expected the field that was read to be a view into the message, result: %v", viewed)
+	}
+	if copied, _ := fixMessage.GetTag(2); !reflect.DeepEqual(copied, []byte{1, 2}) {
+		t.Errorf("Expected the copied field not to be a view into the message, even though it holds the same bytes as the view, result: %v", copied)
+	}
+}
+
+func decodeWithTemplateAndProgram(template store.Template, program Program, slots *dictionary.Slots, message []byte) (string, string) {
+	templateDictionary, programDictionary := dictionary.New(), dictionary.NewOf(slots)
+	expected := decodeWith(func(inputSource decoder.Reader) (fmt.Stringer, error) {
+		messageHeader, err := header.New(inputSource, &templateDictionary, template.Logger)
+		if err != nil {
+			return nil, err
+		}
+		return template.Deserialise(inputSource, messageHeader.PMap, &templateDictionary)
+	}, message)
+	actual := decodeWith(func(inputSource decoder.Reader) (fmt.Stringer, error) {
+		messageHeader, err := header.New(inputSource, &programDictionary, program.logger)
+		if err != nil {
+			return nil, err
+		}
+		return program.Deserialise(inputSource, messageHeader.PMap, &programDictionary)
+	}, message)
+	return expected, actual
+}
+
+// decodeWith the decode function, describing the message decoded along with how many bytes were read, or that decoding failed. Sequences are limited
+// to 64 repeating groups so random lengths do not exhaust memory.
+func decodeWith(decode func(inputSource decoder.Reader) (fmt.Stringer, error), message []byte) string {
+	return decodeWithSettings(decode, message, decoder.Settings{MaxSequenceLength: 64})
+}
+
+// decodeWithSettings describes the message decoded in the same way as decodeWith, reading with the settings given
+func decodeWithSettings(decode func(inputSource decoder.Reader) (fmt.Stringer, error), message []byte, settings decoder.Settings) string {
+	cursor := decoder.NewCursor(message)
+	inputSource := &decoder.SettingsReader{Reader: cursor, Settings: settings}
+
+	decoded, err := decode(inputSource)
+	if err != nil {
+		return "failed to decode"
+	}
+	return fmt.Sprintf("%s (read %d bytes)", decoded, cursor.Offset())
+}
+
+// randomMessage of up to 64 bytes, where no value is more than 3 bytes long so random byte vector lengths do not exhaust memory
+func randomMessage(random *rand.Rand) []byte {
+	message := make([]byte, 1+random.Intn(64))
+	withoutStopBit := 0
+	for index := range message {
+		message[index] = byte(random.Intn(8))
+		if random.Intn(2) == 0 {
+			message[index] = byte(random.Intn(256))
+		}
+		if random.Intn(2) == 0 || withoutStopBit == 2 {
+			message[index] |= 0x80
+		}
+
+		withoutStopBit++
+		if message[index]&0x80 == 0x80 {
+			withoutStopBit = 0
+		}
+	}
+	return message
+}
+
+func loadStore(t *testing.T, templateFile string) store.Store {
+	file, err := os.Open(templateFile)
+	if err != nil {
+		t.Fatalf("unable to open template file: %v", err)
+	}
+	defer file.Close()
+
+	templateStore, err := loader.Load(file, log.New(ioutil.Discard, "", 0))
+	if err != nil {
+		t.Fatalf("unable to load template file: %v", err)
+	}
+	return templateStore
+}
+
+func readHexMessages(t *testing.T, messagesFile string) [][]byte {
+	file, err := os.Open(messagesFile)
+	if err != nil {
+		t.Fatalf("unable to open messages file: %v", err)
+	}
+	defer file.Close()
+
+	messages := [][]byte{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		message, _ := hex.DecodeString(scanner.Text())
+		messages = append(messages, message)
+	}
+
+	return messages
+}
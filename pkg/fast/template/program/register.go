@@ -0,0 +1,24 @@
+package program
+
+import (
+	"math/big"
+
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+// typedOf the fix value, returning false if the value is not null or a raw go type a FAST field is decoded to
+func typedOf(fixValue fix.Value) (fix.TypedValue, bool) {
+	typedValue := fix.TypedValue{}
+	ok := typedValue.SetValue(fixValue)
+	return typedValue, ok
+}
+
+// delta read off the message, held by its type until it is applied to its base value. Integer deltas are held in 64 bits unless they were encoded in
+// 10 or more bytes, and string and byte vector deltas are read into the same buffer for every delta.
+type delta struct {
+	null          bool
+	integer       int64
+	bigInt        *big.Int
+	itemsToRemove int32
+	bytes         []byte
+}
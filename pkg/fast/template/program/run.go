@@ -0,0 +1,700 @@
+package program
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"unicode/utf8"
+
+	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
+	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
+	"github.com/Guardian-Development/fastengine/pkg/fast/errors"
+	"github.com/Guardian-Development/fastengine/pkg/fast/presencemap"
+	"github.com/Guardian-Development/fastengine/pkg/fast/template/store"
+	"github.com/Guardian-Development/fastengine/pkg/fast/value"
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+// Machine holds the state of a running program. A Machine can be reused for every message decoded by DeserialiseReusing or Visit, keeping the buffers
+// of the values, deltas and presence maps it has read, and the zero value is ready to use.
+type Machine struct {
+	register fix.TypedValue
+	delta    delta
+	scratch  []byte
+	exponent int32
+	message  *fix.Message
+	pMap     *presencemap.PresenceMap
+	groups   []group
+	// typed machines set tags to typed values of the message, rather than boxing each value into a new fix.Value
+	typed bool
+	// visitor is passed the register as the value of each tag rather than setting the tag in a message, when the machine is visiting a message
+	visitor store.Visitor
+	// view is the last unicode string or byte vector read as a view into the message with the ZeroCopy setting. While viewed is set the register holds only
+	// the kind of the value, whose bytes are the view, so the view is stored as the value of the next tag rather than a copy of it
+	view   []byte
+	viewed bool
+}
+
+// group is a sequence being decoded, along with the message and presence map to return to once every repeating group has been decoded
+type group struct {
+	sequence fix.SequenceValue
+	length   int
+	index    int
+	pMap     presencemap.PresenceMap
+	message  *fix.Message
+	parent   *presencemap.PresenceMap
+}
+
+// Deserialise a message from the input source by running the program, in the same way as store.Template Deserialise
+func (program Program) Deserialise(inputSource decoder.Reader, pMap *presencemap.PresenceMap, dictionary *dictionary.Dictionary) (*fix.Message, error) {
+	fixMessage := fix.New()
+	machine := Machine{message: &fixMessage, pMap: pMap}
+
+	if err := program.deserialise(&machine, inputSource, dictionary); err != nil {
+		return &fixMessage, err
+	}
+	return &fixMessage, nil
+}
+
+// DeserialiseReusing decodes a message from the input source in the same way as Deserialise, into the message given rather than a new message. The
+// message is Reset and its tags are set to typed values, so once the machine and message have both held messages as large before no allocations are made.
+func (program Program) DeserialiseReusing(inputSource decoder.Reader, pMap *presencemap.PresenceMap, dictionary *dictionary.Dictionary, machine *Machine, fixMessage *fix.Message) error {
+	fixMessage.Reset()
+	machine.message = fixMessage
+	machine.pMap = pMap
+	machine.groups = machine.groups[:0]
+	machine.typed = true
+
+	return program.deserialise(machine, inputSource, dictionary)
+}
+
+// Visit a message from the input source by running the program, passing each value to the visitor in the same way as store.Template Visit. The
+// value passed to OnField is the register of the machine, so it is only valid until OnField returns, and once the machine has visited messages as
+// large before no allocations are made.
+func (program Program) Visit(inputSource decoder.Reader, pMap *presencemap.PresenceMap, dictionary *dictionary.Dictionary, machine *Machine, visitor store.Visitor) error {
+	machine.message = nil
+	machine.pMap = pMap
+	machine.groups = machine.groups[:0]
+	machine.typed = false
+	machine.visitor = visitor
+
+	pc, err := program.run(machine, inputSource, dictionary)
+	machine.visitor = nil
+	if err != nil {
+		unit := program.instructions[pc].unit
+		program.logger.Printf("failed to visit unit [%d] within template, reason: %s", unit, err)
+		return fmt.Errorf("failed visiting message at unit[%d], reason: %s", unit, err)
+	}
+
+	return nil
+}
+
+func (program Program) deserialise(machine *Machine, inputSource decoder.Reader, dict *dictionary.Dictionary) error {
+	fixMessage := machine.message
+	if pc, err := program.run(machine, inputSource, dict); err != nil {
+		unit := program.instructions[pc].unit
+		program.logger.Printf("failed to deseralise unit [%d] within template, reason: %s, fix message before failure: %s", unit, err, fixMessage.String())
+		return fmt.Errorf("failed deserialising message at unit[%d], reason: %s", unit, err)
+	}
+
+	return nil
+}
+
+// run every instruction of the program, returning the index of the instruction that failed along with its error
+func (program Program) run(machine *Machine, inputSource decoder.Reader, dict *dictionary.Dictionary) (int, error) {
+	instructions := program.instructions
+	register := &machine.register
+	readDelta := &machine.delta
+	views := !machine.typed && machine.visitor == nil && decoder.SettingsOf(inputSource).ZeroCopy
+	machine.view, machine.viewed = nil, false
+
+	for pc := 0; pc < len(instructions); pc++ {
+		instruction := &instructions[pc]
+		var err error
+
+		switch instruction.op {
+		case opReadPmap:
+			if err = machine.pMap.Load(inputSource); err == nil {
+				err = machine.pMap.Check(inputSource, instruction.bits)
+			}
+		case opIfPmapNotSet:
+			if !machine.pMap.GetIsSetAndIncrement() {
+				pc = instruction.jump - 1
+			}
+		case opJump:
+			pc = instruction.jump - 1
+
+		case opReadUInt32:
+			var readValue value.UInt32Value
+			if readValue, err = decoder.ReadUInt32(inputSource); err == nil {
+				register.SetUInt32(readValue.Value)
+			}
+		case opReadOptionalUInt32:
+			var readValue uint32
+			var isNull bool
+			if readValue, isNull, err = decoder.ReadNullableUInt32(inputSource); err == nil {
+				register.SetUInt32(readValue)
+				setNullIf(register, isNull)
+			}
+		case opReadInt32:
+			var readValue value.Int32Value
+			if readValue, err = decoder.ReadInt32(inputSource); err == nil {
+				register.SetInt32(readValue.Value)
+			}
+		case opReadOptionalInt32:
+			var readValue int32
+			var isNull bool
+			if readValue, isNull, err = decoder.ReadNullableInt32(inputSource); err == nil {
+				register.SetInt32(readValue)
+				setNullIf(register, isNull)
+			}
+		case opReadUInt64:
+			var readValue value.UInt64Value
+			if readValue, err = decoder.ReadUInt64(inputSource); err == nil {
+				register.SetUInt64(readValue.Value)
+			}
+		case opReadOptionalUInt64:
+			var readValue uint64
+			var isNull bool
+			if readValue, isNull, err = decoder.ReadNullableUInt64(inputSource); err == nil {
+				register.SetUInt64(readValue)
+				setNullIf(register, isNull)
+			}
+		case opReadInt64:
+			var readValue value.Int64Value
+			if readValue, err = decoder.ReadInt64(inputSource); err == nil {
+				register.SetInt64(readValue.Value)
+			}
+		case opReadOptionalInt64:
+			var readValue int64
+			var isNull bool
+			if readValue, isNull, err = decoder.ReadNullableInt64(inputSource); err == nil {
+				register.SetInt64(readValue)
+				setNullIf(register, isNull)
+			}
+		case opReadASCIIString, opReadOptionalASCIIString:
+			err = readBytes(register, instruction.op, inputSource)
+		case opReadUnicodeString, opReadOptionalUnicodeString, opReadByteVector, opReadOptionalByteVector:
+			if views {
+				err = machine.readView(instruction.op, inputSource)
+				break
+			}
+			err = readBytes(register, instruction.op, inputSource)
+
+		case opReadInt64Delta:
+			var readValue value.Int64Value
+			readValue, err = decoder.ReadInt64(inputSource)
+			readDelta.null, readDelta.integer, readDelta.bigInt = false, readValue.Value, nil
+		case opReadOptionalInt64Delta:
+			readDelta.bigInt = nil
+			readDelta.integer, readDelta.null, err = decoder.ReadNullableInt64(inputSource)
+		case opReadBigIntDelta:
+			readDelta.null = false
+			readDelta.integer, readDelta.bigInt, err = decoder.ReadInt64OrBigInt(inputSource)
+		case opReadOptionalBigIntDelta:
+			readDelta.integer, readDelta.bigInt, readDelta.null, err = decoder.ReadNullableInt64OrBigInt(inputSource)
+		case opReadASCIIStringDelta, opReadUnicodeStringDelta, opReadByteVectorDelta:
+			var subtractionLength value.Int32Value
+			if subtractionLength, err = decoder.ReadInt32(inputSource); err == nil {
+				readDelta.null, readDelta.itemsToRemove = false, subtractionLength.Value
+				err = machine.readDeltaBytes(instruction.op, inputSource)
+			}
+		case opReadOptionalASCIIStringDelta, opReadOptionalUnicodeStringDelta, opReadOptionalByteVectorDelta:
+			if readDelta.itemsToRemove, readDelta.null, err = decoder.ReadNullableInt32(inputSource); err == nil && !readDelta.null {
+				err = machine.readDeltaBytes(instruction.op, inputSource)
+			}
+
+		case opLoad:
+			register.Set(&instruction.value)
+		case opCopyFromSlot:
+			err = copyFromSlot(register, instruction, dict.Entry(instruction.slot))
+		case opIncrementFromSlot:
+			err = incrementFromSlot(register, instruction, dict.Entry(instruction.slot))
+		case opApplyDelta:
+			err = applyDelta(register, readDelta, instruction, dict.Entry(instruction.slot))
+		case opApplyTail:
+			machine.unview()
+			err = machine.applyTail(instruction, dict.Entry(instruction.slot))
+
+		case opSetSlot:
+			machine.setSlot(dict, instruction.slot)
+		case opSetTag:
+			machine.setTag(instruction, register)
+
+		case opBeginDecimal:
+			switch register.Kind {
+			case fix.NullKind:
+				machine.setTag(instruction, register)
+				pc = instruction.jump - 1
+			case fix.Int32Kind:
+				if register.Signed < -63 || register.Signed > 63 {
+					err = fmt.Errorf("%s", errors.R1)
+				}
+				machine.exponent = int32(register.Signed)
+			default:
+				err = fmt.Errorf("exponent value of decimal was not expected type: %#v", register.Boxed())
+			}
+		case opEndDecimal:
+			if register.Kind != fix.Int64Kind {
+				err = fmt.Errorf("mantissa value of decimal was not expected type: %#v", register.Boxed())
+				break
+			}
+			register.SetDecimal(math.Pow(10, float64(machine.exponent)) * float64(register.Signed))
+
+		case opBeginSequence:
+			pc, err = machine.beginSequence(instruction, pc, inputSource)
+		case opEndGroup:
+			pc = machine.endGroup(instruction, pc)
+
+		case opUnit:
+			if machine.visitor != nil {
+				err = store.Visit(instruction.fallback, inputSource, machine.pMap, dict, machine.visitor)
+				break
+			}
+			var fixValue fix.Value
+			if fixValue, err = instruction.fallback.Deserialise(inputSource, machine.pMap, dict); err == nil {
+				machine.message.SetTag(instruction.tag, fixValue)
+			}
+		}
+
+		if err != nil {
+			return pc, fmt.Errorf("[%s][%s] %s", instruction.name, instruction.op, err)
+		}
+	}
+
+	return len(instructions), nil
+}
+
+// setNullIf the value read was null
+func setNullIf(register *fix.TypedValue, isNull bool) {
+	if isNull {
+		register.SetNull()
+	}
+}
+
+// kindRead by the opcode, unicode strings are read as byte vectors but are held as strings
+func kindRead(op opcode) fix.Kind {
+	switch op {
+	case opReadASCIIString, opReadOptionalASCIIString, opReadUnicodeString, opReadOptionalUnicodeString:
+		return fix.StringKind
+	case opReadASCIIStringDelta, opReadOptionalASCIIStringDelta, opReadUnicodeStringDelta, opReadOptionalUnicodeStringDelta:
+		return fix.StringKind
+	}
+	return fix.ByteVectorKind
+}
+
+// readBytes of a string or byte vector into the register
+func readBytes(register *fix.TypedValue, op opcode, inputSource decoder.Reader) (err error) {
+	var isNull bool
+	register.Kind = kindRead(op)
+	switch op {
+	case opReadASCIIString:
+		register.Bytes, err = decoder.AppendString(register.Bytes[:0], inputSource)
+	case opReadOptionalASCIIString:
+		register.Bytes, isNull, err = decoder.AppendNullableString(register.Bytes[:0], inputSource)
+	case opReadUnicodeString, opReadByteVector:
+		register.Bytes, err = decoder.AppendByteVector(register.Bytes[:0], inputSource)
+	default:
+		register.Bytes, isNull, err = decoder.AppendNullableByteVector(register.Bytes[:0], inputSource)
+	}
+	setNullIf(register, isNull)
+	return err
+}
+
+// readView of a unicode string or byte vector, which is kept as the view of the value read rather than copied into the register. ASCII strings are never
+// viewed, see decoder.ViewByteVector.
+func (machine *Machine) readView(op opcode, inputSource decoder.Reader) (err error) {
+	var view []byte
+	var isNull bool
+	switch op {
+	case opReadUnicodeString, opReadByteVector:
+		view, err = decoder.ViewByteVector(inputSource)
+	default:
+		view, isNull, err = decoder.ViewNullableByteVector(inputSource)
+	}
+
+	machine.register.Kind = kindRead(op)
+	machine.register.Bytes = machine.register.Bytes[:0]
+	setNullIf(&machine.register, isNull)
+	machine.view, machine.viewed = view, err == nil && !isNull
+	return err
+}
+
+// unview copies the view into the register, for an operator that needs the bytes of the value read
+func (machine *Machine) unview() {
+	if machine.viewed {
+		machine.register.Bytes = append(machine.register.Bytes[:0], machine.view...)
+		machine.viewed = false
+	}
+}
+
+// setSlot of the dictionary to the value in the register. A viewed value is copied straight from the view into the entry, without copying it into the
+// register first.
+func (machine *Machine) setSlot(dict *dictionary.Dictionary, slot dictionary.Slot) {
+	register := &machine.register
+	if !machine.viewed {
+		dict.Set(slot, register)
+		return
+	}
+
+	registerBytes := register.Bytes
+	register.Bytes = machine.view
+	dict.Set(slot, register)
+	register.Bytes = registerBytes
+}
+
+// readDeltaBytes of a string or byte vector delta, following its subtraction length. Deltas are never viewed, as the value they are applied to is kept in
+// the dictionary.
+func (machine *Machine) readDeltaBytes(op opcode, inputSource decoder.Reader) (err error) {
+	readDelta := &machine.delta
+	if op == opReadASCIIStringDelta || op == opReadOptionalASCIIStringDelta {
+		readDelta.bytes, err = decoder.AppendString(readDelta.bytes[:0], inputSource)
+		return err
+	}
+
+	readDelta.bytes, err = decoder.AppendByteVector(readDelta.bytes[:0], inputSource)
+	return err
+}
+
+// setTag of the message or repeating group being decoded to the value, or pass the value to the visitor
+func (machine *Machine) setTag(instruction *instruction, register *fix.TypedValue) {
+	tag := instruction.tag
+	if machine.visitor != nil {
+		machine.visitor.OnField(tag, instruction.name, register)
+		return
+	}
+	if machine.typed {
+		machine.message.SetTypedTag(tag).Set(register)
+		return
+	}
+	if machine.viewed {
+		machine.viewed = false
+		machine.message.SetTag(tag, machine.boxedView())
+		return
+	}
+	machine.message.SetTag(tag, register.Boxed())
+}
+
+// boxedView of the value read without copying it, a unicode string is copied as the string it is stored as must not change under the caller
+func (machine *Machine) boxedView() fix.Value {
+	if machine.register.Kind == fix.StringKind {
+		return fix.NewRawValue(string(machine.view))
+	}
+	return fix.NewRawValue(machine.view)
+}
+
+// beginSequence with the length in the register, returning the index of the instruction before the next one to run. Typed machines set the tag of the
+// sequence before its repeating groups are decoded, so the groups are decoded straight into the typed value, while visiting machines build no sequence.
+func (machine *Machine) beginSequence(instruction *instruction, pc int, inputSource decoder.Reader) (int, error) {
+	switch machine.register.Kind {
+	case fix.NullKind:
+		machine.setTag(instruction, &machine.register)
+		return instruction.jump - 1, nil
+	case fix.UInt32Kind:
+	default:
+		return pc, fmt.Errorf("length of sequence was not expected type: %#v", machine.register.Boxed())
+	}
+
+	length := uint32(machine.register.Unsigned)
+	if maxLength := decoder.SettingsOf(inputSource).MaxSequenceLength; maxLength > 0 && length > maxLength {
+		return pc, fmt.Errorf("sequence of %d repeating groups exceeds the maximum sequence length of %d", length, maxLength)
+	}
+
+	var sequence fix.SequenceValue
+	switch {
+	case machine.visitor != nil:
+		machine.visitor.OnSequenceStart(instruction.tag, length)
+	case machine.typed:
+		sequence.Values = machine.message.SetTypedTag(instruction.tag).SetSequence(int(length))
+	default:
+		sequence = fix.NewSequenceValue(length)
+	}
+	if length == 0 {
+		switch {
+		case machine.visitor != nil:
+			machine.visitor.OnSequenceEnd()
+		case !machine.typed:
+			machine.message.SetTag(instruction.tag, sequence)
+		}
+		return instruction.jump - 1, nil
+	}
+
+	// groups are reused rather than appended so the presence map of each group keeps its buffer
+	if len(machine.groups) < cap(machine.groups) {
+		machine.groups = machine.groups[:len(machine.groups)+1]
+	} else {
+		machine.groups = append(machine.groups, group{})
+	}
+	current := &machine.groups[len(machine.groups)-1]
+	current.sequence = sequence
+	current.length = int(length)
+	current.index = 0
+	current.message = machine.message
+	current.parent = machine.pMap
+
+	if machine.visitor != nil {
+		machine.visitor.OnGroupStart(0)
+	} else {
+		machine.message = &current.sequence.Values[0]
+	}
+	machine.pMap = &current.pMap
+	return pc, nil
+}
+
+// endGroup of the sequence, starting the next repeating group or ending the sequence, returning the index of the instruction before the next one to run
+func (machine *Machine) endGroup(instruction *instruction, pc int) int {
+	current := &machine.groups[len(machine.groups)-1]
+	current.index++
+	if current.index < current.length {
+		if machine.visitor != nil {
+			machine.visitor.OnGroupStart(uint32(current.index))
+		} else {
+			machine.message = &current.sequence.Values[current.index]
+		}
+		return instruction.jump - 1
+	}
+
+	machine.message = current.message
+	switch {
+	case machine.visitor != nil:
+		machine.visitor.OnSequenceEnd()
+	case !machine.typed:
+		machine.message.SetTag(instruction.tag, current.sequence)
+	}
+	machine.groups = machine.groups[:len(machine.groups)-1]
+	if len(machine.groups) == 0 {
+		machine.pMap = current.parent
+	} else {
+		machine.pMap = &machine.groups[len(machine.groups)-1].pMap
+	}
+	return pc
+}
+
+// copyFromSlot loads the previous value, or the initial value if the previous value is undefined, as operation.Copy and operation.Tail
+func copyFromSlot(register *fix.TypedValue, instruction *instruction, previousValue *dictionary.Entry) error {
+	switch previousValue.State() {
+	case dictionary.Assigned:
+		register.Set(previousValue.Value())
+		return nil
+	case dictionary.Empty:
+		register.SetNull()
+		return nil
+	}
+
+	if instruction.value.Kind == fix.NullKind && instruction.required {
+		return fmt.Errorf("%s", instruction.missing)
+	}
+	register.Set(&instruction.value)
+	return nil
+}
+
+// incrementFromSlot loads the previous value incremented by 1, or the initial value if the previous value is undefined, as operation.Increment
+func incrementFromSlot(register *fix.TypedValue, instruction *instruction, previousValue *dictionary.Entry) error {
+	switch previousValue.State() {
+	case dictionary.Assigned:
+		previous := previousValue.Value()
+		switch previous.Kind {
+		case fix.UInt32Kind:
+			register.SetUInt32(uint32(previous.Unsigned) + 1)
+		case fix.UInt64Kind:
+			register.SetUInt64(previous.Unsigned + 1)
+		case fix.Int32Kind:
+			register.SetInt32(int32(previous.Signed) + 1)
+		case fix.Int64Kind:
+			register.SetInt64(previous.Signed + 1)
+		default:
+			return fmt.Errorf("unsupported type for increment operator, can only increment integers")
+		}
+		return nil
+	case dictionary.Empty:
+		if instruction.required {
+			return fmt.Errorf("%s", errors.D6)
+		}
+		register.SetNull()
+		return nil
+	}
+
+	return copyFromSlot(register, instruction, previousValue)
+}
+
+// baseOf the delta or tail, the previous value if assigned, else the initial value, falling back to the base value of the type
+func baseOf(instruction *instruction, previousValue *dictionary.Entry) *fix.TypedValue {
+	if previousValue.State() == dictionary.Assigned {
+		return previousValue.Value()
+	}
+	if instruction.value.Kind != fix.NullKind {
+		return &instruction.value
+	}
+	return &instruction.base
+}
+
+// applyDelta read to its base value, the type of the value is the type of the base value as with value.Value Add
+func applyDelta(register *fix.TypedValue, readDelta *delta, instruction *instruction, previousValue *dictionary.Entry) error {
+	if readDelta.null {
+		register.SetNull()
+		return nil
+	}
+
+	base := baseOf(instruction, previousValue)
+	switch instruction.base.Kind {
+	case fix.UInt32Kind, fix.Int32Kind:
+		switch base.Kind {
+		case fix.Int32Kind:
+			combined, err := value.Int64Value{Value: readDelta.integer}.AddToInt32(int32(base.Signed))
+			register.SetInt32(combined)
+			return err
+		case fix.UInt32Kind:
+			combined, err := value.Int64Value{Value: readDelta.integer}.AddToUInt32(uint32(base.Unsigned))
+			register.SetUInt32(combined)
+			return err
+		}
+		return fmt.Errorf("unsupported type to add int64 to: %#v", base.Get())
+	case fix.UInt64Kind, fix.Int64Kind:
+		switch base.Kind {
+		case fix.Int64Kind:
+			combined, ok := addInt64(base.Signed, readDelta)
+			if !ok {
+				var err error
+				combined, err = value.BigInt{Value: bigIntOf(readDelta)}.AddToInt64(base.Signed)
+				register.SetInt64(combined)
+				return err
+			}
+			register.SetInt64(combined)
+			return nil
+		case fix.UInt64Kind:
+			combined, ok := addUInt64(base.Unsigned, readDelta)
+			if !ok {
+				var err error
+				combined, err = value.BigInt{Value: bigIntOf(readDelta)}.AddToUInt64(base.Unsigned)
+				register.SetUInt64(combined)
+				return err
+			}
+			register.SetUInt64(combined)
+			return nil
+		}
+		return fmt.Errorf("unsupported type to add big int to: %#v", base.Get())
+	case fix.StringKind:
+		if base.Kind != fix.StringKind {
+			return fmt.Errorf("unsupported type to add string delta to: %#v", base.Get())
+		}
+		if !addDelta(register, readDelta, base) {
+			_, err := value.StringValue{Value: string(readDelta.bytes), ItemsToRemove: readDelta.itemsToRemove}.AddTo(string(base.Bytes))
+			return err
+		}
+		register.Kind = fix.StringKind
+		return nil
+	case fix.ByteVectorKind:
+		if base.Kind != fix.ByteVectorKind {
+			return fmt.Errorf("unsupported type to add byte vector delta to: %#v", base.Get())
+		}
+		if !addDelta(register, readDelta, base) {
+			_, err := value.ByteVector{Value: readDelta.bytes, ItemsToRemove: readDelta.itemsToRemove}.AddTo(base.Bytes)
+			return err
+		}
+		register.Kind = fix.ByteVectorKind
+		return nil
+	}
+
+	return fmt.Errorf("unsupported type for delta operator")
+}
+
+// addInt64 adds the delta to the base, returning false if the delta was read as a big.Int or the result overflows, in which case value.BigInt is used
+func addInt64(base int64, readDelta *delta) (int64, bool) {
+	if readDelta.bigInt != nil {
+		return 0, false
+	}
+	combined := base + readDelta.integer
+	if (readDelta.integer > 0 && combined < base) || (readDelta.integer < 0 && combined > base) {
+		return 0, false
+	}
+	return combined, true
+}
+
+// addUInt64 adds the delta to the base, returning false if the delta was read as a big.Int or the result overflows, in which case value.BigInt is used
+func addUInt64(base uint64, readDelta *delta) (uint64, bool) {
+	if readDelta.bigInt != nil {
+		return 0, false
+	}
+	combined := base + uint64(readDelta.integer)
+	if (readDelta.integer > 0 && combined < base) || (readDelta.integer < 0 && combined > base) {
+		return 0, false
+	}
+	return combined, true
+}
+
+// bigIntOf the delta, only built when a delta overflows or was read as a big.Int
+func bigIntOf(readDelta *delta) *big.Int {
+	if readDelta.bigInt != nil {
+		return readDelta.bigInt
+	}
+	return big.NewInt(readDelta.integer)
+}
+
+// addDelta of bytes to the bytes of the base value, writing the combined bytes into the register in the same way as value.StringValue and value.ByteVector
+// AddTo. Returns false if the delta removes more bytes than the base value has.
+func addDelta(register *fix.TypedValue, readDelta *delta, base *fix.TypedValue) bool {
+	existingValue := base.Bytes
+	if readDelta.itemsToRemove < 0 {
+		if readDelta.itemsToRemove == -1 {
+			register.Bytes = append(append(register.Bytes[:0], readDelta.bytes...), existingValue...)
+			return true
+		}
+
+		itemsToRemove := (-readDelta.itemsToRemove) - 1
+		if itemsToRemove > int32(len(existingValue)) {
+			return false
+		}
+		register.Bytes = append(append(register.Bytes[:0], readDelta.bytes...), existingValue[itemsToRemove:]...)
+		return true
+	}
+
+	itemsToKeep := int32(len(existingValue)) - readDelta.itemsToRemove
+	if itemsToKeep < 0 {
+		return false
+	}
+	register.Bytes = append(append(register.Bytes[:0], existingValue[:itemsToKeep]...), readDelta.bytes...)
+	return true
+}
+
+// applyTail overwrites the end of the base value with the value in the register, as operation.Tail
+func (machine *Machine) applyTail(instruction *instruction, previousValue *dictionary.Entry) error {
+	register := &machine.register
+	if register.Kind == fix.NullKind {
+		return nil
+	}
+
+	base := baseOf(instruction, previousValue)
+	switch {
+	case register.Kind == fix.StringKind && base.Kind == fix.StringKind:
+		// strings are overwritten by character, so invalid UTF-8 is left to value.StringValue to replace in the same way
+		if !utf8.Valid(register.Bytes) || !utf8.Valid(base.Bytes) {
+			register.SetString([]byte(value.StringValue{Value: string(register.Bytes)}.TailOf(string(base.Bytes))))
+			return nil
+		}
+		keep := utf8.RuneCount(base.Bytes) - utf8.RuneCount(register.Bytes)
+		end := 0
+		for ; keep > 0; keep-- {
+			_, size := utf8.DecodeRune(base.Bytes[end:])
+			end += size
+		}
+		machine.tailOf(base.Bytes[:end])
+		return nil
+	case register.Kind == fix.ByteVectorKind && base.Kind == fix.ByteVectorKind:
+		if keep := len(base.Bytes) - len(register.Bytes); keep > 0 {
+			machine.tailOf(base.Bytes[:keep])
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unsupported type for tail operator, you can only use this with strings and byte vectors")
+}
+
+// tailOf sets the register to the start of the base value followed by the value in the register, swapping the bytes of the register with the scratch buffer
+func (machine *Machine) tailOf(start []byte) {
+	if len(start) == 0 {
+		return
+	}
+	combined := append(append(machine.scratch[:0], start...), machine.register.Bytes...)
+	machine.scratch = machine.register.Bytes
+	machine.register.Bytes = combined
+}
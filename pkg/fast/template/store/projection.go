@@ -0,0 +1,149 @@
+package store
+
+import (
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+// Projection of a Template onto a subset of its tags. A message visited with a ProjectingVisitor of the projection stores only the tags within the
+// projection, while every field is still decoded through its operators, so the pmap and dictionary of previous values stay correct.
+type Projection struct {
+	units *projectedUnits
+}
+
+// projectedUnits is the set of tags stored at one level of a message. Fields map to nil, and sequences map to the tags stored within their repeating groups.
+type projectedUnits struct {
+	tags map[uint64]*projectedUnits
+}
+
+// Project the template onto the tags given. A tag within a sequence stores the sequence with only the projected tags in each repeating group,
+// and the tag of a sequence itself stores the whole sequence.
+func (template Template) Project(tags ...uint64) Projection {
+	projectedTags := make(map[uint64]bool, len(tags))
+	for _, tag := range tags {
+		projectedTags[tag] = true
+	}
+
+	units, _ := project(template.TemplateUnits, projectedTags, false)
+	return Projection{units: units}
+}
+
+// project the units onto the tags, returning whether any unit was projected. If all is true every unit is projected.
+func project(units []Unit, tags map[uint64]bool, all bool) (*projectedUnits, bool) {
+	projected := &projectedUnits{tags: make(map[uint64]*projectedUnits)}
+	for _, unit := range units {
+		tag := unit.GetTagId()
+		visitingUnit, isVisitingUnit := unit.(VisitingUnit)
+		if !isVisitingUnit {
+			if all || tags[tag] {
+				projected.tags[tag] = nil
+			}
+			continue
+		}
+
+		groupUnits, anyProjected := project(visitingUnit.Units(), tags, all || tags[tag])
+		if anyProjected || all || tags[tag] {
+			projected.tags[tag] = groupUnits
+		}
+	}
+
+	return projected, len(projected.tags) > 0
+}
+
+// ProjectingVisitor stores the values of the tags within a Projection in a fix.Message as a message is visited, skipping every other value, so values
+// outside the projection are never boxed. The zero value is ready to be Reset.
+type ProjectingVisitor struct {
+	units    *projectedUnits
+	message  *fix.Message
+	typed    bool
+	parents  []projectedSequence
+	skipping int
+}
+
+// projectedSequence is a sequence being built by the ProjectingVisitor, along with the message and units it is stored in
+type projectedSequence struct {
+	tag      uint64
+	sequence fix.SequenceValue
+	groups   []fix.Message
+	units    *projectedUnits
+	message  *fix.Message
+}
+
+// Reset the visitor to store the tags of the projection in the message as it is visited. A typed visitor sets each tag to a typed value of the message,
+// see fix.Message SetTypedTag, so a message that is Reset and visited again stops allocating once it has held as many values. Otherwise each projected
+// value is boxed, in the same way as a message that is deserialised.
+func (visitor *ProjectingVisitor) Reset(projection Projection, message *fix.Message, typed bool) {
+	visitor.units = projection.units
+	visitor.message = message
+	visitor.typed = typed
+	visitor.parents = visitor.parents[:0]
+	visitor.skipping = 0
+}
+
+// OnField stores the value if its tag is within the projection, a *fix.TypedValue is copied as it may be reused once OnField returns
+func (visitor *ProjectingVisitor) OnField(tag uint64, name string, value fix.Value) {
+	if visitor.skipping > 0 {
+		return
+	}
+	if _, projected := visitor.units.tags[tag]; !projected {
+		return
+	}
+
+	typedValue, isTyped := value.(*fix.TypedValue)
+	switch {
+	case isTyped && visitor.typed:
+		visitor.message.SetTypedTag(tag).Set(typedValue)
+	case isTyped:
+		visitor.message.SetTag(tag, typedValue.Boxed())
+	default:
+		visitor.message.SetTag(tag, value)
+	}
+}
+
+// OnSequenceStart stores the sequence if its tag, or any tag within its repeating groups, is within the projection
+func (visitor *ProjectingVisitor) OnSequenceStart(tag uint64, length uint32) {
+	if visitor.skipping > 0 {
+		visitor.skipping++
+		return
+	}
+
+	groupUnits, projected := visitor.units.tags[tag]
+	if !projected {
+		visitor.skipping = 1
+		return
+	}
+
+	parent := projectedSequence{tag: tag, units: visitor.units, message: visitor.message}
+	if visitor.typed {
+		parent.groups = visitor.message.SetTypedTag(tag).SetSequence(int(length))
+	} else {
+		parent.sequence = fix.NewSequenceValue(length)
+		parent.groups = parent.sequence.Values
+	}
+	visitor.parents = append(visitor.parents, parent)
+	visitor.units = groupUnits
+}
+
+// OnGroupStart stores the values that follow within the repeating group
+func (visitor *ProjectingVisitor) OnGroupStart(index uint32) {
+	if visitor.skipping > 0 {
+		return
+	}
+
+	visitor.message = &visitor.parents[len(visitor.parents)-1].groups[index]
+}
+
+// OnSequenceEnd stores the values that follow within the message the sequence is stored in
+func (visitor *ProjectingVisitor) OnSequenceEnd() {
+	if visitor.skipping > 0 {
+		visitor.skipping--
+		return
+	}
+
+	parent := visitor.parents[len(visitor.parents)-1]
+	visitor.parents = visitor.parents[:len(visitor.parents)-1]
+	visitor.units = parent.units
+	visitor.message = parent.message
+	if !visitor.typed {
+		visitor.message.SetTag(parent.tag, parent.sequence)
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"fmt"
 	"log"
+	"sort"
 
+	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
 	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
 	"github.com/Guardian-Development/fastengine/pkg/fast/presencemap"
 	"github.com/Guardian-Development/fastengine/pkg/fix"
@@ -13,23 +15,29 @@ import (
 // Store represents a loaded set of Templates that can be used to Serialise/Deserialise FAST messages
 type Store struct {
 	Templates map[uint32]Template
+	// Slots of the dictionary key of every unit within the Templates, resolved as the store is loaded, see SlotsOf
+	Slots *dictionary.Slots
 }
 
 // Template represents an ordered List of operations needed to Serialise/Deserialise a FAST message
 type Template struct {
 	TemplateUnits []Unit
 	Logger        *log.Logger
+	// Reset every dictionary before the body of a message encoded with the template is decoded or encoded, set by reset="Y" on the template
+	Reset bool
 }
 
 // Unit represents an element within a FAST Template, with the ability to Serialise/Deserialise a part of a FAST message
 type Unit interface {
-	Deserialise(inputSource *bytes.Buffer, pMap *presencemap.PresenceMap, dictionary *dictionary.Dictionary) (fix.Value, error)
+	Deserialise(inputSource decoder.Reader, pMap *presencemap.PresenceMap, dictionary *dictionary.Dictionary) (fix.Value, error)
+	Serialise(outputSource *bytes.Buffer, pMap *presencemap.PresenceMap, dictionary *dictionary.Dictionary, value fix.Value) error
 	GetTagId() uint64
+	GetName() string
 	RequiresPmap() bool
 }
 
 // Deserialise a message from the input source iterating through the TemplateUnits to do this
-func (template Template) Deserialise(inputSource *bytes.Buffer, pMap *presencemap.PresenceMap, dictionary *dictionary.Dictionary) (*fix.Message, error) {
+func (template Template) Deserialise(inputSource decoder.Reader, pMap *presencemap.PresenceMap, dictionary *dictionary.Dictionary) (*fix.Message, error) {
 	fixMessage := fix.New()
 	for _, unit := range template.TemplateUnits {
 		value, err := unit.Deserialise(inputSource, pMap, dictionary)
@@ -43,3 +51,123 @@ func (template Template) Deserialise(inputSource *bytes.Buffer, pMap *presencema
 
 	return &fixMessage, nil
 }
+
+// Visit a message from the input source iterating through the TemplateUnits, passing each value to the visitor as it is decoded rather than building a fix.Message
+func (template Template) Visit(inputSource decoder.Reader, pMap *presencemap.PresenceMap, dictionary *dictionary.Dictionary, visitor Visitor) error {
+	for _, unit := range template.TemplateUnits {
+		if err := Visit(unit, inputSource, pMap, dictionary, visitor); err != nil {
+			template.Logger.Printf("failed to visit unit [%d] within template, reason: %s", unit.GetTagId(), err)
+			return fmt.Errorf("failed visiting message at unit[%d], reason: %s", unit.GetTagId(), err)
+		}
+	}
+
+	return nil
+}
+
+// TagsByName returns the tag of every unit within the template by its name, including the units within sequences
+func (template Template) TagsByName() map[string]uint64 {
+	tags := make(map[string]uint64)
+	addTagsByName(template.TemplateUnits, tags)
+	return tags
+}
+
+func addTagsByName(units []Unit, tags map[string]uint64) {
+	for _, unit := range units {
+		tags[unit.GetName()] = unit.GetTagId()
+		if visitingUnit, ok := unit.(VisitingUnit); ok {
+			addTagsByName(visitingUnit.Units(), tags)
+		}
+	}
+}
+
+// Serialise a message to the output source iterating through the TemplateUnits to do this. Tags not present in the message are encoded as null.
+func (template Template) Serialise(outputSource *bytes.Buffer, pMap *presencemap.PresenceMap, dictionary *dictionary.Dictionary, message *fix.Message) error {
+	for _, unit := range template.TemplateUnits {
+		value, exists := message.Tags[unit.GetTagId()]
+		if !exists {
+			value = fix.NullValue{}
+		}
+
+		err := unit.Serialise(outputSource, pMap, dictionary, value)
+		if err != nil {
+			template.Logger.Printf("failed to serialise unit [%d] within template, reason: %s, fix message being serialised: %s", unit.GetTagId(), err, message.String())
+			return fmt.Errorf("failed serialising message at unit[%d], reason: %s", unit.GetTagId(), err)
+		}
+	}
+
+	return nil
+}
+
+// PresenceMapUnit is a Unit that reads more than one bit of the presence map, such as a decimal with an operator on both its exponent and mantissa. Units
+// that do not implement it read a single bit when they RequiresPmap.
+type PresenceMapUnit interface {
+	Unit
+	// PresenceMapUnits names the unit that reads each bit of the presence map, in the order the bits are read
+	PresenceMapUnits() []string
+}
+
+// LayoutOf the presence map read by the units, which are either the units of a template or the units of a repeating group within a sequence
+func LayoutOf(units []Unit) presencemap.Layout {
+	layout := presencemap.Layout{}
+	for _, unit := range units {
+		if presenceMapUnit, ok := unit.(PresenceMapUnit); ok {
+			layout.Units = append(layout.Units, presenceMapUnit.PresenceMapUnits()...)
+			continue
+		}
+		if unit.RequiresPmap() {
+			layout.Units = append(layout.Units, unit.GetName())
+		}
+	}
+
+	return layout
+}
+
+// KeyedUnit is a Unit that holds previous values within the dictionary, such as a field with a copy operator. The keys of the units within a VisitingUnit
+// are the keys of those units.
+type KeyedUnit interface {
+	Unit
+	// Keys of the dictionary entries the unit reads and sets
+	Keys() []dictionary.Key
+}
+
+// KeysOf the dictionary entries of the units, including the units within sequences, each key once in the order it is first used
+func KeysOf(units []Unit) []dictionary.Key {
+	keys := make([]dictionary.Key, 0)
+	seen := make(map[dictionary.Key]bool)
+	addKeys(units, &keys, seen)
+	return keys
+}
+
+func addKeys(units []Unit, keys *[]dictionary.Key, seen map[dictionary.Key]bool) {
+	for _, unit := range units {
+		if keyedUnit, ok := unit.(KeyedUnit); ok {
+			for _, key := range keyedUnit.Keys() {
+				if !seen[key] {
+					seen[key] = true
+					*keys = append(*keys, key)
+				}
+			}
+		}
+		if visitingUnit, ok := unit.(VisitingUnit); ok {
+			addKeys(visitingUnit.Units(), keys, seen)
+		}
+	}
+}
+
+// SlotsOf the dictionary key of every unit within the templates, resolved in template ID order so the same templates always resolve the same slots
+func SlotsOf(templates map[uint32]Template) *dictionary.Slots {
+	templateIDs := make([]uint32, 0, len(templates))
+	for templateID := range templates {
+		templateIDs = append(templateIDs, templateID)
+	}
+	sort.Slice(templateIDs, func(i, j int) bool { return templateIDs[i] < templateIDs[j] })
+
+	slots := &dictionary.Slots{}
+	for _, templateID := range templateIDs {
+		for _, key := range KeysOf(templates[templateID].TemplateUnits) {
+			slots.Resolve(key)
+		}
+	}
+
+	return slots
+}
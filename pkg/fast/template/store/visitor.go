@@ -0,0 +1,41 @@
+package store
+
+import (
+	"github.com/Guardian-Development/fastengine/pkg/fast/decoder"
+	"github.com/Guardian-Development/fastengine/pkg/fast/dictionary"
+	"github.com/Guardian-Development/fastengine/pkg/fast/presencemap"
+	"github.com/Guardian-Development/fastengine/pkg/fix"
+)
+
+// Visitor is called with each part of a FAST message in template order as it is decoded, allowing the message to be read without building a fix.Message.
+// A sequence is visited as OnSequenceStart, then OnGroupStart followed by the fields of each repeating group, then OnSequenceEnd. Sequences within a repeating group
+// are visited in the same way, so the start and end calls are always nested. An optional sequence that is not present is visited as OnField with a null value.
+// The value passed to OnField may be reused once OnField returns, so a visitor that keeps a value must copy it.
+type Visitor interface {
+	OnField(tag uint64, name string, value fix.Value)
+	OnSequenceStart(tag uint64, length uint32)
+	OnGroupStart(index uint32)
+	OnSequenceEnd()
+}
+
+// VisitingUnit is a Unit made up of other units (i.e. a <sequence/>), that can pass each of its values to a Visitor rather than building a single fix.Value
+type VisitingUnit interface {
+	Unit
+	Units() []Unit
+	Visit(inputSource decoder.Reader, pMap *presencemap.PresenceMap, dictionary *dictionary.Dictionary, visitor Visitor) error
+}
+
+// Visit the unit, if it is a VisitingUnit it visits its own values, otherwise the unit is deserialised and its value passed to OnField
+func Visit(unit Unit, inputSource decoder.Reader, pMap *presencemap.PresenceMap, dictionary *dictionary.Dictionary, visitor Visitor) error {
+	if visitingUnit, ok := unit.(VisitingUnit); ok {
+		return visitingUnit.Visit(inputSource, pMap, dictionary, visitor)
+	}
+
+	value, err := unit.Deserialise(inputSource, pMap, dictionary)
+	if err != nil {
+		return err
+	}
+
+	visitor.OnField(unit.GetTagId(), unit.GetName(), value)
+	return nil
+}
@@ -13,6 +13,8 @@ const ByteVectorTag = "byteVector"
 const SequenceTag = "sequence"
 const LengthTag = "length"
 const DecimalTag = "decimal"
+const TypeRefTag = "typeRef"
+const TemplateRefTag = "templateRef"
 const UnicodeStringLabel = "unicode"
 
 const ConstantOperation = "constant"
@@ -23,12 +25,25 @@ const TailOperation = "tail"
 const DeltaOperation = "delta"
 
 const ValueAttribute = "value"
+const DictionaryAttribute = "dictionary"
+const KeyAttribute = "key"
+const NamespaceAttribute = "ns"
+const ResetAttribute = "reset"
 
 // HasValue returns whether the value attribute is set on the xml tags
 func HasValue(tagInTemplate *xml.Tag) bool {
 	return tagInTemplate.Attributes[ValueAttribute] != ""
 }
 
+// IsOperation returns whether the xml tag is a field operator
+func IsOperation(tagInTemplate *xml.Tag) bool {
+	switch tagInTemplate.Type {
+	case ConstantOperation, DefaultOperation, CopyOperation, IncrementOperation, TailOperation, DeltaOperation:
+		return true
+	}
+	return false
+}
+
 // IsNullString returns whether the value is equal to ""
 func IsNullString(value string) bool {
 	return value == ""
@@ -44,44 +44,57 @@ func (value StringValue) GetAsFix() fix.Value {
 // Add applies the read value to the previous toAdd value. ItemsToRemove represents how much of the existing value to overwrite.
 // Positive means overwrite from end of existing string, negative means overwrite from beginning of existing string.
 func (value StringValue) Add(toAdd fix.Value) (fix.Value, error) {
-	existingValue := toAdd.Get().(string)
+	combinedValue, err := value.AddTo(toAdd.Get().(string))
+	if err != nil {
+		return nil, err
+	}
+	return fix.NewRawValue(combinedValue), nil
+}
+
+// AddTo applies the read value to the existing string in the same way as Add, without wrapping either in a fix type
+func (value StringValue) AddTo(existingValue string) (string, error) {
 	// prepend
 	if value.ItemsToRemove < 0 {
 		if value.ItemsToRemove == -1 {
-			return fix.NewRawValue(value.Value + existingValue), nil
+			return value.Value + existingValue, nil
 		}
 
 		itemsToRemove := (-value.ItemsToRemove) - 1
 		if itemsToRemove > int32(len(existingValue)) {
-			return nil, fmt.Errorf("%s: removing %d values from string %s", errors.D7, itemsToRemove, existingValue)
+			return "", fmt.Errorf("%s: removing %d values from string %s", errors.D7, itemsToRemove, existingValue)
 		}
 		stringWithRemovedChars := existingValue[itemsToRemove:]
-		return fix.NewRawValue(value.Value + stringWithRemovedChars), nil
+		return value.Value + stringWithRemovedChars, nil
 	}
 
 	// append
 	itemsToRemove := int32(len(existingValue)) - value.ItemsToRemove
 	if itemsToRemove < 0 {
-		return nil, fmt.Errorf("%s: removing %d values from string %s", errors.D7, value.ItemsToRemove, existingValue)
+		return "", fmt.Errorf("%s: removing %d values from string %s", errors.D7, value.ItemsToRemove, existingValue)
 	}
 	stringWithRemovedChars := existingValue[:int32(len(existingValue))-value.ItemsToRemove]
-	return fix.NewRawValue(stringWithRemovedChars + value.Value), nil
+	return stringWithRemovedChars + value.Value, nil
 }
 
 // ApplyTail overwrites the end of the previous string with the read value
 func (value StringValue) ApplyTail(baseValue fix.Value) (fix.Value, error) {
-	baseValueAsChars := []rune(baseValue.Get().(string))
+	return fix.NewRawValue(value.TailOf(baseValue.Get().(string))), nil
+}
+
+// TailOf overwrites the end of the base string with the read value in the same way as ApplyTail, without wrapping either in a fix type
+func (value StringValue) TailOf(baseValue string) string {
+	baseValueAsChars := []rune(baseValue)
 	readValueAsChars := []rune(value.Value)
 	indexToAppendReadValue := len(baseValueAsChars) - len(readValueAsChars)
 
 	// read more than base value, read value replaces all of base value
 	if indexToAppendReadValue <= 0 {
-		return value.GetAsFix(), nil
+		return value.Value
 	}
 
 	start := baseValueAsChars[0:indexToAppendReadValue]
 	combinedValue := append(start, readValueAsChars...)
-	return fix.NewRawValue(string(combinedValue)), nil
+	return string(combinedValue)
 }
 
 // ByteVector represents a byte vector fast value. Items to remove is used when applying a delta to a byte vector
@@ -98,11 +111,19 @@ func (value ByteVector) GetAsFix() fix.Value {
 // Add applies the read value to the previous toAdd value. ItemsToRemove represents how much of the existing value to overwrite.
 // Positive means overwrite from end of existing []byte, negative means overwrite from beginning of existing []byte.
 func (value ByteVector) Add(toAdd fix.Value) (fix.Value, error) {
-	existingValue := toAdd.Get().([]byte)
+	combinedValue, err := value.AddTo(toAdd.Get().([]byte))
+	if err != nil {
+		return nil, err
+	}
+	return fix.NewRawValue(combinedValue), nil
+}
+
+// AddTo applies the read value to the existing []byte in the same way as Add, without wrapping either in a fix type
+func (value ByteVector) AddTo(existingValue []byte) ([]byte, error) {
 	// prepend
 	if value.ItemsToRemove < 0 {
 		if value.ItemsToRemove == -1 {
-			return fix.NewRawValue(append(value.Value, existingValue...)), nil
+			return append(value.Value, existingValue...), nil
 		}
 
 		itemsToRemove := (-value.ItemsToRemove) - 1
@@ -110,7 +131,7 @@ func (value ByteVector) Add(toAdd fix.Value) (fix.Value, error) {
 			return nil, fmt.Errorf("%s: removing %d values from bytevector %#v", errors.D7, itemsToRemove, existingValue)
 		}
 		vectorWithRemovedBytes := existingValue[itemsToRemove:]
-		return fix.NewRawValue(append(value.Value, vectorWithRemovedBytes...)), nil
+		return append(value.Value, vectorWithRemovedBytes...), nil
 	}
 
 	// append
@@ -118,23 +139,29 @@ func (value ByteVector) Add(toAdd fix.Value) (fix.Value, error) {
 	if itemsToRemove < 0 {
 		return nil, fmt.Errorf("%s: removing %d values from bytevector %#v", errors.D7, value.ItemsToRemove, existingValue)
 	}
-	vectorWithRemovedBytes := existingValue[:int32(len(existingValue))-value.ItemsToRemove]
-	return fix.NewRawValue(append(vectorWithRemovedBytes, value.Value...)), nil
+	// capped so the existing value, which may be a previous value still held elsewhere, is copied rather than appended to
+	keep := int32(len(existingValue)) - value.ItemsToRemove
+	vectorWithRemovedBytes := existingValue[:keep:keep]
+	return append(vectorWithRemovedBytes, value.Value...), nil
 }
 
 // ApplyTail overwrites the end of the previous []byte with the read value
 func (value ByteVector) ApplyTail(baseValue fix.Value) (fix.Value, error) {
-	baseValueAsBytes := baseValue.Get().([]byte)
-	indexToAppendReadValue := len(baseValueAsBytes) - len(value.Value)
+	return fix.NewRawValue(value.TailOf(baseValue.Get().([]byte))), nil
+}
+
+// TailOf overwrites the end of the base []byte with the read value in the same way as ApplyTail, without wrapping either in a fix type
+func (value ByteVector) TailOf(baseValue []byte) []byte {
+	indexToAppendReadValue := len(baseValue) - len(value.Value)
 
 	// read more than base value, read value replaces all of base value
 	if indexToAppendReadValue <= 0 {
-		return value.GetAsFix(), nil
+		return value.Value
 	}
 
-	start := baseValueAsBytes[0:indexToAppendReadValue]
-	combinedValue := append(start, value.Value...)
-	return fix.NewRawValue(combinedValue), nil
+	// capped so the base value, which may be a previous value still held elsewhere, is copied rather than appended to
+	start := baseValue[0:indexToAppendReadValue:indexToAppendReadValue]
+	return append(start, value.Value...)
 }
 
 // UInt32Value represents a uint32 fast value
@@ -208,6 +235,16 @@ func (value Int64Value) Add(toAdd fix.Value) (fix.Value, error) {
 	return fix.NullValue{}, fmt.Errorf("unsupported type to add int64 to: %#v", toAdd.Get())
 }
 
+// AddToUInt32 adds the read delta to the base value, returning an error if the result does not fit within a uint32
+func (value Int64Value) AddToUInt32(baseValue uint32) (uint32, error) {
+	return addWithinUInt32Constraints(value.Value, int64(baseValue))
+}
+
+// AddToInt32 adds the read delta to the base value, returning an error if the result does not fit within an int32
+func (value Int64Value) AddToInt32(baseValue int32) (int32, error) {
+	return addWithinInt32Constraints(value.Value, int64(baseValue))
+}
+
 // BigInt represents a uint64 and int64 when we are allowing for byte overflows
 type BigInt struct {
 	Value *big.Int
@@ -220,52 +257,229 @@ func (value BigInt) GetAsFix() fix.Value {
 
 // Add the previous value to the read value, assuring we stay within the constraints of either an int64 or uint64 depending on the previous value
 func (value BigInt) Add(toAdd fix.Value) (fix.Value, error) {
-	valueCopy := big.NewInt(0).Set(value.Value)
-
 	switch t := toAdd.Get().(type) {
 	case int64:
-		valueAfterAddition := valueCopy.Add(valueCopy, big.NewInt(t))
-
-		// if the addition does not stay within the bounds of an int64, we have an overflow and report an error
-		if !valueAfterAddition.IsInt64() {
-			return nil, fmt.Errorf("%s, %v + %v would overflow int64", errors.R4, t, value.Value.Int64())
+		valueAfterAddition, err := value.AddToInt64(t)
+		if err != nil {
+			return nil, err
 		}
-		return fix.NewRawValue(valueAfterAddition.Int64()), nil
+		return fix.NewRawValue(valueAfterAddition), nil
 	case uint64:
-		valueAfterAddition := valueCopy.Add(valueCopy, big.NewInt(0).SetUint64(t))
-
-		// if the addition does not stay within the bounds of an uint64, we have an overflow and report an error
-		if !valueAfterAddition.IsUint64() {
-			return nil, fmt.Errorf("%s, %v + %v would overflow uint64", errors.R4, t, value.Value.Uint64())
+		valueAfterAddition, err := value.AddToUInt64(t)
+		if err != nil {
+			return nil, err
 		}
-		return fix.NewRawValue(valueAfterAddition.Uint64()), nil
+		return fix.NewRawValue(valueAfterAddition), nil
 	}
 
 	return fix.NullValue{}, fmt.Errorf("unsupported type to add big int to: %#v", toAdd.Get())
 }
 
+// AddToInt64 adds the read delta to the base value, returning an error if the result does not fit within an int64
+func (value BigInt) AddToInt64(baseValue int64) (int64, error) {
+	valueCopy := big.NewInt(0).Set(value.Value)
+	valueAfterAddition := valueCopy.Add(valueCopy, big.NewInt(baseValue))
+
+	// if the addition does not stay within the bounds of an int64, we have an overflow and report an error
+	if !valueAfterAddition.IsInt64() {
+		return 0, fmt.Errorf("%s, %v + %v would overflow int64", errors.R4, baseValue, value.Value.Int64())
+	}
+	return valueAfterAddition.Int64(), nil
+}
+
+// AddToUInt64 adds the read delta to the base value, returning an error if the result does not fit within a uint64
+func (value BigInt) AddToUInt64(baseValue uint64) (uint64, error) {
+	valueCopy := big.NewInt(0).Set(value.Value)
+	valueAfterAddition := valueCopy.Add(valueCopy, big.NewInt(0).SetUint64(baseValue))
+
+	// if the addition does not stay within the bounds of an uint64, we have an overflow and report an error
+	if !valueAfterAddition.IsUint64() {
+		return 0, fmt.Errorf("%s, %v + %v would overflow uint64", errors.R4, baseValue, value.Value.Uint64())
+	}
+	return valueAfterAddition.Uint64(), nil
+}
+
 func addValueWithinUInt32Constraints(readValue int64, value int64) (fix.Value, error) {
+	valueAfterAddition, err := addWithinUInt32Constraints(readValue, value)
+	if err != nil {
+		return nil, err
+	}
+	return fix.NewRawValue(valueAfterAddition), nil
+}
+
+func addWithinUInt32Constraints(readValue int64, value int64) (uint32, error) {
 	// positive value and value you add is greater than the difference between the positive value and the max value, you will positive overflow
 	if readValue > 0 && uint64(value) > uint64(math.MaxUint32)-uint64(readValue) {
-		return nil, fmt.Errorf("%s, %v + %v would overflow uint32", errors.R4, readValue, value)
+		return 0, fmt.Errorf("%s, %v + %v would overflow uint32", errors.R4, readValue, value)
 	}
 	// if subtracting the value would take us below 0, you will negative overflow
 	if 0 > value+readValue {
-		return nil, fmt.Errorf("%s, %v + %v would overflow uint32", errors.R4, readValue, value)
+		return 0, fmt.Errorf("%s, %v + %v would overflow uint32", errors.R4, readValue, value)
 	}
 
-	return fix.NewRawValue(uint32(readValue + value)), nil
+	return uint32(readValue + value), nil
 }
 
 func addValueWithinInt32Constraints(readValue int64, value int64) (fix.Value, error) {
+	valueAfterAddition, err := addWithinInt32Constraints(readValue, value)
+	if err != nil {
+		return nil, err
+	}
+	return fix.NewRawValue(valueAfterAddition), nil
+}
+
+func addWithinInt32Constraints(readValue int64, value int64) (int32, error) {
 	// positive value and value you add is greater than the difference between the positive value and the max value, you will positive overflow
 	if readValue > 0 && value > math.MaxInt32-readValue {
-		return nil, fmt.Errorf("%s, %v + %v would overflow int32", errors.R4, readValue, value)
+		return 0, fmt.Errorf("%s, %v + %v would overflow int32", errors.R4, readValue, value)
 	}
 	// negative value and you're add is greater than the difference between the negative value and the min value, you will negative overflow
 	if value < math.MinInt32-readValue {
-		return nil, fmt.Errorf("%s, %v + %v would overflow int32", errors.R4, readValue, value)
+		return 0, fmt.Errorf("%s, %v + %v would overflow int32", errors.R4, readValue, value)
 	}
 
-	return fix.NewRawValue(int32(readValue + value)), nil
+	return int32(readValue + value), nil
+}
+
+// FromFix converts a fix value into its fast representation, ready to be encoded. Only raw go types that can be decoded from a FAST message are supported.
+func FromFix(fixValue fix.Value) (Value, error) {
+	switch t := fixValue.Get().(type) {
+	case nil:
+		return NullValue{}, nil
+	case uint32:
+		return UInt32Value{Value: t}, nil
+	case int32:
+		return Int32Value{Value: t}, nil
+	case uint64:
+		return UInt64Value{Value: t}, nil
+	case int64:
+		return Int64Value{Value: t}, nil
+	case string:
+		return StringValue{Value: t}, nil
+	case []byte:
+		return ByteVector{Value: t}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type to convert to fast value: %#v", fixValue.Get())
+}
+
+// Delta returns the difference between the value to encode and the base value, such that calling Add on the result with the base value gives back
+// the value to encode. Integers are differenced arithmetically, strings and byte vectors by the amount of the base value to remove from
+// either the front or back of the base value.
+func Delta(toEncode fix.Value, baseValue fix.Value) (Value, error) {
+	switch t := toEncode.Get().(type) {
+	case nil:
+		return NullValue{}, nil
+	case uint32:
+		base, ok := baseValue.Get().(uint32)
+		if !ok {
+			return nil, fmt.Errorf("unable to delta uint32 %d against base value: %#v", t, baseValue.Get())
+		}
+		return Int64Value{Value: int64(t) - int64(base)}, nil
+	case int32:
+		base, ok := baseValue.Get().(int32)
+		if !ok {
+			return nil, fmt.Errorf("unable to delta int32 %d against base value: %#v", t, baseValue.Get())
+		}
+		return Int64Value{Value: int64(t) - int64(base)}, nil
+	case uint64:
+		base, ok := baseValue.Get().(uint64)
+		if !ok {
+			return nil, fmt.Errorf("unable to delta uint64 %d against base value: %#v", t, baseValue.Get())
+		}
+		difference := big.NewInt(0).SetUint64(t)
+		return BigInt{Value: difference.Sub(difference, big.NewInt(0).SetUint64(base))}, nil
+	case int64:
+		base, ok := baseValue.Get().(int64)
+		if !ok {
+			return nil, fmt.Errorf("unable to delta int64 %d against base value: %#v", t, baseValue.Get())
+		}
+		difference := big.NewInt(t)
+		return BigInt{Value: difference.Sub(difference, big.NewInt(base))}, nil
+	case string:
+		base, ok := baseValue.Get().(string)
+		if !ok {
+			return nil, fmt.Errorf("unable to delta string %s against base value: %#v", t, baseValue.Get())
+		}
+		itemsToRemove, delta := deltaOf([]byte(t), []byte(base))
+		return StringValue{Value: string(delta), ItemsToRemove: itemsToRemove}, nil
+	case []byte:
+		base, ok := baseValue.Get().([]byte)
+		if !ok {
+			return nil, fmt.Errorf("unable to delta bytevector %#v against base value: %#v", t, baseValue.Get())
+		}
+		itemsToRemove, delta := deltaOf(t, base)
+		return ByteVector{Value: delta, ItemsToRemove: itemsToRemove}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type for delta: %#v", toEncode.Get())
+}
+
+// deltaOf works out the smallest delta to apply to the base value to get the value to encode, either by removing items from the end of the base value
+// and appending, or removing items from the front of the base value and prepending. Prepending is encoded as a negative number of items to remove, offset by 1.
+func deltaOf(toEncode []byte, baseValue []byte) (int32, []byte) {
+	commonPrefix := 0
+	for commonPrefix < len(toEncode) && commonPrefix < len(baseValue) && toEncode[commonPrefix] == baseValue[commonPrefix] {
+		commonPrefix++
+	}
+
+	commonSuffix := 0
+	for commonSuffix < len(toEncode) && commonSuffix < len(baseValue) && toEncode[len(toEncode)-1-commonSuffix] == baseValue[len(baseValue)-1-commonSuffix] {
+		commonSuffix++
+	}
+
+	if commonSuffix > commonPrefix {
+		return -int32(len(baseValue)-commonSuffix) - 1, toEncode[:len(toEncode)-commonSuffix]
+	}
+
+	return int32(len(baseValue) - commonPrefix), toEncode[commonPrefix:]
+}
+
+// Tail returns the part of the value to encode that differs from the end of the base value, such that calling ApplyTail on the result with the base
+// value gives back the value to encode. If the value to encode is shorter than the base value, it cannot be represented as a tail and an err is returned.
+func Tail(toEncode fix.Value, baseValue fix.Value) (Value, error) {
+	switch t := toEncode.Get().(type) {
+	case nil:
+		return NullValue{}, nil
+	case string:
+		base, ok := baseValue.Get().(string)
+		if !ok {
+			return nil, fmt.Errorf("unable to tail string %s against base value: %#v", t, baseValue.Get())
+		}
+		toEncodeAsChars := []rune(t)
+		baseValueAsChars := []rune(base)
+		tailStart, err := tailOf(len(toEncodeAsChars), len(baseValueAsChars), func(i int) bool { return toEncodeAsChars[i] == baseValueAsChars[i] })
+		if err != nil {
+			return nil, err
+		}
+		return StringValue{Value: string(toEncodeAsChars[tailStart:])}, nil
+	case []byte:
+		base, ok := baseValue.Get().([]byte)
+		if !ok {
+			return nil, fmt.Errorf("unable to tail bytevector %#v against base value: %#v", t, baseValue.Get())
+		}
+		tailStart, err := tailOf(len(t), len(base), func(i int) bool { return t[i] == base[i] })
+		if err != nil {
+			return nil, err
+		}
+		return ByteVector{Value: t[tailStart:]}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type for tail, you can only use this with strings and byte vectors: %#v", toEncode.Get())
+}
+
+// tailOf returns the index the tail starts at within the value to encode. A tail can only overwrite the end of the base value, or replace it entirely.
+func tailOf(toEncodeLength int, baseValueLength int, isEqualAt func(int) bool) (int, error) {
+	if toEncodeLength > baseValueLength || baseValueLength == 0 {
+		return 0, nil
+	}
+	if toEncodeLength < baseValueLength {
+		return 0, fmt.Errorf("value of length %d cannot be encoded as a tail of a base value of length %d", toEncodeLength, baseValueLength)
+	}
+
+	commonPrefix := 0
+	for commonPrefix < toEncodeLength && isEqualAt(commonPrefix) {
+		commonPrefix++
+	}
+	return commonPrefix, nil
 }
@@ -10,6 +10,8 @@ import (
 type Message struct {
 	Tags        map[uint64]Value
 	tagsInOrder []uint64
+	typed       []TypedValue
+	typedInUse  int
 }
 
 // SetTag with value
@@ -18,6 +20,32 @@ func (message *Message) SetTag(tag uint64, value Value) {
 	message.tagsInOrder = append(message.tagsInOrder, tag)
 }
 
+// SetTypedTag adds the tag to the message, returning the typed value to set as the value of the tag. The typed values of a message are reused once the
+// message is Reset, so setting a tag this way does not allocate once the message has held as many values before.
+func (message *Message) SetTypedTag(tag uint64) *TypedValue {
+	if message.typedInUse == len(message.typed) {
+		message.typed = append(message.typed, TypedValue{})
+	}
+
+	typedValue := &message.typed[message.typedInUse]
+	message.typedInUse++
+	message.SetTag(tag, typedValue)
+	return typedValue
+}
+
+// Reset the message to hold no tags, keeping the storage of its tags, typed values and repeating groups to be reused by the next message set into it.
+// Any value read from the message before it is Reset may be changed by the next message.
+func (message *Message) Reset() {
+	if message.Tags == nil {
+		message.Tags = make(map[uint64]Value)
+	}
+	for tag := range message.Tags {
+		delete(message.Tags, tag)
+	}
+	message.tagsInOrder = message.tagsInOrder[:0]
+	message.typedInUse = 0
+}
+
 // GetTag returns the value associated with the tag.
 // This can be: nil, int32, uint32, int64, uint64, []byte, string, []Message (for sequences)
 func (message Message) GetTag(tag uint64) (interface{}, error) {
@@ -29,6 +57,8 @@ func (message Message) GetTag(tag uint64) (interface{}, error) {
 			return t.Get(), nil
 		case RawValue:
 			return t.Get(), nil
+		case *TypedValue:
+			return t.Get(), nil
 		default:
 			return nil, fmt.Errorf("unsupported type of tag: %s", t)
 		}
@@ -138,3 +168,188 @@ func NewSequenceValue(sequenceSize uint32) SequenceValue {
 
 	return value
 }
+
+// Kind of the value held by a TypedValue
+type Kind uint8
+
+const (
+	// NullKind is an explicit null value
+	NullKind Kind = iota
+	// UInt32Kind and UInt64Kind are held in Unsigned
+	UInt32Kind
+	UInt64Kind
+	// Int32Kind and Int64Kind are held in Signed
+	Int32Kind
+	Int64Kind
+	// StringKind and ByteVectorKind are held in Bytes
+	StringKind
+	ByteVectorKind
+	// DecimalKind is held in Decimal
+	DecimalKind
+	// SequenceKind is held in Groups, one message per repeating group
+	SequenceKind
+)
+
+// TypedValue is a value held by its type, rather than boxed into an interface{} as RawValue is, so it can be set and reused without allocating.
+// Integers of either size are held in 64 bits, and strings are held as their bytes. The value is only boxed when Get is called.
+type TypedValue struct {
+	Kind     Kind
+	Unsigned uint64
+	Signed   int64
+	Decimal  float64
+	Bytes    []byte
+	Groups   []Message
+}
+
+// Get the value as the same raw go type a RawValue would hold, strings are copied out of Bytes while byte vectors are returned without copying
+func (typedValue *TypedValue) Get() interface{} {
+	switch typedValue.Kind {
+	case UInt32Kind:
+		return uint32(typedValue.Unsigned)
+	case UInt64Kind:
+		return typedValue.Unsigned
+	case Int32Kind:
+		return int32(typedValue.Signed)
+	case Int64Kind:
+		return typedValue.Signed
+	case StringKind:
+		return string(typedValue.Bytes)
+	case ByteVectorKind:
+		return typedValue.Bytes
+	case DecimalKind:
+		return typedValue.Decimal
+	case SequenceKind:
+		return typedValue.Groups
+	}
+
+	return nil
+}
+
+// String representation of the value, the same as the RawValue, NullValue or SequenceValue holding the same value
+func (typedValue *TypedValue) String() string {
+	switch typedValue.Kind {
+	case NullKind:
+		return NullValue{}.String()
+	case SequenceKind:
+		return SequenceValue{Values: typedValue.Groups}.String()
+	}
+
+	return RawValue{value: typedValue.Get()}.String()
+}
+
+// Boxed returns the value as a RawValue, NullValue or SequenceValue. Bytes are copied, so the value returned is not changed when the typed value is reused.
+func (typedValue *TypedValue) Boxed() Value {
+	switch typedValue.Kind {
+	case NullKind:
+		return NullValue{}
+	case ByteVectorKind:
+		return NewRawValue(append([]byte{}, typedValue.Bytes...))
+	case SequenceKind:
+		return SequenceValue{Values: typedValue.Groups}
+	}
+
+	return NewRawValue(typedValue.Get())
+}
+
+// Set the value to a copy of the scalar value held by source, reusing the bytes of the value
+func (typedValue *TypedValue) Set(source *TypedValue) {
+	typedValue.Kind = source.Kind
+	typedValue.Unsigned = source.Unsigned
+	typedValue.Signed = source.Signed
+	typedValue.Decimal = source.Decimal
+	typedValue.Bytes = append(typedValue.Bytes[:0], source.Bytes...)
+}
+
+// SetValue sets the value to a copy of the fix value, returning false if the fix value is not null or a raw go type a FAST message is decoded to
+func (typedValue *TypedValue) SetValue(value Value) bool {
+	if value == nil {
+		typedValue.SetNull()
+		return true
+	}
+
+	switch t := value.Get().(type) {
+	case nil:
+		typedValue.SetNull()
+	case uint32:
+		typedValue.SetUInt32(t)
+	case uint64:
+		typedValue.SetUInt64(t)
+	case int32:
+		typedValue.SetInt32(t)
+	case int64:
+		typedValue.SetInt64(t)
+	case string:
+		typedValue.Kind = StringKind
+		typedValue.Bytes = append(typedValue.Bytes[:0], t...)
+	case []byte:
+		typedValue.SetByteVector(t)
+	case float64:
+		typedValue.SetDecimal(t)
+	default:
+		return false
+	}
+	return true
+}
+
+// SetNull sets the value to null
+func (typedValue *TypedValue) SetNull() {
+	typedValue.Kind = NullKind
+}
+
+// SetUInt32 sets the value to the uint32
+func (typedValue *TypedValue) SetUInt32(value uint32) {
+	typedValue.Kind = UInt32Kind
+	typedValue.Unsigned = uint64(value)
+}
+
+// SetUInt64 sets the value to the uint64
+func (typedValue *TypedValue) SetUInt64(value uint64) {
+	typedValue.Kind = UInt64Kind
+	typedValue.Unsigned = value
+}
+
+// SetInt32 sets the value to the int32
+func (typedValue *TypedValue) SetInt32(value int32) {
+	typedValue.Kind = Int32Kind
+	typedValue.Signed = int64(value)
+}
+
+// SetInt64 sets the value to the int64
+func (typedValue *TypedValue) SetInt64(value int64) {
+	typedValue.Kind = Int64Kind
+	typedValue.Signed = value
+}
+
+// SetString sets the value to a string of the bytes, which are copied
+func (typedValue *TypedValue) SetString(value []byte) {
+	typedValue.Kind = StringKind
+	typedValue.Bytes = append(typedValue.Bytes[:0], value...)
+}
+
+// SetByteVector sets the value to a byte vector of the bytes, which are copied
+func (typedValue *TypedValue) SetByteVector(value []byte) {
+	typedValue.Kind = ByteVectorKind
+	typedValue.Bytes = append(typedValue.Bytes[:0], value...)
+}
+
+// SetDecimal sets the value to the decimal
+func (typedValue *TypedValue) SetDecimal(value float64) {
+	typedValue.Kind = DecimalKind
+	typedValue.Decimal = value
+}
+
+// SetSequence sets the value to a sequence of length repeating groups, returning the groups. Each group is Reset, reusing the groups the value held before.
+func (typedValue *TypedValue) SetSequence(length int) []Message {
+	if length > cap(typedValue.Groups) {
+		groups := make([]Message, length)
+		copy(groups, typedValue.Groups[:cap(typedValue.Groups)])
+		typedValue.Groups = groups
+	}
+
+	typedValue.Kind = SequenceKind
+	typedValue.Groups = typedValue.Groups[:length]
+	for index := range typedValue.Groups {
+		typedValue.Groups[index].Reset()
+	}
+	return typedValue.Groups
+}
@@ -0,0 +1,216 @@
+package fix
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// ValueUnmarshaler is implemented by types that convert a FIX value themselves, such as a fixed point decimal built from a decoded float64.
+// The value given is the same as returned by GetTag, and is never nil.
+type ValueUnmarshaler interface {
+	UnmarshalFIX(value interface{}) error
+}
+
+var valueUnmarshalerType = reflect.TypeOf((*ValueUnmarshaler)(nil)).Elem()
+
+// Unmarshal the message into v, which must be a pointer to a struct. Struct fields are filled from the tag given by their `fast:"<tag>"` struct tag, i.e. `fast:"270"`,
+// fields without a struct tag, or with `fast:"-"`, are skipped. Slices of structs are filled from the repeating groups of a sequence.
+// Values are converted to the type of the field, returning an error if they could not be without losing information. Integers can be converted to any integer
+// type they fit within, decimals to float64, or to float32 rounded to the nearest float32 as long as they do not overflow it, and strings and byte vectors
+// to strings and []byte, which are copied so the field is not changed by the next message decoded. Fields that are pointers or slices are set to nil if the value is nil,
+// and fields implementing ValueUnmarshaler convert the value themselves. Tags that are not in the message leave the field unchanged.
+func Unmarshal(message *Message, v interface{}) error {
+	return UnmarshalWithNames(message, v, nil)
+}
+
+// UnmarshalWithNames the message into v in the same way as Unmarshal, also allowing struct fields to be tagged with the name of a field, i.e. `fast:"MDEntryPx"`.
+// names maps the name of each field to its tag, as given in the template the message was decoded with.
+func UnmarshalWithNames(message *Message, v interface{}, names map[string]uint64) error {
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Ptr || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("unable to unmarshal message into %T, must be a non nil pointer to a struct", v)
+	}
+
+	return unmarshalMessage(message, target.Elem(), names)
+}
+
+func unmarshalMessage(message *Message, target reflect.Value, names map[string]uint64) error {
+	targetType := target.Type()
+	for i := 0; i < targetType.NumField(); i++ {
+		field := targetType.Field(i)
+		structTag, tagged := field.Tag.Lookup("fast")
+		if !tagged || structTag == "-" || field.PkgPath != "" {
+			continue
+		}
+
+		tag, err := resolveTag(structTag, names)
+		if err != nil {
+			return fmt.Errorf("unable to unmarshal field %s of %s, reason: %v", field.Name, targetType, err)
+		}
+
+		value, exists := message.Tags[tag]
+		if !exists {
+			continue
+		}
+		if err := unmarshalValue(value, target.Field(i), names); err != nil {
+			return fmt.Errorf("unable to unmarshal tag %d into field %s of %s, reason: %v", tag, field.Name, targetType, err)
+		}
+	}
+
+	return nil
+}
+
+// resolveTag of a struct field, which is either the tag itself or the name of a field within names
+func resolveTag(structTag string, names map[string]uint64) (uint64, error) {
+	if tag, err := strconv.ParseUint(structTag, 10, 64); err == nil {
+		return tag, nil
+	}
+	if tag, exists := names[structTag]; exists {
+		return tag, nil
+	}
+
+	return 0, fmt.Errorf("%s is not a tag, or the name of a field in the template", structTag)
+}
+
+func unmarshalValue(value Value, target reflect.Value, names map[string]uint64) error {
+	typedValue, isTyped := value.(*TypedValue)
+	if _, isNull := value.(NullValue); isNull || (isTyped && typedValue.Kind == NullKind) {
+		if target.Kind() == reflect.Ptr || target.Kind() == reflect.Slice {
+			target.Set(reflect.Zero(target.Type()))
+		}
+		return nil
+	}
+
+	if target.Kind() == reflect.Ptr {
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		target = target.Elem()
+	}
+
+	if target.CanAddr() && target.Addr().Type().Implements(valueUnmarshalerType) {
+		return target.Addr().Interface().(ValueUnmarshaler).UnmarshalFIX(value.Get())
+	}
+
+	switch t := value.(type) {
+	case SequenceValue:
+		return unmarshalSequence(t, target, names)
+	case RawValue:
+		return convert(t.Get(), target)
+	case *TypedValue:
+		if t.Kind == SequenceKind {
+			return unmarshalSequence(SequenceValue{Values: t.Groups}, target, names)
+		}
+		return convert(t.Get(), target)
+	}
+
+	return fmt.Errorf("unsupported value %#v", value)
+}
+
+func unmarshalSequence(sequence SequenceValue, target reflect.Value, names map[string]uint64) error {
+	if target.Kind() != reflect.Slice {
+		return fmt.Errorf("sequence can only be unmarshalled into a slice, not %s", target.Type())
+	}
+
+	groups := reflect.MakeSlice(target.Type(), len(sequence.Values), len(sequence.Values))
+	for index := range sequence.Values {
+		group := groups.Index(index)
+		if group.Kind() == reflect.Ptr {
+			group.Set(reflect.New(group.Type().Elem()))
+			group = group.Elem()
+		}
+		if group.Kind() != reflect.Struct {
+			return fmt.Errorf("sequence can only be unmarshalled into a slice of structs, not %s", target.Type())
+		}
+
+		if err := unmarshalMessage(&sequence.Values[index], group, names); err != nil {
+			return fmt.Errorf("repeating group [%d], %v", index, err)
+		}
+	}
+
+	target.Set(groups)
+	return nil
+}
+
+// convert the raw value into the target, checking the value can be represented by the type of the target
+func convert(value interface{}, target reflect.Value) error {
+	switch t := value.(type) {
+	case uint32:
+		return convertUnsigned(uint64(t), target)
+	case uint64:
+		return convertUnsigned(t, target)
+	case int32:
+		return convertSigned(int64(t), target)
+	case int64:
+		return convertSigned(t, target)
+	case float64:
+		switch target.Kind() {
+		case reflect.Float64:
+			target.SetFloat(t)
+			return nil
+		case reflect.Float32:
+			if math.Abs(t) > math.MaxFloat32 {
+				return fmt.Errorf("%v overflows %s", t, target.Type())
+			}
+			target.SetFloat(t)
+			return nil
+		}
+	case string:
+		if target.Kind() == reflect.String {
+			target.SetString(t)
+			return nil
+		}
+	case []byte:
+		if target.Kind() == reflect.Slice && target.Type().Elem().Kind() == reflect.Uint8 {
+			// the bytes may be a view into the message decoded, or storage reused by the next message decoded, so the field holds a copy
+			target.SetBytes(append([]byte(nil), t...))
+			return nil
+		}
+		if target.Kind() == reflect.String {
+			target.SetString(string(t))
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unable to convert %T to %s", value, target.Type())
+}
+
+func convertUnsigned(value uint64, target reflect.Value) error {
+	switch target.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if target.OverflowUint(value) {
+			return fmt.Errorf("%d overflows %s", value, target.Type())
+		}
+		target.SetUint(value)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if value > math.MaxInt64 || target.OverflowInt(int64(value)) {
+			return fmt.Errorf("%d overflows %s", value, target.Type())
+		}
+		target.SetInt(int64(value))
+		return nil
+	}
+
+	return fmt.Errorf("unable to convert unsigned integer %d to %s", value, target.Type())
+}
+
+func convertSigned(value int64, target reflect.Value) error {
+	switch target.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if target.OverflowInt(value) {
+			return fmt.Errorf("%d overflows %s", value, target.Type())
+		}
+		target.SetInt(value)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if value < 0 || target.OverflowUint(uint64(value)) {
+			return fmt.Errorf("%d overflows %s", value, target.Type())
+		}
+		target.SetUint(uint64(value))
+		return nil
+	}
+
+	return fmt.Errorf("unable to convert integer %d to %s", value, target.Type())
+}
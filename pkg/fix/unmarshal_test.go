@@ -0,0 +1,299 @@
+package fix
+
+import (
+	"fmt"
+	"testing"
+)
+
+type price struct {
+	mantissa int64
+}
+
+func (p *price) UnmarshalFIX(value interface{}) error {
+	asFloat, ok := value.(float64)
+	if !ok {
+		return fmt.Errorf("price must be a decimal, not %T", value)
+	}
+	p.mantissa = int64(asFloat * 100)
+	return nil
+}
+
+type side string
+
+func TestUnmarshalConvertsValuesToFieldTypes(t *testing.T) {
+	// Arrange
+	message := New()
+	message.SetTag(34, NewRawValue(uint32(10)))
+	message.SetTag(52, NewRawValue(uint64(20200101)))
+	message.SetTag(264, NewRawValue(int32(-5)))
+	message.SetTag(270, NewRawValue(float64(3999.96)))
+	message.SetTag(269, NewRawValue("0"))
+	message.SetTag(96, NewRawValue([]byte("raw")))
+	var target struct {
+		MsgSeqNum   int     `fast:"34"`
+		SendingTime uint64  `fast:"52"`
+		MarketDepth int8    `fast:"264"`
+		Px          float64 `fast:"270"`
+		Side        side    `fast:"269"`
+		RawData     string  `fast:"96"`
+		Ignored     string  `fast:"-"`
+		Missing     uint32  `fast:"999"`
+	}
+
+	// Act
+	err := Unmarshal(&message, &target)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+	if target.MsgSeqNum != 10 || target.SendingTime != 20200101 || target.MarketDepth != -5 || target.Px != 3999.96 ||
+		target.Side != "0" || target.RawData != "raw" || target.Ignored != "" || target.Missing != 0 {
+		t.Errorf("Expected every tagged field to be unmarshalled, actual: %+v", target)
+	}
+}
+
+func TestUnmarshalReturnsErrorWhenIntegerOverflowsField(t *testing.T) {
+	// Arrange
+	message := New()
+	message.SetTag(34, NewRawValue(uint32(300)))
+	var target struct {
+		MsgSeqNum uint8 `fast:"34"`
+	}
+
+	// Act
+	err := Unmarshal(&message, &target)
+
+	// Assert
+	if err == nil || err.Error() != "unable to unmarshal tag 34 into field MsgSeqNum of struct { MsgSeqNum uint8 \"fast:\\\"34\\\"\" }, reason: 300 overflows uint8" {
+		t.Errorf("Expected an overflow error, actual: %v", err)
+	}
+}
+
+func TestUnmarshalReturnsErrorWhenNegativeIntegerIntoUnsignedField(t *testing.T) {
+	// Arrange
+	message := New()
+	message.SetTag(264, NewRawValue(int64(-1)))
+	var target struct {
+		MarketDepth uint64 `fast:"264"`
+	}
+
+	// Act
+	err := Unmarshal(&message, &target)
+
+	// Assert
+	if err == nil {
+		t.Errorf("Expected an error unmarshalling a negative integer into an unsigned field")
+	}
+}
+
+func TestUnmarshalReturnsErrorWhenDecimalIntoIntegerField(t *testing.T) {
+	// Arrange
+	message := New()
+	message.SetTag(270, NewRawValue(float64(1.5)))
+	var target struct {
+		Px int64 `fast:"270"`
+	}
+
+	// Act
+	err := Unmarshal(&message, &target)
+
+	// Assert
+	if err == nil {
+		t.Errorf("Expected an error unmarshalling a decimal into an integer field")
+	}
+}
+
+func TestUnmarshalNullValuesIntoPointers(t *testing.T) {
+	// Arrange
+	message := New()
+	message.SetTag(270, NullValue{})
+	message.SetTag(271, NewRawValue(int64(257510)))
+	existing := 1.0
+	var target struct {
+		Px   *float64 `fast:"270"`
+		Size *int64   `fast:"271"`
+	}
+	target.Px = &existing
+
+	// Act
+	err := Unmarshal(&message, &target)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+	if target.Px != nil {
+		t.Errorf("Expected a null value to set the pointer to nil, actual: %v", *target.Px)
+	}
+	if target.Size == nil || *target.Size != 257510 {
+		t.Errorf("Expected the pointer to be allocated with the value, actual: %v", target.Size)
+	}
+}
+
+func TestUnmarshalUsesValueUnmarshaler(t *testing.T) {
+	// Arrange
+	message := New()
+	message.SetTag(270, NewRawValue(float64(3999.96)))
+	var target struct {
+		Px  price  `fast:"270"`
+		Ptr *price `fast:"270"`
+	}
+
+	// Act
+	err := Unmarshal(&message, &target)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+	if target.Px.mantissa != 399996 || target.Ptr == nil || target.Ptr.mantissa != 399996 {
+		t.Errorf("Expected the price to be unmarshalled by UnmarshalFIX, actual: %+v", target)
+	}
+}
+
+func TestUnmarshalSequenceIntoSliceOfStructs(t *testing.T) {
+	// Arrange
+	message := New()
+	entries := NewSequenceValue(2)
+	entries.SetValue(0, 269, NewRawValue("0"))
+	entries.SetValue(0, 270, NewRawValue(float64(10.5)))
+	entries.SetValue(1, 269, NewRawValue("1"))
+	entries.SetValue(1, 270, NullValue{})
+	message.SetTag(268, entries)
+	type entry struct {
+		Type string   `fast:"MDEntryType"`
+		Px   *float64 `fast:"MDEntryPx"`
+	}
+	var target struct {
+		Entries   []entry  `fast:"MDEntries"`
+		EntryPtrs []*entry `fast:"268"`
+	}
+	names := map[string]uint64{"MDEntries": 268, "MDEntryType": 269, "MDEntryPx": 270}
+
+	// Act
+	err := UnmarshalWithNames(&message, &target, names)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+	if len(target.Entries) != 2 || target.Entries[0].Type != "0" || *target.Entries[0].Px != 10.5 || target.Entries[1].Type != "1" || target.Entries[1].Px != nil {
+		t.Errorf("Expected both repeating groups to be unmarshalled, actual: %+v", target.Entries)
+	}
+	if len(target.EntryPtrs) != 2 || target.EntryPtrs[1].Type != "1" {
+		t.Errorf("Expected both repeating groups to be unmarshalled into pointers, actual: %+v", target.EntryPtrs)
+	}
+}
+
+func TestUnmarshalReturnsErrorForUnknownName(t *testing.T) {
+	// Arrange
+	message := New()
+	var target struct {
+		Px float64 `fast:"MDEntryPx"`
+	}
+
+	// Act
+	err := Unmarshal(&message, &target)
+
+	// Assert
+	if err == nil {
+		t.Errorf("Expected an error for a name that is not within the template")
+	}
+}
+
+func TestUnmarshalReturnsErrorWhenNotPointerToStruct(t *testing.T) {
+	// Arrange
+	message := New()
+	var target struct{}
+
+	// Act
+	err := Unmarshal(&message, target)
+
+	// Assert
+	if err == nil {
+		t.Errorf("Expected an error when not given a pointer to a struct")
+	}
+}
+
+func TestUnmarshalTypedValuesOfReusedMessage(t *testing.T) {
+	// Arrange
+	message := New()
+	message.SetTypedTag(34).SetUInt32(1)
+	message.Reset()
+	message.SetTypedTag(34).SetUInt32(10)
+	message.SetTypedTag(269).SetString([]byte("0"))
+	message.SetTypedTag(270).SetNull()
+	groups := message.SetTypedTag(268).SetSequence(2)
+	groups[0].SetTypedTag(271).SetInt64(5)
+	groups[1].SetTypedTag(271).SetInt64(6)
+	var target struct {
+		MsgSeqNum int      `fast:"34"`
+		Side      string   `fast:"269"`
+		Px        *float64 `fast:"270"`
+		Entries   []struct {
+			Size int64 `fast:"271"`
+		} `fast:"268"`
+	}
+
+	// Act
+	err := Unmarshal(&message, &target)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+	if target.MsgSeqNum != 10 || target.Side != "0" || target.Px != nil || len(target.Entries) != 2 || target.Entries[1].Size != 6 {
+		t.Errorf("Expected every typed value to be unmarshalled, actual: %+v", target)
+	}
+	if expected := "34=10|269=0|270=nil|268=2|271=5|271=6|"; message.String() != expected {
+		t.Errorf("Expected typed values to be written as raw values, expected: %s, actual: %s", expected, message.String())
+	}
+}
+
+func TestUnmarshalCopiesByteVectorsOutOfTheMessage(t *testing.T) {
+	// Arrange
+	message := New()
+	message.SetTypedTag(96).SetByteVector([]byte("raw"))
+	var target struct {
+		RawData []byte `fast:"96"`
+	}
+
+	// Act
+	err := Unmarshal(&message, &target)
+	message.Reset()
+	message.SetTypedTag(96).SetByteVector([]byte("new"))
+
+	// Assert
+	if err != nil {
+		t.Errorf("Got an error when none was expected: %s", err)
+	}
+	if string(target.RawData) != "raw" {
+		t.Errorf("Expected the field to keep the byte vector once the message is reused, actual: %s", target.RawData)
+	}
+}
+
+func TestUnmarshalRoundsDecimalIntoFloat32Field(t *testing.T) {
+	// Arrange
+	message := New()
+	message.SetTag(270, NewRawValue(float64(3999.96)))
+	message.SetTag(271, NewRawValue(float64(1e39)))
+	var target struct {
+		Px float32 `fast:"270"`
+	}
+	var overflowing struct {
+		Size float32 `fast:"271"`
+	}
+
+	// Act
+	err := Unmarshal(&message, &target)
+	overflowErr := Unmarshal(&message, &overflowing)
+
+	// Assert
+	if err != nil || target.Px != float32(3999.96) {
+		t.Errorf("Expected the decimal to be rounded to the nearest float32, actual: %v, error: %v", target.Px, err)
+	}
+	if overflowErr == nil {
+		t.Errorf("Expected an error when the decimal overflows a float32 field")
+	}
+}